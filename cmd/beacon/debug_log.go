@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+// redactedHeaders carry credentials and are logged with only their scheme.
+var redactedHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+}
+
+type requestLogLine struct {
+	StartedAt  time.Time           `json:"started_at"`
+	DurationMS float64             `json:"duration_ms"`
+	Method     string              `json:"method"`
+	URL        string              `json:"url"`
+	Status     int                 `json:"status,omitempty"`
+	Headers    map[string][]string `json:"headers,omitempty"`
+}
+
+// makeJSONRequestLogger writes each request as one JSON object per line.
+// Clients log from several goroutines, so writes are serialized.
+func makeJSONRequestLogger(w io.Writer) registry.RequestLogger {
+	var mu sync.Mutex
+	encoder := json.NewEncoder(w)
+	return func(log registry.RequestLog) {
+		line := requestLogLine{
+			StartedAt:  log.StartedAt,
+			DurationMS: float64(log.Duration.Microseconds()) / 1000,
+			Method:     log.Method,
+			URL:        log.URL,
+			Status:     log.Status,
+			Headers:    redactHeaders(log.Headers),
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		_ = encoder.Encode(line)
+	}
+}
+
+func redactHeaders(headers map[string][]string) map[string][]string {
+	if len(headers) == 0 {
+		return nil
+	}
+	out := make(map[string][]string, len(headers))
+	for key, values := range headers {
+		if !redactedHeaders[key] {
+			out[key] = values
+			continue
+		}
+		redacted := make([]string, len(values))
+		for i, value := range values {
+			scheme, _, found := strings.Cut(value, " ")
+			if found {
+				redacted[i] = scheme + " <redacted>"
+			} else {
+				redacted[i] = "<redacted>"
+			}
+		}
+		out[key] = redacted
+	}
+	return out
+}
+
+func chainRequestLoggers(loggers ...registry.RequestLogger) registry.RequestLogger {
+	return func(log registry.RequestLog) {
+		for _, logger := range loggers {
+			logger(log)
+		}
+	}
+}
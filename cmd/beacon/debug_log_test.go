@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+func TestJSONRequestLoggerRedactsCredentials(t *testing.T) {
+	var buf bytes.Buffer
+	logger := makeJSONRequestLogger(&buf)
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	logger(registry.RequestLog{
+		Method:    "GET",
+		URL:       "https://registry.example.com/v2/_catalog",
+		Status:    200,
+		StartedAt: at,
+		Duration:  142 * time.Millisecond,
+		Headers:   map[string][]string{"Authorization": {"Bearer secret-token"}, "Accept": {"application/json"}},
+	})
+	logger(registry.RequestLog{Method: "POST", URL: "https://registry.example.com/token", StartedAt: at})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected one line per request, got %q", buf.String())
+	}
+	if strings.Contains(lines[0], "secret-token") {
+		t.Fatalf("expected the token to be redacted, got %s", lines[0])
+	}
+	var got requestLogLine
+	if err := json.Unmarshal([]byte(lines[0]), &got); err != nil {
+		t.Fatalf("invalid JSON line %q: %v", lines[0], err)
+	}
+	if got.Method != "GET" || got.Status != 200 || !got.StartedAt.Equal(at) || got.DurationMS != 142 {
+		t.Fatalf("unexpected entry %+v", got)
+	}
+	if auth := got.Headers["Authorization"]; len(auth) != 1 || auth[0] != "Bearer <redacted>" {
+		t.Fatalf("expected the auth scheme to survive redaction, got %v", auth)
+	}
+	if accept := got.Headers["Accept"]; len(accept) != 1 || accept[0] != "application/json" {
+		t.Fatalf("expected other headers untouched, got %v", accept)
+	}
+}
+
+func TestFormatRequestLogShowsDuration(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		want     string
+	}{
+		{duration: 142 * time.Millisecond, want: "GET https://registry.example.com/v2/ -> 200 (142ms)"},
+		{duration: 2340 * time.Millisecond, want: "GET https://registry.example.com/v2/ -> 200 (2.3s)"},
+		{want: "GET https://registry.example.com/v2/ -> 200"},
+	}
+	for _, tt := range tests {
+		got := formatRequestLog(registry.RequestLog{Method: "GET", URL: "https://registry.example.com/v2/", Status: 200, Duration: tt.duration})
+		if got != tt.want {
+			t.Fatalf("expected %q, got %q", tt.want, got)
+		}
+	}
+}
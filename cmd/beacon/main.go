@@ -1,59 +1,147 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"runtime/debug"
 	"sort"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 
+	"github.com/scottbass3/beacon/internal/config"
 	"github.com/scottbass3/beacon/internal/contextstore"
 	"github.com/scottbass3/beacon/internal/registry"
 	"github.com/scottbass3/beacon/internal/tui"
 )
 
+// version is set at build time with -ldflags "-X main.version=v1.2.3".
+var version = "dev"
+
 func main() {
 	var registryHost string
 	var configPath string
-	var debug bool
+	var showLog bool
+	var timeZone string
+	var printVersion bool
+	var debugLogPath string
+	var compact bool
+	var printSelection bool
 	flag.StringVar(&registryHost, "registry", "", "Registry host (e.g. https://registry.example.com)")
 	flag.StringVar(&configPath, "config", "", "Path to config file (defaults to $XDG_CONFIG_HOME/beacon/config.json)")
-	flag.BoolVar(&debug, "debug", false, "Enable request logging")
+	flag.BoolVar(&showLog, "debug", false, "Show the request log at startup (toggle with :debug)")
+	flag.StringVar(&timeZone, "tz", "", "Time zone for timestamps: local, UTC or an IANA name (overrides the time_zone setting)")
+	flag.StringVar(&debugLogPath, "debug-log", "", "Append every registry request as a JSON line to this file (credentials redacted)")
+	flag.BoolVar(&compact, "compact", false, "Start in the compact layout (toggle with :compact)")
+	flag.BoolVar(&printSelection, "print-selection", false, "Quit on Enter over a tag and print its full reference to stdout")
+	flag.BoolVar(&printVersion, "version", false, "Print the Beacon version and exit")
 	flag.Parse()
 
+	if printVersion {
+		fmt.Println("beacon", buildVersion())
+		return
+	}
+
+	// Requests are always logged so :debug can show the panel mid-session.
 	logCh := make(chan string, 256)
-	logger := registry.RequestLogger(nil)
-	if debug {
-		logger = makeRequestLogger(logCh)
-	} else {
-		close(logCh)
-		logCh = nil
+	logger := makeRequestLogger(logCh)
+	if debugLogPath != "" {
+		file, err := os.OpenFile(debugLogPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "open --debug-log: %v\n", err)
+			os.Exit(2)
+		}
+		defer file.Close()
+		logger = chainRequestLoggers(logger, makeJSONRequestLogger(file))
 	}
 
 	auth, host, contexts, currentContext, resolvedConfigPath, err := resolveRegistry(registryHost, configPath)
-	if err != nil {
+	var corrupt *config.CorruptError
+	if err != nil && !errors.As(err, &corrupt) {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(2)
 	}
 
-	program := tea.NewProgram(
-		tui.NewModel(host, auth, logger, debug, logCh, contexts, currentContext, resolvedConfigPath),
-		tea.WithAltScreen(),
-		tea.WithMouseCellMotion(),
-	)
-	if err := program.Start(); err != nil {
+	settings := contextstore.New(resolvedConfigPath).Settings()
+	if timeZone == "" {
+		timeZone = settings.TimeZone
+	}
+	if _, err := tui.ResolveLocation(timeZone); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid time zone %q: %v\n", timeZone, err)
+		os.Exit(2)
+	}
+	settings.TimeZone = timeZone
+	if settings.StatusColors != nil {
+		tui.SetStatusColors(*settings.StatusColors)
+	}
+
+	if printSelection {
+		// The UI is drawn on stderr while stdout is captured by the caller.
+		drawStylesOn(os.Stderr)
+	}
+	model := tui.NewModel(host, auth, logger, showLog, logCh, contexts, currentContext, resolvedConfigPath).
+		WithSettings(settings).
+		WithRecentContexts(contextstore.PushRecent(contextstore.LoadRecent(), currentContext)).
+		WithVersion(buildVersion()).
+		WithCompact(compact).
+		WithPrintSelection(printSelection)
+	if corrupt != nil {
+		model = model.WithConfigProblem(corrupt)
+	}
+	options := []tea.ProgramOption{tea.WithAltScreen(), tea.WithMouseCellMotion()}
+	if printSelection {
+		// Keep stdout clean for the picked reference.
+		options = append(options, tea.WithOutput(os.Stderr))
+	}
+	final, err := tea.NewProgram(model, options...).Run()
+	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
+	if printSelection {
+		picked, _ := final.(tui.Model)
+		if picked.Selection() == "" {
+			os.Exit(1)
+		}
+		fmt.Println(picked.Selection())
+	}
+}
+
+// drawStylesOn makes lipgloss detect colors from w instead of stdout. The
+// package-level styles keep the renderer they were built with, so the default
+// renderer is pointed at w rather than replaced.
+func drawStylesOn(w io.Writer) {
+	output := termenv.NewOutput(w)
+	renderer := lipgloss.DefaultRenderer()
+	renderer.SetOutput(output)
+	renderer.SetColorProfile(output.EnvColorProfile())
+}
+
+// buildVersion falls back to the module version for go install builds.
+func buildVersion() string {
+	if version != "dev" {
+		return version
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	return version
 }
 
+// resolveRegistry returns a *config.CorruptError alongside usable results
+// when the config file can't be parsed, so the UI can offer to repair it.
 func resolveRegistry(registryHost, configPath string) (registry.Auth, string, []tui.ContextOption, string, string, error) {
 	store := contextstore.New(configPath)
-	contextConfigs, err := store.Ensure()
-	if err != nil {
-		return registry.Auth{}, "", nil, "", store.Path(), err
+	contextConfigs, configErr := store.Ensure()
+	var corrupt *config.CorruptError
+	if configErr != nil && !errors.As(configErr, &corrupt) {
+		return registry.Auth{}, "", nil, "", store.Path(), configErr
 	}
 
 	contexts := make([]tui.ContextOption, 0, len(contextConfigs))
@@ -62,30 +150,40 @@ func resolveRegistry(registryHost, configPath string) (registry.Auth, string, []
 	}
 
 	if registryHost != "" {
-		return registry.Auth{
-			Kind: "registry_v2",
-			RegistryV2: registry.RegistryV2Auth{
-				Anonymous: true,
-			},
-		}, registryHost, contexts, "", store.Path(), nil
+		var err error
+		registryHost, err = contextstore.NormalizeRegistryURL(registryHost)
+		if err != nil {
+			return registry.Auth{}, "", nil, "", store.Path(), fmt.Errorf("invalid --registry: %w", err)
+		}
+		auth := registry.Auth{Kind: registry.KindForHost(registryHost)}
+		// gcr always authenticates with gcloud or a service account key.
+		auth.RegistryV2.Anonymous = auth.Kind != "gcr"
+		return auth, registryHost, contexts, "", store.Path(), configErr
 	}
 
 	if len(contextConfigs) == 0 {
-		return registry.Auth{}, "", contexts, "", store.Path(), nil
+		return registry.Auth{}, "", contexts, "", store.Path(), configErr
 	}
 
 	ctx := contextConfigs[0]
 	current := ctx.Name
-	return toContextOption(ctx).Auth, ctx.Host, contexts, current, store.Path(), nil
+	return toContextOption(ctx).Auth, ctx.Host, contexts, current, store.Path(), configErr
 }
 
 func toContextOption(ctx contextstore.Context) tui.ContextOption {
 	auth := ctx.Auth
 	auth.Normalize()
+	host := ctx.Host
+	// Hand-edited hosts that don't parse are kept so the connect error shows them.
+	if normalized, err := contextstore.NormalizeRegistryURL(host); err == nil {
+		host = normalized
+	}
 	return tui.ContextOption{
-		Name: ctx.Name,
-		Host: ctx.Host,
-		Auth: auth,
+		Name:        ctx.Name,
+		Host:        host,
+		Auth:        auth,
+		DefaultPath: ctx.DefaultPath,
+		Namespaces:  ctx.Namespaces,
 	}
 }
 
@@ -108,6 +206,9 @@ func formatRequestLog(log registry.RequestLog) string {
 		b.WriteString(" -> ")
 		b.WriteString(fmt.Sprintf("%d", log.Status))
 	}
+	if log.Duration > 0 {
+		b.WriteString(fmt.Sprintf(" (%s)", formatRequestDuration(log.Duration)))
+	}
 	if len(log.Headers) == 0 {
 		return b.String()
 	}
@@ -128,3 +229,12 @@ func formatRequestLog(log registry.RequestLog) string {
 	}
 	return b.String()
 }
+
+// formatRequestDuration keeps milliseconds for fast requests and switches to
+// tenths of a second past one second.
+func formatRequestDuration(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+	return fmt.Sprintf("%.1fs", d.Seconds())
+}
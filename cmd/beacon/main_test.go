@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+func TestRegistryFlagForGCRSendsBearer(t *testing.T) {
+	dir := t.TempDir()
+	keyPath := filepath.Join(dir, "key.json")
+	if err := os.WriteFile(keyPath, []byte(`{"type":"service_account"}`), 0o600); err != nil {
+		t.Fatalf("write key: %v", err)
+	}
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", keyPath)
+
+	auth, host, _, _, _, err := resolveRegistry("https://gcr.io", filepath.Join(dir, "config.json"))
+	if err != nil {
+		t.Fatalf("resolveRegistry: %v", err)
+	}
+	if host != "https://gcr.io" || auth.Kind != "gcr" || auth.RegistryV2.Anonymous {
+		t.Fatalf("expected authenticated gcr auth for gcr.io, got host %q auth %+v", host, auth)
+	}
+
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/token" {
+			_ = json.NewEncoder(w).Encode(map[string]string{"token": "exchanged"})
+			return
+		}
+		gotAuth = r.Header.Get("Authorization")
+		_ = json.NewEncoder(w).Encode(map[string][]string{"repositories": {"proj/app"}})
+	}))
+	defer server.Close()
+
+	// The test server stands in for gcr.io with the auth resolved above.
+	client, err := registry.NewClientWithLogger(server.URL, auth, nil)
+	if err != nil {
+		t.Fatalf("NewClientWithLogger: %v", err)
+	}
+	if _, err := client.ListImages(context.Background()); err != nil {
+		t.Fatalf("ListImages: %v", err)
+	}
+	if gotAuth != "Bearer exchanged" {
+		t.Fatalf("expected the catalog request to carry the gcr bearer, got %q", gotAuth)
+	}
+}
+
+func TestDrawStylesOnUpdatesExistingStyles(t *testing.T) {
+	renderer := lipgloss.DefaultRenderer()
+	previous := renderer.ColorProfile()
+	defer renderer.SetColorProfile(previous)
+	defer renderer.SetOutput(termenv.NewOutput(os.Stdout))
+	t.Setenv("CLICOLOR_FORCE", "1")
+
+	style := lipgloss.NewStyle().Bold(true)
+	renderer.SetColorProfile(termenv.Ascii)
+	drawStylesOn(&bytes.Buffer{})
+	if got := style.Render("pick"); got == "pick" {
+		t.Fatalf("expected a style built earlier to follow the new output's colors")
+	}
+}
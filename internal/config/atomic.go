@@ -0,0 +1,55 @@
+package config
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// writeData is swapped out in tests to simulate an interrupted write.
+var writeData = func(w io.Writer, data []byte) error {
+	_, err := w.Write(data)
+	return err
+}
+
+// writeFileAtomic writes data to a temp file next to path, syncs it and
+// renames it over path, so readers see either the old file or the new one
+// but never a truncated mix. A symlinked path is resolved first so the
+// link survives and its target gets the new contents.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	keep := false
+	defer func() {
+		if !keep {
+			_ = os.Remove(tmpPath)
+		}
+	}()
+
+	if err := writeData(tmp, data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return err
+	}
+	keep = true
+	return nil
+}
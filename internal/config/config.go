@@ -5,31 +5,134 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
 )
 
 type Config struct {
-	Contexts []Context `json:"contexts"`
+	Contexts []Context `json:"contexts" toml:"contexts" yaml:"contexts"`
+	Settings Settings  `json:"settings" toml:"settings,omitempty" yaml:"settings,omitempty"`
+}
+
+// Settings holds app-wide display defaults. The zero value keeps the
+// built-in defaults, and JSON configs without settings are still written as
+// a plain context array.
+type Settings struct {
+	CleanHistory bool   `json:"clean_history,omitempty" toml:"clean_history,omitempty" yaml:"clean_history,omitempty"`
+	GitHubToken  string `json:"github_token,omitempty" toml:"github_token,omitempty" yaml:"github_token,omitempty"`
+	// PullTool is the CLI used in copied pull commands: "docker" (default) or "podman".
+	PullTool string `json:"pull_tool,omitempty" toml:"pull_tool,omitempty" yaml:"pull_tool,omitempty"`
+	// WrapNavigation moves the cursor to the other end of a list when
+	// stepping past the first or last row.
+	WrapNavigation bool `json:"wrap_navigation,omitempty" toml:"wrap_navigation,omitempty" yaml:"wrap_navigation,omitempty"`
+	// TimeFormat is "absolute" (default) or "relative" for table timestamps.
+	TimeFormat string `json:"time_format,omitempty" toml:"time_format,omitempty" yaml:"time_format,omitempty"`
+	// TimeZone is "local" (default), "UTC" or an IANA zone name for absolute
+	// timestamps.
+	TimeZone string `json:"time_zone,omitempty" toml:"time_zone,omitempty" yaml:"time_zone,omitempty"`
+	// CatalogLimit caps how many repositories are loaded up front from a v2
+	// catalog; more load on demand. 0 uses the default, -1 loads everything.
+	CatalogLimit int `json:"catalog_limit,omitempty" toml:"catalog_limit,omitempty" yaml:"catalog_limit,omitempty"`
+	// TagCounts fetches tag counts for catalog repositories in the
+	// background, one tag list request per repository.
+	TagCounts bool `json:"tag_counts,omitempty" toml:"tag_counts,omitempty" yaml:"tag_counts,omitempty"`
+	// AutoRefresh re-runs the current view's refresh every N seconds from
+	// startup. 0 leaves it off until :watch is used.
+	AutoRefresh int `json:"auto_refresh,omitempty" toml:"auto_refresh,omitempty" yaml:"auto_refresh,omitempty"`
+	// HistoryLimit shows only the N most recent history entries until the
+	// rest are expanded. 0 shows everything.
+	HistoryLimit int `json:"history_limit,omitempty" toml:"history_limit,omitempty" yaml:"history_limit,omitempty"`
+	// StickyFilter keeps the filter text when moving between images, tags
+	// and history instead of clearing it.
+	StickyFilter bool `json:"sticky_filter,omitempty" toml:"sticky_filter,omitempty" yaml:"sticky_filter,omitempty"`
+	// StatusColors override the semantic colors used for health and
+	// severity indicators.
+	StatusColors *StatusColors `json:"status_colors,omitempty" toml:"status_colors,omitempty" yaml:"status_colors,omitempty"`
+	// DockerHubSkipResolve opens a bare Docker Hub name as library/<name>
+	// without the extra search request that resolves its namespace.
+	DockerHubSkipResolve bool `json:"dockerhub_skip_resolve,omitempty" toml:"dockerhub_skip_resolve,omitempty" yaml:"dockerhub_skip_resolve,omitempty"`
+	// Sort is the sort each view starts with.
+	Sort *SortSettings `json:"sort,omitempty" toml:"sort,omitempty" yaml:"sort,omitempty"`
+	// SeenWelcome is set once the first-run welcome screen was dismissed.
+	SeenWelcome bool `json:"seen_welcome,omitempty" toml:"seen_welcome,omitempty" yaml:"seen_welcome,omitempty"`
+}
+
+// StatusColors are ANSI color numbers ("78") or hex values ("#2ecc71");
+// empty fields keep the built-in color.
+type StatusColors struct {
+	OK       string `json:"ok,omitempty" toml:"ok,omitempty" yaml:"ok,omitempty"`
+	Warn     string `json:"warn,omitempty" toml:"warn,omitempty" yaml:"warn,omitempty"`
+	Critical string `json:"critical,omitempty" toml:"critical,omitempty" yaml:"critical,omitempty"`
+	Info     string `json:"info,omitempty" toml:"info,omitempty" yaml:"info,omitempty"`
+}
+
+// SortSettings name the starting sort per view: "size" for tags and
+// history, "count" for projects; anything else keeps the load order.
+type SortSettings struct {
+	Tags     string `json:"tags,omitempty" toml:"tags,omitempty" yaml:"tags,omitempty"`
+	History  string `json:"history,omitempty" toml:"history,omitempty" yaml:"history,omitempty"`
+	Projects string `json:"projects,omitempty" toml:"projects,omitempty" yaml:"projects,omitempty"`
 }
 
 type Context struct {
-	Name      string `json:"name"`
-	Registry  string `json:"registry"`
-	Kind      string `json:"kind"`
-	Anonymous bool   `json:"anonymous"`
-	Service   string `json:"service"`
+	Name      string `json:"name" toml:"name" yaml:"name"`
+	Registry  string `json:"registry" toml:"registry" yaml:"registry"`
+	Kind      string `json:"kind" toml:"kind" yaml:"kind"`
+	Anonymous bool   `json:"anonymous" toml:"anonymous" yaml:"anonymous"`
+	Service   string `json:"service" toml:"service" yaml:"service"`
+	Proxy     string `json:"proxy,omitempty" toml:"proxy,omitempty" yaml:"proxy,omitempty"`
+	// ManifestAccept overrides the manifest media types sent in Accept.
+	ManifestAccept []string `json:"manifest_accept,omitempty" toml:"manifest_accept,omitempty" yaml:"manifest_accept,omitempty"`
+	// Mirrors are fallback registry URLs tried when the registry host can't
+	// be reached.
+	Mirrors []string `json:"mirrors,omitempty" toml:"mirrors,omitempty" yaml:"mirrors,omitempty"`
+	// Robot marks Harbor credentials as a robot account (robot$project+name).
+	Robot bool `json:"robot,omitempty" toml:"robot,omitempty" yaml:"robot,omitempty"`
+	// Token is a pre-issued registry bearer token (deploy token, PAT).
+	Token string `json:"token,omitempty" toml:"token,omitempty" yaml:"token,omitempty"`
+	// DefaultPath is a project, namespace or image to open after connecting.
+	DefaultPath string `json:"default_path,omitempty" toml:"default_path,omitempty" yaml:"default_path,omitempty"`
+	// Namespaces limits the listed repositories and projects to these path
+	// prefixes. Repositories outside them can still be opened by name.
+	Namespaces []string `json:"namespaces,omitempty" toml:"namespaces,omitempty" yaml:"namespaces,omitempty"`
 }
 
+// DefaultPath returns config.json in the beacon config directory, or an
+// existing config.toml/config.yaml there when no JSON config exists.
 func DefaultPath() string {
+	dir := "."
 	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
-		return filepath.Join(xdg, "beacon", "config.json")
+		dir = filepath.Join(xdg, "beacon")
+	} else if home, err := os.UserHomeDir(); err == nil && home != "" {
+		dir = filepath.Join(home, ".config", "beacon")
 	}
-	if home, err := os.UserHomeDir(); err == nil && home != "" {
-		return filepath.Join(home, ".config", "beacon", "config.json")
+	return findConfigFile(dir)
+}
+
+func findConfigFile(dir string) string {
+	for _, name := range []string{"config.json", "config.toml", "config.yaml", "config.yml"} {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
 	}
-	return "config.json"
+	return filepath.Join(dir, "config.json")
+}
+
+// CorruptError reports a config file that exists but can't be parsed.
+type CorruptError struct {
+	Path string
+	Err  error
+}
+
+func (e *CorruptError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Path, e.Err)
+}
+
+func (e *CorruptError) Unwrap() error {
+	return e.Err
 }
 
 func Load(path string) (Config, error) {
@@ -38,9 +141,10 @@ func Load(path string) (Config, error) {
 		return Config{}, err
 	}
 
+	format := codecForPath(path)
 	var cfg Config
-	if err := json.Unmarshal(data, &cfg); err != nil {
-		return Config{}, fmt.Errorf("invalid config JSON: %w", err)
+	if err := format.Unmarshal(data, &cfg); err != nil {
+		return Config{}, &CorruptError{Path: path, Err: fmt.Errorf("invalid config %s: %w", format.Name(), err)}
 	}
 	if err := normalizeAndValidate(&cfg); err != nil {
 		return Config{}, err
@@ -65,15 +169,24 @@ func Ensure(path string) (Config, error) {
 	return cfg, nil
 }
 
+// Backup moves a config file aside to <path>.bak, replacing an older backup,
+// and returns the backup path.
+func Backup(path string) (string, error) {
+	backup := path + ".bak"
+	if err := os.Rename(path, backup); err != nil {
+		return "", fmt.Errorf("back up config file: %w", err)
+	}
+	return backup, nil
+}
+
 func Save(path string, cfg Config) error {
 	if err := normalizeAndValidate(&cfg); err != nil {
 		return err
 	}
-	data, err := json.MarshalIndent(cfg.Contexts, "", "  ")
+	data, err := codecForPath(path).Marshal(cfg)
 	if err != nil {
 		return fmt.Errorf("marshal config: %w", err)
 	}
-	data = append(data, '\n')
 
 	dir := filepath.Dir(path)
 	if dir != "." && dir != "" {
@@ -82,7 +195,7 @@ func Save(path string, cfg Config) error {
 		}
 	}
 
-	if err := os.WriteFile(path, data, 0o600); err != nil {
+	if err := writeFileAtomic(path, data, 0o600); err != nil {
 		return fmt.Errorf("write config file: %w", err)
 	}
 	return nil
@@ -105,11 +218,13 @@ func (c *Config) UnmarshalJSON(data []byte) error {
 	case '{':
 		var wrapper struct {
 			Contexts []Context `json:"contexts"`
+			Settings Settings  `json:"settings"`
 		}
 		if err := json.Unmarshal(trimmed, &wrapper); err != nil {
 			return err
 		}
 		c.Contexts = wrapper.Contexts
+		c.Settings = wrapper.Settings
 		return nil
 	default:
 		return fmt.Errorf("invalid config JSON: expected array at root")
@@ -122,12 +237,19 @@ func normalizeAndValidate(cfg *Config) error {
 		cfg.Contexts[i].Registry = strings.TrimSpace(cfg.Contexts[i].Registry)
 		cfg.Contexts[i].Kind = strings.TrimSpace(cfg.Contexts[i].Kind)
 		cfg.Contexts[i].Service = strings.TrimSpace(cfg.Contexts[i].Service)
+		cfg.Contexts[i].Proxy = strings.TrimSpace(cfg.Contexts[i].Proxy)
 		if cfg.Contexts[i].Registry == "" {
 			return fmt.Errorf("context %d missing registry", i+1)
 		}
 		if cfg.Contexts[i].Kind == "" {
 			return fmt.Errorf("context %d missing kind", i+1)
 		}
+		if proxy := cfg.Contexts[i].Proxy; proxy != "" {
+			parsed, err := url.Parse(proxy)
+			if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+				return fmt.Errorf("context %d has invalid proxy URL", i+1)
+			}
+		}
 	}
 	return nil
 }
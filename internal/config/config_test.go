@@ -0,0 +1,74 @@
+package config
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveInterruptedKeepsExistingFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	original := Config{Contexts: []Context{{Name: "prod", Registry: "https://registry.example.com", Kind: "registry_v2"}}}
+	if err := Save(path, original); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	before, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+
+	defer func(orig func(io.Writer, []byte) error) { writeData = orig }(writeData)
+	writeData = func(w io.Writer, data []byte) error {
+		_, _ = w.Write(data[:len(data)/2])
+		return errors.New("disk full")
+	}
+	updated := original
+	updated.Contexts = append(updated.Contexts, Context{Name: "staging", Registry: "https://staging.example.com", Kind: "harbor"})
+	if err := Save(path, updated); err == nil {
+		t.Fatalf("expected the interrupted save to fail")
+	}
+
+	after, err := os.ReadFile(path)
+	if err != nil || string(after) != string(before) {
+		t.Fatalf("expected the existing config untouched, got %q (%v)", after, err)
+	}
+	if _, err := Load(path); err != nil {
+		t.Fatalf("expected the existing config to still load: %v", err)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Fatalf("expected the temp file to be cleaned up, got %d entries", len(entries))
+	}
+}
+
+func TestSaveKeepsSymlinkedConfig(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "dotfiles", "config.json")
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		t.Fatalf("mkdir failed: %v", err)
+	}
+	if err := Save(target, Config{Contexts: []Context{{Name: "prod", Registry: "https://registry.example.com", Kind: "registry_v2"}}}); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	link := filepath.Join(dir, "config.json")
+	if err := os.Symlink(target, link); err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+
+	updated := Config{Contexts: []Context{{Name: "staging", Registry: "https://staging.example.com", Kind: "harbor"}}}
+	if err := Save(link, updated); err != nil {
+		t.Fatalf("save through link failed: %v", err)
+	}
+
+	info, err := os.Lstat(link)
+	if err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Fatalf("expected the config path to stay a symlink (%v)", err)
+	}
+	loaded, err := Load(target)
+	if err != nil || len(loaded.Contexts) != 1 || loaded.Contexts[0].Name != "staging" {
+		t.Fatalf("expected the link target to hold the new config, got %+v (%v)", loaded, err)
+	}
+}
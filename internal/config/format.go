@@ -0,0 +1,87 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// codec reads and writes a config file in one on-disk format.
+type codec interface {
+	Name() string
+	Marshal(cfg Config) ([]byte, error)
+	Unmarshal(data []byte, cfg *Config) error
+}
+
+// codecForPath picks the format from the file extension, defaulting to JSON.
+func codecForPath(path string) codec {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		return tomlCodec{}
+	case ".yaml", ".yml":
+		return yamlCodec{}
+	default:
+		return jsonCodec{}
+	}
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string { return "JSON" }
+
+func (jsonCodec) Marshal(cfg Config) ([]byte, error) {
+	var payload any = cfg.Contexts
+	if cfg.Settings != (Settings{}) {
+		payload = cfg
+	}
+	data, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func (jsonCodec) Unmarshal(data []byte, cfg *Config) error {
+	return json.Unmarshal(data, cfg)
+}
+
+type tomlCodec struct{}
+
+func (tomlCodec) Name() string { return "TOML" }
+
+func (tomlCodec) Marshal(cfg Config) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(cfg); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (tomlCodec) Unmarshal(data []byte, cfg *Config) error {
+	return toml.Unmarshal(data, cfg)
+}
+
+type yamlCodec struct{}
+
+func (yamlCodec) Name() string { return "YAML" }
+
+func (yamlCodec) Marshal(cfg Config) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(cfg); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (yamlCodec) Unmarshal(data []byte, cfg *Config) error {
+	return yaml.Unmarshal(data, cfg)
+}
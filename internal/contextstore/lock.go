@@ -0,0 +1,66 @@
+package contextstore
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+)
+
+// lock takes an exclusive advisory lock on <path>.lock, blocking until other
+// Beacon instances release it.
+func (s Store) lock() (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return nil, fmt.Errorf("create config directory: %w", err)
+	}
+	file, err := os.OpenFile(s.path+".lock", os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open config lock: %w", err)
+	}
+	if err := lockFile(file); err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("lock config: %w", err)
+	}
+	return func() {
+		_ = unlockFile(file)
+		_ = file.Close()
+	}, nil
+}
+
+// Update re-reads the contexts from disk under the config lock, applies fn
+// and saves the result. Edits made by another Beacon instance since this one
+// loaded the config are kept instead of being overwritten.
+func (s Store) Update(fn func(current []Context) ([]Context, error)) ([]Context, error) {
+	unlock, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	current, err := s.Ensure()
+	if err != nil {
+		return nil, err
+	}
+	updated, err := fn(current)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Save(updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// Changed reports whether two context lists differ in anything persisted to
+// the config file.
+func Changed(a, b []Context) bool {
+	if len(a) != len(b) {
+		return true
+	}
+	for i := range a {
+		if !reflect.DeepEqual(toConfigContext(a[i]), toConfigContext(b[i])) {
+			return true
+		}
+	}
+	return false
+}
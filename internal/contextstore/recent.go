@@ -0,0 +1,70 @@
+package contextstore
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MaxRecent caps how many recently used context names are remembered.
+const MaxRecent = 5
+
+func recentPath() string {
+	if xdg := os.Getenv("XDG_CACHE_HOME"); xdg != "" {
+		return filepath.Join(xdg, "beacon", "recent_contexts.json")
+	}
+	if home, err := os.UserHomeDir(); err == nil && home != "" {
+		return filepath.Join(home, ".cache", "beacon", "recent_contexts.json")
+	}
+	return "recent_contexts.json"
+}
+
+// LoadRecent returns the most recently used context names, newest first.
+// A missing or unreadable file yields no names.
+func LoadRecent() []string {
+	data, err := os.ReadFile(recentPath())
+	if err != nil {
+		return nil
+	}
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return nil
+	}
+	if len(names) > MaxRecent {
+		names = names[:MaxRecent]
+	}
+	return names
+}
+
+func SaveRecent(names []string) error {
+	path := recentPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(names)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// PushRecent moves name to the front of names, dropping duplicates
+// (case-insensitively) and anything past MaxRecent.
+func PushRecent(names []string, name string) []string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return names
+	}
+	out := make([]string, 0, MaxRecent)
+	out = append(out, name)
+	for _, existing := range names {
+		if len(out) == MaxRecent {
+			break
+		}
+		if !strings.EqualFold(existing, name) {
+			out = append(out, existing)
+		}
+	}
+	return out
+}
@@ -20,6 +20,12 @@ func (s Service) Save(contexts []Context) error {
 	return s.store.Save(contexts)
 }
 
+// Update applies fn to the contexts currently on disk under the config lock
+// and saves the result; see Store.Update.
+func (s Service) Update(fn func(current []Context) ([]Context, error)) ([]Context, error) {
+	return s.store.Update(fn)
+}
+
 func (s Service) Add(existing []Context, candidate Context) ([]Context, int, error) {
 	normalized, err := normalizeContext(candidate)
 	if err != nil {
@@ -85,35 +91,60 @@ func NormalizeKindInput(input string) (string, bool) {
 		return "registry_v2", true
 	case "harbor":
 		return "harbor", true
+	case "acr", "azure":
+		return "acr", true
+	case "gcr", "gar", "google":
+		return "gcr", true
 	default:
 		return "", false
 	}
 }
 
+// NormalizeRegistryURL cleans up a pasted registry address: it adds https://
+// when no scheme is given and drops trailing slashes and a trailing /v2.
+func NormalizeRegistryURL(input string) (string, error) {
+	trimmed := strings.TrimSpace(input)
+	if trimmed == "" {
+		return "", fmt.Errorf("registry is required")
+	}
+	parsed, err := registry.ParseRegistryURL(trimmed)
+	if err != nil {
+		return "", err
+	}
+	path := strings.TrimRight(parsed.Path, "/")
+	path = strings.TrimRight(strings.TrimSuffix(path, "/v2"), "/")
+	return parsed.Scheme + "://" + parsed.Host + path, nil
+}
+
 func normalizeContext(candidate Context) (Context, error) {
 	name := strings.TrimSpace(candidate.Name)
-	host := strings.TrimSpace(candidate.Host)
 	if name == "" {
 		return Context{}, fmt.Errorf("context name is required")
 	}
-	if host == "" {
-		return Context{}, fmt.Errorf("registry is required")
+	host, err := NormalizeRegistryURL(candidate.Host)
+	if err != nil {
+		return Context{}, err
 	}
 	kind, ok := NormalizeKindInput(candidate.Auth.Kind)
 	if !ok {
-		return Context{}, fmt.Errorf("kind must be registry_v2 or harbor")
+		return Context{}, fmt.Errorf("kind must be registry_v2, harbor, acr or gcr")
+	}
+	if err := registry.ValidateProxy(candidate.Auth.Proxy); err != nil {
+		return Context{}, err
 	}
-	auth := registry.Auth{Kind: kind}
+	auth := registry.Auth{Kind: kind, Proxy: candidate.Auth.Proxy, ManifestAccept: candidate.Auth.ManifestAccept, Mirrors: candidate.Auth.Mirrors}
 	switch kind {
 	case "harbor":
 		auth.Harbor.Anonymous = candidate.Auth.Harbor.Anonymous
 		auth.Harbor.Service = strings.TrimSpace(candidate.Auth.Harbor.Service)
+		auth.Harbor.Robot = candidate.Auth.Harbor.Robot
 	default:
 		auth.RegistryV2.Anonymous = candidate.Auth.RegistryV2.Anonymous
 		auth.RegistryV2.Service = strings.TrimSpace(candidate.Auth.RegistryV2.Service)
+		auth.RegistryV2.Token = strings.TrimSpace(candidate.Auth.RegistryV2.Token)
 	}
 	auth.Normalize()
-	return Context{Name: name, Host: host, Auth: auth}, nil
+	return Context{Name: name, Host: host, Auth: auth, DefaultPath: normalizeDefaultPath(candidate.DefaultPath), Namespaces: normalizeNamespaces(candidate.Namespaces)}, nil
 }
 
 func ensureUniqueName(existing []Context, name string, skip int) error {
@@ -1,8 +1,15 @@
 package contextstore
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
 	"testing"
 
+	"github.com/scottbass3/beacon/internal/config"
 	"github.com/scottbass3/beacon/internal/registry"
 )
 
@@ -86,3 +93,245 @@ func TestResolveByName(t *testing.T) {
 		t.Fatalf("expected to resolve by host, got ok=%v index=%d", ok, index)
 	}
 }
+
+func TestStoreRoundTripsDefaultPath(t *testing.T) {
+	store := New(filepath.Join(t.TempDir(), "config.json"))
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	if err := store.Save([]Context{{Name: "prod", Host: "https://registry.example.com", Auth: auth, DefaultPath: "/team/app/"}}); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	contexts, err := store.Ensure()
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if len(contexts) != 1 || contexts[0].DefaultPath != "team/app" {
+		t.Fatalf("expected normalized default path, got %+v", contexts)
+	}
+}
+
+func TestStoreRoundTripsManifestAccept(t *testing.T) {
+	store := New(filepath.Join(t.TempDir(), "config.json"))
+	auth := registry.Auth{Kind: "registry_v2", ManifestAccept: []string{"application/vnd.oci.image.manifest.v1+json"}}
+	if err := store.Save([]Context{{Name: "oci", Host: "https://registry.example.com", Auth: auth}}); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	contexts, err := store.Ensure()
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if len(contexts) != 1 || len(contexts[0].Auth.ManifestAccept) != 1 || contexts[0].Auth.ManifestAccept[0] != "application/vnd.oci.image.manifest.v1+json" {
+		t.Fatalf("expected the manifest accept override to round-trip, got %+v", contexts)
+	}
+}
+
+func TestStoreRoundTripsConfigFormats(t *testing.T) {
+	settings := map[string]string{
+		"config.toml": "[settings]\nwrap_navigation = true\ntime_format = \"relative\"\n",
+		"config.yaml": "settings:\n  wrap_navigation: true\n  time_format: relative\n",
+		"config.yml":  "settings:\n  wrap_navigation: true\n  time_format: relative\n",
+	}
+	for name, initial := range settings {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			if err := os.WriteFile(path, []byte(initial), 0o600); err != nil {
+				t.Fatalf("write failed: %v", err)
+			}
+			store := New(path)
+
+			harbor := registry.Auth{Kind: "harbor"}
+			v2 := registry.Auth{Kind: "registry_v2"}
+			v2.RegistryV2.Anonymous = true
+			v2.RegistryV2.Service = "registry.example.com"
+			want := []Context{
+				{Name: "harbor", Host: "https://harbor.example.com", Auth: harbor, DefaultPath: "library"},
+				{Name: "prod", Host: "https://registry.example.com", Auth: v2},
+			}
+			want[0].Auth.Proxy = "http://proxy.example.com:3128"
+			if err := store.Save(want); err != nil {
+				t.Fatalf("save failed: %v", err)
+			}
+
+			got, err := store.Ensure()
+			if err != nil {
+				t.Fatalf("load failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("round trip mismatch:\n got %+v\nwant %+v", got, want)
+			}
+			if s := store.Settings(); !s.WrapNavigation || s.TimeFormat != "relative" {
+				t.Fatalf("expected settings to survive save, got %+v", s)
+			}
+		})
+	}
+}
+
+func TestStoreResetsCorruptConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	broken := []byte(`[{"name": "prod", "registry": `)
+	if err := os.WriteFile(path, broken, 0o600); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	store := New(path)
+
+	var corrupt *config.CorruptError
+	if _, err := store.Ensure(); !errors.As(err, &corrupt) {
+		t.Fatalf("expected a corrupt config error, got %v", err)
+	}
+
+	backup, err := store.Reset()
+	if err != nil {
+		t.Fatalf("reset failed: %v", err)
+	}
+	if data, err := os.ReadFile(backup); err != nil || string(data) != string(broken) {
+		t.Fatalf("expected the broken file kept at %s, got %q (%v)", backup, data, err)
+	}
+	if contexts, err := store.Ensure(); err != nil || len(contexts) != 0 {
+		t.Fatalf("expected an empty config after reset, got %+v (%v)", contexts, err)
+	}
+}
+
+func TestStoreUpdateKeepsConcurrentEdits(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	service := NewService(path)
+
+	const writers = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := service.Update(func(current []Context) ([]Context, error) {
+				updated, _, err := service.Add(current, Context{Name: fmt.Sprintf("ctx-%d", i), Host: "https://registry.example.com", Auth: auth})
+				return updated, err
+			})
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("update failed: %v", err)
+		}
+	}
+
+	contexts, err := New(path).Ensure()
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if len(contexts) != writers {
+		t.Fatalf("expected every concurrent add to survive, got %d contexts", len(contexts))
+	}
+
+	stale := contexts[:1]
+	if !Changed(contexts, stale) || Changed(contexts, contexts) {
+		t.Fatalf("expected Changed to spot a stale list only")
+	}
+}
+
+func TestPushRecent(t *testing.T) {
+	tests := []struct {
+		name  string
+		names []string
+		push  string
+		want  []string
+	}{
+		{name: "empty", names: nil, push: "prod", want: []string{"prod"}},
+		{name: "moves to front", names: []string{"staging", "prod", "dev"}, push: "Prod", want: []string{"Prod", "staging", "dev"}},
+		{name: "caps", names: []string{"a", "b", "c", "d", "e"}, push: "f", want: []string{"f", "a", "b", "c", "d"}},
+		{name: "blank", names: []string{"a"}, push: " ", want: []string{"a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PushRecent(tt.names, tt.push); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRecentRoundTrip(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	if got := LoadRecent(); got != nil {
+		t.Fatalf("expected no recent contexts, got %v", got)
+	}
+	if err := SaveRecent([]string{"prod", "staging"}); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	if got := LoadRecent(); !reflect.DeepEqual(got, []string{"prod", "staging"}) {
+		t.Fatalf("unexpected recent contexts %v", got)
+	}
+}
+
+func TestNormalizeRegistryURL(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "registry.example.com", want: "https://registry.example.com"},
+		{input: " https://registry.example.com/ ", want: "https://registry.example.com"},
+		{input: "https://registry.example.com/v2/", want: "https://registry.example.com"},
+		{input: "http://localhost:5000/v2", want: "http://localhost:5000"},
+		{input: "https://example.com/registry//", want: "https://example.com/registry"},
+		{input: "", wantErr: true},
+		{input: "ftp://registry.example.com", wantErr: true},
+		{input: "https://", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := NormalizeRegistryURL(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %q", got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("expected %q, got %q (err %v)", tt.want, got, err)
+			}
+		})
+	}
+}
+
+func TestStoreRoundTripsNamespaces(t *testing.T) {
+	store := New(filepath.Join(t.TempDir(), "config.json"))
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	if err := store.Save([]Context{{Name: "prod", Host: "https://registry.example.com", Auth: auth, Namespaces: []string{"/team/", " ", "infra/base"}}}); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	contexts, err := store.Ensure()
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if len(contexts) != 1 || !reflect.DeepEqual(contexts[0].Namespaces, []string{"team", "infra/base"}) {
+		t.Fatalf("expected normalized namespaces, got %+v", contexts)
+	}
+}
+
+func TestContextWithoutKindUsesHostKind(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{host: "https://gcr.io", want: "gcr"},
+		{host: "https://europe-docker.pkg.dev", want: "gcr"},
+		{host: "https://registry.example.com", want: "registry_v2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			ctx := fromConfigContext(config.Context{Name: "x", Registry: tt.host})
+			if ctx.Auth.Kind != tt.want {
+				t.Fatalf("kind = %q, want %q", ctx.Auth.Kind, tt.want)
+			}
+		})
+	}
+}
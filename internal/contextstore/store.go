@@ -9,9 +9,11 @@ import (
 
 // Context is the app-level context configuration persisted to disk.
 type Context struct {
-	Name string
-	Host string
-	Auth registry.Auth
+	Name        string
+	Host        string
+	Auth        registry.Auth
+	DefaultPath string
+	Namespaces  []string
 }
 
 // Store persists registry contexts in the Beacon config file.
@@ -43,8 +45,51 @@ func (s Store) Ensure() ([]Context, error) {
 	return contextsFromConfig(cfg.Contexts), nil
 }
 
+// Reset moves an unreadable config file to <path>.bak and writes an empty
+// one in its place. It returns the backup path.
+func (s Store) Reset() (string, error) {
+	backup, err := config.Backup(s.path)
+	if err != nil {
+		return "", err
+	}
+	if _, err := config.Ensure(s.path); err != nil {
+		return backup, err
+	}
+	return backup, nil
+}
+
+// Settings returns the app-wide settings from the config file, or the
+// defaults when the file can't be read.
+func (s Store) Settings() config.Settings {
+	cfg, err := config.Load(s.path)
+	if err != nil {
+		return config.Settings{}
+	}
+	return cfg.Settings
+}
+
+// MarkWelcomeSeen records that the first-run welcome screen was dismissed,
+// keeping the contexts and other settings on disk as they are.
+func (s Store) MarkWelcomeSeen() error {
+	unlock, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	cfg, err := config.Ensure(s.path)
+	if err != nil {
+		return err
+	}
+	cfg.Settings.SeenWelcome = true
+	return config.Save(s.path, cfg)
+}
+
 func (s Store) Save(contexts []Context) error {
-	cfg := config.Config{Contexts: make([]config.Context, 0, len(contexts))}
+	cfg := config.Config{
+		Contexts: make([]config.Context, 0, len(contexts)),
+		Settings: s.Settings(),
+	}
 	for _, ctx := range contexts {
 		cfg.Contexts = append(cfg.Contexts, toConfigContext(ctx))
 	}
@@ -64,37 +109,81 @@ func contextsFromConfig(configContexts []config.Context) []Context {
 
 func fromConfigContext(ctx config.Context) Context {
 	kind := normalizeKind(ctx.Kind)
-	auth := registry.Auth{Kind: kind}
+	if strings.TrimSpace(ctx.Kind) == "" {
+		kind = registry.KindForHost(ctx.Registry)
+	}
+	auth := registry.Auth{Kind: kind, Proxy: ctx.Proxy, ManifestAccept: ctx.ManifestAccept, Mirrors: normalizeMirrors(ctx.Mirrors)}
 	switch kind {
 	case "harbor":
 		auth.Harbor.Anonymous = ctx.Anonymous
 		auth.Harbor.Service = strings.TrimSpace(ctx.Service)
+		auth.Harbor.Robot = ctx.Robot
 	default:
 		auth.RegistryV2.Anonymous = ctx.Anonymous
 		auth.RegistryV2.Service = strings.TrimSpace(ctx.Service)
+		auth.RegistryV2.Token = strings.TrimSpace(ctx.Token)
 	}
 	auth.Normalize()
 	return Context{
-		Name: strings.TrimSpace(ctx.Name),
-		Host: strings.TrimSpace(ctx.Registry),
-		Auth: auth,
+		Name:        strings.TrimSpace(ctx.Name),
+		Host:        strings.TrimSpace(ctx.Registry),
+		Auth:        auth,
+		DefaultPath: normalizeDefaultPath(ctx.DefaultPath),
+		Namespaces:  normalizeNamespaces(ctx.Namespaces),
 	}
 }
 
 func toConfigContext(ctx Context) config.Context {
 	kind := normalizeKind(ctx.Auth.Kind)
 	out := config.Context{
-		Name:     strings.TrimSpace(ctx.Name),
-		Registry: strings.TrimSpace(ctx.Host),
-		Kind:     kind,
+		Name:           strings.TrimSpace(ctx.Name),
+		Registry:       strings.TrimSpace(ctx.Host),
+		Kind:           kind,
+		Proxy:          strings.TrimSpace(ctx.Auth.Proxy),
+		ManifestAccept: ctx.Auth.ManifestAccept,
+		Mirrors:        ctx.Auth.Mirrors,
+		DefaultPath:    normalizeDefaultPath(ctx.DefaultPath),
+		Namespaces:     normalizeNamespaces(ctx.Namespaces),
 	}
 	switch kind {
 	case "harbor":
 		out.Anonymous = ctx.Auth.Harbor.Anonymous
 		out.Service = strings.TrimSpace(ctx.Auth.Harbor.Service)
+		out.Robot = ctx.Auth.Harbor.Robot
 	default:
 		out.Anonymous = ctx.Auth.RegistryV2.Anonymous
 		out.Service = strings.TrimSpace(ctx.Auth.RegistryV2.Service)
+		out.Token = strings.TrimSpace(ctx.Auth.RegistryV2.Token)
+	}
+	return out
+}
+
+// normalizeMirrors applies the registry URL rules to each mirror, keeping
+// values that don't parse so the client can skip them.
+func normalizeMirrors(mirrors []string) []string {
+	var out []string
+	for _, mirror := range mirrors {
+		if normalized, err := NormalizeRegistryURL(mirror); err == nil {
+			mirror = normalized
+		}
+		if mirror = strings.TrimSpace(mirror); mirror != "" {
+			out = append(out, mirror)
+		}
+	}
+	return out
+}
+
+func normalizeDefaultPath(value string) string {
+	return strings.Trim(strings.TrimSpace(value), "/")
+}
+
+// normalizeNamespaces trims slashes from each namespace and drops empty ones.
+func normalizeNamespaces(namespaces []string) []string {
+	var out []string
+	for _, namespace := range namespaces {
+		if namespace = normalizeDefaultPath(namespace); namespace != "" {
+			out = append(out, namespace)
+		}
 	}
 	return out
 }
@@ -104,6 +193,10 @@ func normalizeKind(value string) string {
 	switch kind {
 	case "harbor":
 		return "harbor"
+	case "acr", "azure":
+		return "acr"
+	case "gcr", "gar", "google":
+		return "gcr"
 	case "registry", "v2", "registry_v2":
 		return "registry_v2"
 	default:
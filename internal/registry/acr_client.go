@@ -0,0 +1,105 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const acrTagsPageSize = 100
+
+// ACRClient talks to Azure Container Registry. It reuses the Distribution v2
+// plumbing and lists tags through the /acr/v1 API to get timestamps.
+type ACRClient struct {
+	*HTTPClient
+}
+
+func newACRClient(baseURL *url.URL, auth Auth, logger RequestLogger) *ACRClient {
+	client := newRegistryV2Client(baseURL, auth, logger)
+	client.tokenPath = "/oauth2/token"
+	client.scope = "registry:catalog:* repository:*:metadata_read repository:*:pull"
+	return &ACRClient{HTTPClient: client}
+}
+
+type acrTag struct {
+	Name           string `json:"name"`
+	Digest         string `json:"digest"`
+	CreatedTime    string `json:"createdTime"`
+	LastUpdateTime string `json:"lastUpdateTime"`
+}
+
+func (c *ACRClient) ListTags(ctx context.Context, image string) ([]Tag, error) {
+	image = strings.TrimSpace(image)
+	if image == "" {
+		return nil, nil
+	}
+
+	endpoint := c.resolve("/acr/v1/"+image+"/_tags", url.Values{
+		"n":       []string{fmt.Sprintf("%d", acrTagsPageSize)},
+		"orderby": []string{"timedesc"},
+	})
+	var tags []Tag
+	for endpoint != "" {
+		page, next, err := c.listTagsPage(ctx, endpoint)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, page...)
+		endpoint = next
+	}
+	return tags, nil
+}
+
+func (c *ACRClient) listTagsPage(ctx context.Context, endpoint string) ([]Tag, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := c.applyAuth(ctx, req); err != nil {
+		return nil, "", err
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	c.logRequest(req, resp, start)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, "", fmt.Errorf("acr tags request failed: %s", resp.Status)
+	}
+
+	var payload struct {
+		Tags []acrTag `json:"tags"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, "", err
+	}
+
+	tags := make([]Tag, 0, len(payload.Tags))
+	for _, item := range payload.Tags {
+		tags = append(tags, Tag{
+			Name:         item.Name,
+			Digest:       item.Digest,
+			SizeBytes:    -1,
+			UpdatedAt:    parseACRTime(item.LastUpdateTime),
+			PushedAt:     parseACRTime(firstNonEmptyToken(item.LastUpdateTime, item.CreatedTime)),
+			ArtifactType: ArtifactTypeFromTagName(item.Name),
+		})
+	}
+	return tags, parseGitHubContainerNext(resp.Header.Get("Link"), c.baseURL), nil
+}
+
+func parseACRTime(value string) time.Time {
+	parsed, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(value))
+	if err != nil {
+		return time.Time{}
+	}
+	return parsed
+}
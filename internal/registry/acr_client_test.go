@@ -0,0 +1,86 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestKindForHost(t *testing.T) {
+	tests := map[string]string{
+		"myregistry.azurecr.io":         "acr",
+		"https://MyRegistry.azurecr.io": "acr",
+		"gcr.io":                        "gcr",
+		"eu.gcr.io":                     "gcr",
+		"europe-docker.pkg.dev":         "gcr",
+		"registry.example.com":          "registry_v2",
+		"":                              "registry_v2",
+	}
+	for host, want := range tests {
+		if got := KindForHost(host); got != want {
+			t.Fatalf("KindForHost(%q) = %q, want %q", host, got, want)
+		}
+	}
+	if got := ProviderForAuth("myregistry.azurecr.io", Auth{Kind: "none"}).Kind(); got != "acr" {
+		t.Fatalf("expected azurecr.io host to select the acr provider, got %q", got)
+	}
+}
+
+func TestACRClientListTagsPages(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth2/token" {
+			_ = r.ParseForm()
+			if r.Form.Get("grant_type") != "password" || r.Form.Get("username") != "user" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "secret"})
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/acr/v1/app/_tags" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.URL.Query().Get("last") == "" {
+			w.Header().Set("Link", `</acr/v1/app/_tags?last=v2&n=100&orderby=timedesc>; rel="next"`)
+			_, _ = w.Write([]byte(`{"tags":[{"name":"v2","digest":"sha256:b","lastUpdateTime":"2024-05-02T10:00:00Z"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"tags":[{"name":"v1","digest":"sha256:a","createdTime":"2024-05-01T10:00:00Z"}]}`))
+	}))
+	defer server.Close()
+
+	auth := Auth{Kind: "acr"}
+	auth.RegistryV2.Username = "user"
+	auth.RegistryV2.Password = "pass"
+	client, err := NewClientWithLogger(server.URL, auth, nil)
+	if err != nil {
+		t.Fatalf("NewClientWithLogger: %v", err)
+	}
+	if _, ok := client.(*ACRClient); !ok {
+		t.Fatalf("expected *ACRClient, got %T", client)
+	}
+
+	tags, err := client.ListTags(context.Background(), "app")
+	if err != nil {
+		t.Fatalf("ListTags: %v", err)
+	}
+	if len(tags) != 2 || tags[0].Name != "v2" || tags[1].Name != "v1" {
+		t.Fatalf("expected both pages in order, got %+v", tags)
+	}
+	if want := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC); !tags[1].PushedAt.Equal(want) {
+		t.Fatalf("expected created time fallback %v, got %v", want, tags[1].PushedAt)
+	}
+	if tags[0].Digest != "sha256:b" {
+		t.Fatalf("expected digest from acr listing, got %q", tags[0].Digest)
+	}
+}
@@ -0,0 +1,46 @@
+package registry
+
+import "net/url"
+
+type ACRProvider struct{}
+
+func (ACRProvider) Kind() string {
+	return "acr"
+}
+
+func (ACRProvider) TableSpec() TableSpec {
+	return TableSpec{
+		SupportsProjects: false,
+		Tag: TagTableSpec{
+			ShowPushed:    true,
+			ShowPlatforms: true,
+		},
+		History: HistoryTableSpec{
+			ShowSize:    true,
+			ShowComment: true,
+		},
+	}
+}
+
+func (ACRProvider) NeedsAuthPrompt(auth Auth) bool {
+	return RegistryV2Provider{}.NeedsAuthPrompt(auth)
+}
+
+func (ACRProvider) AuthUI(auth Auth) AuthUI {
+	return RegistryV2Provider{}.AuthUI(auth)
+}
+
+func (ACRProvider) PrepareAuth(baseURL *url.URL, auth *Auth) error {
+	if auth.Kind == "" || auth.Kind == "none" {
+		auth.Kind = "acr"
+		auth.RegistryV2.Anonymous = true
+	}
+	if auth.RegistryV2.Service == "" && baseURL != nil && baseURL.Host != "" {
+		auth.RegistryV2.Service = baseURL.Host
+	}
+	return nil
+}
+
+func (ACRProvider) NewClient(baseURL *url.URL, auth Auth, logger RequestLogger) (Client, error) {
+	return newACRClient(baseURL, auth, logger), nil
+}
@@ -0,0 +1,89 @@
+package registry
+
+import "strings"
+
+const (
+	ArtifactTypeImage       = "image"
+	ArtifactTypeSignature   = "signature"
+	ArtifactTypeAttestation = "attestation"
+	ArtifactTypeSBOM        = "sbom"
+	ArtifactTypeHelmChart   = "chart"
+	ArtifactTypeOther       = "artifact"
+)
+
+// IsImageArtifact reports whether artifactType describes a container image.
+// An empty type is treated as an image since most registries don't report one.
+func IsImageArtifact(artifactType string) bool {
+	return artifactType == "" || artifactType == ArtifactTypeImage
+}
+
+// ArtifactTypeFromTagName detects cosign-style accessory tags such as
+// sha256-<digest>.sig, .att and .sbom.
+func ArtifactTypeFromTagName(name string) string {
+	lower := strings.ToLower(strings.TrimSpace(name))
+	switch {
+	case strings.HasSuffix(lower, ".sig"):
+		return ArtifactTypeSignature
+	case strings.HasSuffix(lower, ".att"):
+		return ArtifactTypeAttestation
+	case strings.HasSuffix(lower, ".sbom"):
+		return ArtifactTypeSBOM
+	default:
+		return ""
+	}
+}
+
+// ArtifactTypeFromMediaType maps a manifest config media type to an artifact type.
+func ArtifactTypeFromMediaType(mediaType string) string {
+	lower := strings.ToLower(strings.TrimSpace(mediaType))
+	switch {
+	case lower == "":
+		return ""
+	case lower == "application/vnd.docker.container.image.v1+json",
+		lower == "application/vnd.oci.image.config.v1+json":
+		return ArtifactTypeImage
+	case strings.Contains(lower, "helm"):
+		return ArtifactTypeHelmChart
+	case strings.Contains(lower, "cosign") || strings.Contains(lower, "dev.sigstore"):
+		return ArtifactTypeSignature
+	case strings.Contains(lower, "in-toto"):
+		return ArtifactTypeAttestation
+	case strings.Contains(lower, "spdx") || strings.Contains(lower, "cyclonedx") || strings.Contains(lower, "sbom"):
+		return ArtifactTypeSBOM
+	default:
+		return ArtifactTypeOther
+	}
+}
+
+func harborArtifactType(artifact harborArtifact, tagName string) string {
+	if kind := ArtifactTypeFromTagName(tagName); kind != "" {
+		return kind
+	}
+	if kind := ArtifactTypeFromMediaType(artifact.MediaType); kind != "" {
+		return kind
+	}
+	switch strings.ToUpper(strings.TrimSpace(artifact.Type)) {
+	case "", "IMAGE":
+		return ArtifactTypeImage
+	case "CHART":
+		return ArtifactTypeHelmChart
+	default:
+		return ArtifactTypeOther
+	}
+}
+
+// MarkSignedTags sets Signed on tags whose digest has a cosign signature tag
+// (sha256-<hex>.sig) in the same list. Tags without a known digest are left
+// unmarked.
+func MarkSignedTags(tags []Tag) {
+	signatures := make(map[string]bool)
+	for _, tag := range tags {
+		if ArtifactTypeFromTagName(tag.Name) == ArtifactTypeSignature {
+			signatures[strings.ToLower(strings.TrimSpace(tag.Name))] = true
+		}
+	}
+	for i := range tags {
+		digest := strings.ToLower(strings.TrimSpace(tags[i].Digest))
+		tags[i].Signed = digest != "" && signatures[strings.Replace(digest, ":", "-", 1)+".sig"]
+	}
+}
@@ -0,0 +1,63 @@
+package registry
+
+import "testing"
+
+func TestArtifactTypeFromTagName(t *testing.T) {
+	tests := []struct {
+		name string
+		tag  string
+		want string
+	}{
+		{name: "plain tag", tag: "v1.2.3", want: ""},
+		{name: "cosign signature", tag: "sha256-abc123.sig", want: ArtifactTypeSignature},
+		{name: "cosign attestation", tag: "sha256-abc123.att", want: ArtifactTypeAttestation},
+		{name: "sbom", tag: "sha256-abc123.sbom", want: ArtifactTypeSBOM},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ArtifactTypeFromTagName(tt.tag); got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestArtifactTypeFromMediaType(t *testing.T) {
+	tests := []struct {
+		mediaType string
+		want      string
+	}{
+		{mediaType: "", want: ""},
+		{mediaType: "application/vnd.oci.image.config.v1+json", want: ArtifactTypeImage},
+		{mediaType: "application/vnd.docker.container.image.v1+json", want: ArtifactTypeImage},
+		{mediaType: "application/vnd.cncf.helm.config.v1+json", want: ArtifactTypeHelmChart},
+		{mediaType: "application/vnd.dev.cosign.simplesigning.v1+json", want: ArtifactTypeSignature},
+		{mediaType: "application/spdx+json", want: ArtifactTypeSBOM},
+		{mediaType: "application/vnd.example.unknown", want: ArtifactTypeOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mediaType, func(t *testing.T) {
+			if got := ArtifactTypeFromMediaType(tt.mediaType); got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestMarkSignedTags(t *testing.T) {
+	tags := []Tag{
+		{Name: "v1", Digest: "sha256:abc"},
+		{Name: "v2", Digest: "sha256:def"},
+		{Name: "v3"},
+		{Name: "sha256-abc.sig", Digest: "sha256:0123"},
+	}
+	MarkSignedTags(tags)
+	if !tags[0].Signed {
+		t.Fatalf("expected v1 to be signed")
+	}
+	if tags[1].Signed || tags[2].Signed || tags[3].Signed {
+		t.Fatalf("expected only v1 to be signed, got %+v", tags)
+	}
+}
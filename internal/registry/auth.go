@@ -10,6 +10,14 @@ type Auth struct {
 	Kind       string
 	RegistryV2 RegistryV2Auth
 	Harbor     HarborAuth
+	// Proxy overrides HTTP(S)_PROXY for this registry when set.
+	Proxy string
+	// ManifestAccept replaces the default manifest Accept media types, for
+	// registries that reject some of them.
+	ManifestAccept []string
+	// Mirrors are fallback hosts serving the same v2 API, tried in order
+	// when the registry host can't be reached.
+	Mirrors []string
 }
 
 type RegistryV2Auth struct {
@@ -20,6 +28,9 @@ type RegistryV2Auth struct {
 	Password     string `json:"password"`
 	Remember     bool   `json:"remember"`
 	RefreshToken string `json:"refresh_token"`
+	// Token is a pre-issued bearer token sent as is, skipping the token
+	// exchange.
+	Token string `json:"token,omitempty"`
 }
 
 type HarborAuth struct {
@@ -29,6 +40,9 @@ type HarborAuth struct {
 	Username  string `json:"username"`
 	Password  string `json:"password"`
 	Remember  bool   `json:"remember"`
+	// Robot marks the credentials as a Harbor robot account: a robot$ name
+	// and its secret.
+	Robot bool `json:"robot,omitempty"`
 }
 
 func (a *Auth) UnmarshalJSON(data []byte) error {
@@ -55,6 +69,16 @@ func (a *Auth) UnmarshalJSON(data []byte) error {
 			if err := json.Unmarshal(payload, &a.RegistryV2); err != nil {
 				return fmt.Errorf("invalid registry_v2 auth: %w", err)
 			}
+		case "acr", "azure":
+			a.Kind = "acr"
+			if err := json.Unmarshal(payload, &a.RegistryV2); err != nil {
+				return fmt.Errorf("invalid acr auth: %w", err)
+			}
+		case "gcr", "gar", "google":
+			a.Kind = "gcr"
+			if err := json.Unmarshal(payload, &a.RegistryV2); err != nil {
+				return fmt.Errorf("invalid gcr auth: %w", err)
+			}
 		case "harbor":
 			a.Kind = "harbor"
 			if err := json.Unmarshal(payload, &a.Harbor); err != nil {
@@ -78,36 +102,60 @@ func (a *Auth) Normalize() {
 	switch kind {
 	case "registry", "v2":
 		kind = "registry_v2"
+	case "azure":
+		kind = "acr"
+	case "gar", "google":
+		kind = "gcr"
 	case "anonymous":
 		kind = "none"
 	}
 	a.Kind = kind
+	a.Proxy = strings.TrimSpace(a.Proxy)
+	a.ManifestAccept = normalizeList(a.ManifestAccept)
+	a.Mirrors = normalizeList(a.Mirrors)
 	a.RegistryV2.TokenURL = strings.TrimSpace(a.RegistryV2.TokenURL)
 	a.RegistryV2.Service = strings.TrimSpace(a.RegistryV2.Service)
 	a.RegistryV2.Username = strings.TrimSpace(a.RegistryV2.Username)
 	a.RegistryV2.Password = strings.TrimSpace(a.RegistryV2.Password)
 	a.RegistryV2.RefreshToken = strings.TrimSpace(a.RegistryV2.RefreshToken)
+	a.RegistryV2.Token = strings.TrimSpace(a.RegistryV2.Token)
 	a.Harbor.TokenURL = strings.TrimSpace(a.Harbor.TokenURL)
 	a.Harbor.Service = strings.TrimSpace(a.Harbor.Service)
 	a.Harbor.Username = strings.TrimSpace(a.Harbor.Username)
 	a.Harbor.Password = strings.TrimSpace(a.Harbor.Password)
 }
 
+// UsesRegistryV2Auth reports whether the kind keeps its auth settings in the
+// RegistryV2 block. ACR and GCR share it since both are plain v2 registries.
+func (a Auth) UsesRegistryV2Auth() bool {
+	switch a.Kind {
+	case "registry_v2", "acr", "gcr":
+		return true
+	default:
+		return false
+	}
+}
+
 func (a Auth) Validate() error {
+	if err := ValidateProxy(a.Proxy); err != nil {
+		return err
+	}
 	switch a.Kind {
 	case "none":
 		return nil
-	case "registry_v2":
-		if a.RegistryV2.Anonymous {
+	case "registry_v2", "acr":
+		if a.RegistryV2.Anonymous || a.RegistryV2.Token != "" {
 			return nil
 		}
 		if a.RegistryV2.Username == "" {
-			return fmt.Errorf("registry_v2 auth requires username")
+			return fmt.Errorf("%s auth requires username", a.Kind)
 		}
 		if a.RegistryV2.Password == "" && !(a.RegistryV2.Remember && a.RegistryV2.RefreshToken != "") {
-			return fmt.Errorf("registry_v2 auth requires password unless remember is set with a refresh_token")
+			return fmt.Errorf("%s auth requires password unless remember is set with a refresh_token", a.Kind)
 		}
 		return nil
+	case "gcr":
+		return nil
 	case "harbor":
 		if a.Harbor.Anonymous {
 			return nil
@@ -115,8 +163,31 @@ func (a Auth) Validate() error {
 		if a.Harbor.Username == "" || a.Harbor.Password == "" {
 			return fmt.Errorf("harbor auth requires username and password")
 		}
+		if a.Harbor.Robot && !IsHarborRobotName(a.Harbor.Username) {
+			return fmt.Errorf("harbor robot account names look like %sproject+name, got %q", HarborRobotPrefix, a.Harbor.Username)
+		}
 		return nil
 	default:
 		return fmt.Errorf("unsupported auth method: %s", a.Kind)
 	}
 }
+
+func normalizeList(values []string) []string {
+	var out []string
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			out = append(out, value)
+		}
+	}
+	return out
+}
+
+// HarborRobotPrefix starts every Harbor robot account name.
+const HarborRobotPrefix = "robot$"
+
+// IsHarborRobotName reports whether name is a robot account name, either
+// system level (robot$ci) or project level (robot$project+ci).
+func IsHarborRobotName(name string) bool {
+	rest, ok := strings.CutPrefix(name, HarborRobotPrefix)
+	return ok && strings.Trim(rest, "+") != ""
+}
@@ -26,7 +26,7 @@ func ApplyAuthCache(auth *Auth, host string) {
 	}
 
 	switch auth.Kind {
-	case "registry_v2":
+	case "registry_v2", "acr":
 		if auth.RegistryV2.Username == "" && entry.Username != "" {
 			auth.RegistryV2.Username = entry.Username
 		}
@@ -52,7 +52,7 @@ func PersistAuthCache(host string, auth Auth) {
 	key := cacheKey(host, auth.Kind)
 	entry := entries[key]
 	switch auth.Kind {
-	case "registry_v2":
+	case "registry_v2", "acr":
 		if auth.RegistryV2.Username != "" {
 			entry.Username = auth.RegistryV2.Username
 		}
@@ -80,10 +80,36 @@ func PersistAuthCache(host string, auth Auth) {
 	_ = saveAuthCache(entries)
 }
 
+// cacheKey keys entries by scheme and host, so a bare host shares the entry
+// of its https URL while an http registry on the same host keeps its own.
 func cacheKey(host, kind string) string {
+	if parsed, err := ParseRegistryURL(host); err == nil {
+		host = parsed.Scheme + "://" + parsed.Host
+	}
 	return strings.ToLower(host) + "|" + strings.ToLower(kind)
 }
 
+// migrateAuthCacheKeys rewrites entries saved under older key formats, such
+// as a bare host, to their current key. An entry already under the current
+// key wins; the rewritten map is saved on the next PersistAuthCache.
+func migrateAuthCacheKeys(entries map[string]authCacheEntry) {
+	for key, entry := range entries {
+		separator := strings.LastIndex(key, "|")
+		if separator < 0 {
+			delete(entries, key)
+			continue
+		}
+		current := cacheKey(key[:separator], key[separator+1:])
+		if current == key {
+			continue
+		}
+		delete(entries, key)
+		if _, ok := entries[current]; !ok {
+			entries[current] = entry
+		}
+	}
+}
+
 func authCachePath() string {
 	if xdg := os.Getenv("XDG_CACHE_HOME"); xdg != "" {
 		return filepath.Join(xdg, "beacon", "auth.json")
@@ -110,6 +136,7 @@ func loadAuthCache() (map[string]authCacheEntry, error) {
 	if entries == nil {
 		entries = map[string]authCacheEntry{}
 	}
+	migrateAuthCacheKeys(entries)
 	return entries, nil
 }
 
@@ -33,36 +33,90 @@ func decodeTokenResponse(resp *http.Response) (string, string, time.Time, error)
 	return token, refresh, expiry, nil
 }
 
-func parseBearerChallenge(value string) (realm, service, scope string, ok bool) {
+type bearerChallenge struct {
+	realm   string
+	service string
+	scope   string
+	// err and description explain a refused request, e.g. insufficient_scope.
+	err         string
+	description string
+}
+
+func parseBearerChallenge(value string) (bearerChallenge, bool) {
 	parts := strings.SplitN(strings.TrimSpace(value), " ", 2)
 	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
-		return "", "", "", false
+		return bearerChallenge{}, false
 	}
 
-	for _, segment := range strings.Split(parts[1], ",") {
-		segment = strings.TrimSpace(segment)
-		if segment == "" {
-			continue
-		}
-		kv := strings.SplitN(segment, "=", 2)
-		if len(kv) != 2 {
-			continue
-		}
-		key := strings.ToLower(strings.TrimSpace(kv[0]))
-		val := strings.Trim(strings.TrimSpace(kv[1]), `"`)
+	var challenge bearerChallenge
+	for key, val := range parseChallengeParams(parts[1]) {
 		switch key {
 		case "realm":
-			realm = val
+			challenge.realm = val
 		case "service":
-			service = val
+			challenge.service = val
 		case "scope":
-			scope = val
+			challenge.scope = val
+		case "error":
+			challenge.err = val
+		case "error_description":
+			challenge.description = val
 		}
 	}
-	if realm == "" {
-		return "", "", "", false
+	if challenge.realm == "" && challenge.err == "" {
+		return bearerChallenge{}, false
+	}
+	return challenge, true
+}
+
+// parseChallengeParams splits key="value" pairs, keeping commas inside quoted
+// values such as scope="repository:app:pull,push".
+func parseChallengeParams(value string) map[string]string {
+	params := make(map[string]string)
+	for value != "" {
+		value = strings.TrimLeft(value, " ,")
+		eq := strings.IndexByte(value, '=')
+		if eq < 0 {
+			break
+		}
+		key := strings.ToLower(strings.TrimSpace(value[:eq]))
+		value = strings.TrimSpace(value[eq+1:])
+		var val string
+		if strings.HasPrefix(value, `"`) {
+			end := strings.IndexByte(value[1:], '"')
+			if end < 0 {
+				val, value = value[1:], ""
+			} else {
+				val, value = value[1:end+1], value[end+2:]
+			}
+		} else if comma := strings.IndexByte(value, ','); comma >= 0 {
+			val, value = strings.TrimSpace(value[:comma]), value[comma+1:]
+		} else {
+			val, value = value, ""
+		}
+		params[key] = val
 	}
-	return realm, service, scope, true
+	return params
+}
+
+// challengeError turns the error a registry reports in WWW-Authenticate into
+// an "authentication failed" error, or returns nil when there is none.
+func challengeError(resp *http.Response) error {
+	if resp.StatusCode != http.StatusUnauthorized && resp.StatusCode != http.StatusForbidden {
+		return nil
+	}
+	challenge, ok := parseBearerChallenge(resp.Header.Get("Www-Authenticate"))
+	if !ok || challenge.err == "" {
+		return nil
+	}
+	detail := challenge.err
+	if challenge.description != "" {
+		detail += " (" + challenge.description + ")"
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		return fmt.Errorf("authentication failed: %s: %w", detail, ErrUnauthorized)
+	}
+	return fmt.Errorf("authentication failed: %s", detail)
 }
 
 func fetchBearerToken(ctx context.Context, client *http.Client, logger RequestLogger, realm, service, scope string) (string, time.Time, error) {
@@ -85,13 +139,17 @@ func fetchBearerToken(ctx context.Context, client *http.Client, logger RequestLo
 	}
 	req.Header.Set("Accept", "application/json")
 
+	start := time.Now()
 	resp, err := client.Do(req)
-	logRequestWithLogger(logger, req, resp)
+	logRequestWithLogger(logger, req, resp, start)
 	if err != nil {
 		return "", time.Time{}, err
 	}
 	defer resp.Body.Close()
 
+	if err := challengeError(resp); err != nil {
+		return "", time.Time{}, err
+	}
 	if resp.StatusCode >= 300 {
 		return "", time.Time{}, fmt.Errorf("token request failed: %s", resp.Status)
 	}
@@ -106,7 +164,7 @@ func fetchBearerToken(ctx context.Context, client *http.Client, logger RequestLo
 	return token, expiry, nil
 }
 
-func logRequestWithLogger(logger RequestLogger, req *http.Request, resp *http.Response) {
+func logRequestWithLogger(logger RequestLogger, req *http.Request, resp *http.Response, start time.Time) {
 	if logger == nil {
 		return
 	}
@@ -115,10 +173,12 @@ func logRequestWithLogger(logger RequestLogger, req *http.Request, resp *http.Re
 		status = resp.StatusCode
 	}
 	logger(RequestLog{
-		Method:  req.Method,
-		URL:     req.URL.String(),
-		Headers: cloneHeader(req.Header),
-		Status:  status,
+		Method:    req.Method,
+		URL:       req.URL.String(),
+		Headers:   cloneHeader(req.Header),
+		Status:    status,
+		StartedAt: start,
+		Duration:  time.Since(start),
 	})
 }
 
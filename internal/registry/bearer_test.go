@@ -0,0 +1,65 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseBearerChallenge(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   bearerChallenge
+		ok     bool
+	}{
+		{
+			name:   "token realm",
+			header: `Bearer realm="https://auth.example.com/token",service="registry.example.com",scope="repository:team/app:pull,push"`,
+			want:   bearerChallenge{realm: "https://auth.example.com/token", service: "registry.example.com", scope: "repository:team/app:pull,push"},
+			ok:     true,
+		},
+		{
+			name:   "refused scope",
+			header: `Bearer realm="https://auth.example.com/token",error="insufficient_scope",error_description="push access denied, repository is read-only"`,
+			want:   bearerChallenge{realm: "https://auth.example.com/token", err: "insufficient_scope", description: "push access denied, repository is read-only"},
+			ok:     true,
+		},
+		{name: "basic", header: `Basic realm="registry"`},
+		{name: "empty", header: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseBearerChallenge(tt.header)
+			if ok != tt.ok || got != tt.want {
+				t.Fatalf("parseBearerChallenge() = %+v, %v; want %+v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestTokenRequestReportsChallengeError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Www-Authenticate", `Bearer realm="token",error="insufficient_scope"`)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	auth := Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Username = "alice"
+	auth.RegistryV2.Password = "secret"
+	client, err := NewClientWithLogger(server.URL, auth, nil)
+	if err != nil {
+		t.Fatalf("NewClientWithLogger: %v", err)
+	}
+	_, err = client.ListImages(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "authentication failed: insufficient_scope") {
+		t.Fatalf("expected the challenge error in %v", err)
+	}
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected a 401 challenge error to wrap ErrUnauthorized, got %v", err)
+	}
+}
@@ -10,9 +10,60 @@ type Client interface {
 	RenameTag(ctx context.Context, image, from, to string) error
 }
 
+// TagHistoryResolver is implemented by clients that can report the manifest
+// digest a tag resolved to along with its history.
+type TagHistoryResolver interface {
+	ListTagHistoryWithDigest(ctx context.Context, image, tag string) ([]HistoryEntry, string, error)
+}
+
+// TagInspector is implemented by clients that also read the image config
+// labels while resolving a tag's history.
+type TagInspector interface {
+	InspectTag(ctx context.Context, image, tag string) (TagDetails, error)
+}
+
 // ProjectClient provides optional project-scoped operations for registries
 // that expose projects (for example Harbor).
 type ProjectClient interface {
 	ListProjects(ctx context.Context) ([]Project, error)
 	ListProjectImages(ctx context.Context, project string) ([]Image, error)
 }
+
+// ProjectArtifactCounter sums the artifacts of every project. It pages
+// through the whole repository listing, so callers load it apart from the
+// project list and only when the totals are shown.
+type ProjectArtifactCounter interface {
+	ProjectArtifactCounts(ctx context.Context) (map[string]int, error)
+}
+
+// ImageStreamer lists images in batches as they arrive. emit is never called
+// concurrently. Registries whose full catalog takes many requests implement
+// it so the UI can show partial results.
+type ImageStreamer interface {
+	StreamImages(ctx context.Context, emit func([]Image)) error
+}
+
+// ImageTotalStreamer is an ImageStreamer that knows how many images the full
+// listing holds before it finishes; total is 0 when it can't tell.
+type ImageTotalStreamer interface {
+	StreamImagesWithTotal(ctx context.Context, emit func(batch []Image, total int)) error
+}
+
+// TagStreamer lists an image's tags in batches as pages arrive. A non-empty
+// filter is matched server-side, so only artifacts with a tag containing it
+// are fetched.
+type TagStreamer interface {
+	StreamTags(ctx context.Context, image, filter string, emit func([]Tag)) error
+}
+
+// ImagePager lists the catalog one bounded page at a time. Pass the returned
+// cursor as last to fetch the following page; an empty cursor means the
+// catalog is exhausted.
+type ImagePager interface {
+	ListImagesPage(ctx context.Context, last string, limit int) ([]Image, string, error)
+}
+
+// ProjectPager lists projects one bounded page at a time, starting at page 1.
+type ProjectPager interface {
+	ListProjectsPage(ctx context.Context, page, limit int) ([]Project, error)
+}
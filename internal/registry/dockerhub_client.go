@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -19,6 +20,13 @@ type DockerHubClient struct {
 	baseURL    *url.URL
 	httpClient *http.Client
 	logger     RequestLogger
+	limiter    *requestLimiter
+	// SkipResolve treats a bare name as library/<name> instead of asking
+	// the search API which namespace it belongs to.
+	SkipResolve bool
+
+	mu        sync.Mutex
+	rateLimit DockerHubRateLimit
 }
 
 type DockerHubRateLimit struct {
@@ -50,14 +58,17 @@ type DockerHubTagsPage struct {
 	Tags      []Tag
 	Next      string
 	RateLimit DockerHubRateLimit
+	// Digest is the digest pinned in the search input (image@sha256:...), if any.
+	Digest string
 }
 
-func NewDockerHubClient(logger RequestLogger) *DockerHubClient {
+func NewDockerHubClient(logger RequestLogger, proxy string) *DockerHubClient {
 	parsed, _ := url.Parse(dockerHubBaseURL)
 	return &DockerHubClient{
 		baseURL:    parsed,
-		httpClient: &http.Client{Timeout: 15 * time.Second},
+		httpClient: newHTTPClient(proxy),
 		logger:     logger,
+		limiter:    dockerHubLimiter,
 	}
 }
 
@@ -86,7 +97,12 @@ func (c *DockerHubClient) SearchTagsPage(ctx context.Context, input string) (Doc
 		return DockerHubTagsPage{}, err
 	}
 
-	return c.listTagsPage(ctx, fmt.Sprintf("%s/%s", namespace, repo), "")
+	page, err := c.listTagsPage(ctx, fmt.Sprintf("%s/%s", namespace, repo), "")
+	if err != nil {
+		return page, err
+	}
+	_, page.Digest = normalizeDockerHubInput(input)
+	return page, nil
 }
 
 func (c *DockerHubClient) NextTagsPage(ctx context.Context, image, next string) (DockerHubTagsPage, error) {
@@ -100,7 +116,7 @@ func (c *DockerHubClient) NextTagsPage(ctx context.Context, image, next string)
 }
 
 func (c *DockerHubClient) resolveRepository(ctx context.Context, input string) (string, string, error) {
-	trimmed := normalizeDockerHubInput(input)
+	trimmed, _ := normalizeDockerHubInput(input)
 	if trimmed == "" {
 		return "", "", errors.New("docker hub search requires an image name")
 	}
@@ -111,6 +127,9 @@ func (c *DockerHubClient) resolveRepository(ctx context.Context, input string) (
 		}
 		return ns, repo, nil
 	}
+	if c.SkipResolve {
+		return "library", strings.ToLower(trimmed), nil
+	}
 
 	// Use Docker Hub search API to resolve a namespace for a short name.
 	results, err := c.searchRepositories(ctx, trimmed)
@@ -186,6 +205,8 @@ func (c *DockerHubClient) listTagsPage(ctx context.Context, image, next string)
 			UpdatedAt:    parseDockerHubTime(entry.LastUpdated),
 			PushedAt:     parseDockerHubTime(firstNonEmptyString(entry.TagLastPushed, entry.LastUpdated)),
 			LastPulledAt: parseDockerHubTime(entry.TagLastPulled),
+			ArtifactType: ArtifactTypeFromTagName(entry.Name),
+			Platforms:    entry.platformCount(),
 		})
 	}
 
@@ -215,14 +236,18 @@ func (c *DockerHubClient) doJSON(ctx context.Context, method, endpoint string, b
 	if err != nil {
 		return DockerHubRateLimit{}, err
 	}
+	if err := c.limiter.Wait(ctx); err != nil {
+		return DockerHubRateLimit{}, err
+	}
+	start := time.Now()
 	resp, err := c.httpClient.Do(req)
-	c.logRequest(req, resp)
+	c.logRequest(req, resp, start)
 	if err != nil {
 		return DockerHubRateLimit{}, err
 	}
 	defer resp.Body.Close()
 
-	rateLimit := parseDockerHubRateLimit(resp.Header)
+	rateLimit := c.observeRateLimit(resp.Header)
 	if resp.StatusCode == http.StatusTooManyRequests {
 		return rateLimit, &DockerHubRateLimitError{
 			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
@@ -238,7 +263,29 @@ func (c *DockerHubClient) doJSON(ctx context.Context, method, endpoint string, b
 	return rateLimit, json.NewDecoder(resp.Body).Decode(out)
 }
 
-func (c *DockerHubClient) logRequest(req *http.Request, resp *http.Response) {
+// RateLimit returns the quota advertised by the latest response that carried
+// rate limit headers, or Limit -1 when none has been seen yet.
+func (c *DockerHubClient) RateLimit() DockerHubRateLimit {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.rateLimit.Limit <= 0 {
+		return DockerHubRateLimit{Limit: -1, Remaining: -1}
+	}
+	return c.rateLimit
+}
+
+func (c *DockerHubClient) observeRateLimit(headers http.Header) DockerHubRateLimit {
+	rateLimit := parseDockerHubRateLimit(headers)
+	c.limiter.Observe(rateLimit)
+	if rateLimit.Limit > 0 {
+		c.mu.Lock()
+		c.rateLimit = rateLimit
+		c.mu.Unlock()
+	}
+	return rateLimit
+}
+
+func (c *DockerHubClient) logRequest(req *http.Request, resp *http.Response, start time.Time) {
 	if c.logger == nil {
 		return
 	}
@@ -247,10 +294,12 @@ func (c *DockerHubClient) logRequest(req *http.Request, resp *http.Response) {
 		status = resp.StatusCode
 	}
 	c.logger(RequestLog{
-		Method:  req.Method,
-		URL:     req.URL.String(),
-		Headers: cloneHeader(req.Header),
-		Status:  status,
+		Method:    req.Method,
+		URL:       req.URL.String(),
+		Headers:   cloneHeader(req.Header),
+		Status:    status,
+		StartedAt: start,
+		Duration:  time.Since(start),
 	})
 }
 
@@ -286,9 +335,26 @@ type dockerHubTagResult struct {
 	LastUpdated   string `json:"last_updated"`
 	TagLastPushed string `json:"tag_last_pushed"`
 	TagLastPulled string `json:"tag_last_pulled"`
+	Images        []struct {
+		OS           string `json:"os"`
+		Architecture string `json:"architecture"`
+	} `json:"images"`
 }
 
-func normalizeDockerHubInput(input string) string {
+func (r dockerHubTagResult) platformCount() int {
+	if len(r.Images) == 0 {
+		return 0
+	}
+	descriptors := make([]ManifestDescriptor, 0, len(r.Images))
+	for _, image := range r.Images {
+		descriptors = append(descriptors, ManifestDescriptor{Platform: ManifestPlatform{OS: image.OS, Architecture: image.Architecture}})
+	}
+	return platformCount(descriptors)
+}
+
+// normalizeDockerHubInput reduces a pasted reference to the repository name,
+// returning any @digest separately.
+func normalizeDockerHubInput(input string) (string, string) {
 	trimmed := strings.TrimSpace(input)
 	trimmed = strings.TrimPrefix(trimmed, "docker.io/")
 	trimmed = strings.TrimPrefix(trimmed, "index.docker.io/")
@@ -299,15 +365,21 @@ func normalizeDockerHubInput(input string) string {
 			trimmed = strings.TrimPrefix(parsed.Path, "/")
 		}
 	}
-	if at := strings.Index(trimmed, "@"); at != -1 {
-		trimmed = trimmed[:at]
-	}
+	trimmed, digest := splitDigest(trimmed)
 	if colon := strings.LastIndex(trimmed, ":"); colon != -1 {
 		if slash := strings.LastIndex(trimmed, "/"); slash == -1 || colon > slash {
 			trimmed = trimmed[:colon]
 		}
 	}
-	return strings.TrimSpace(trimmed)
+	return strings.TrimSpace(trimmed), digest
+}
+
+func splitDigest(reference string) (string, string) {
+	at := strings.Index(reference, "@")
+	if at == -1 {
+		return reference, ""
+	}
+	return reference[:at], strings.TrimSpace(reference[at+1:])
 }
 
 func splitRepo(input string) (string, string) {
@@ -340,9 +412,16 @@ func firstNonEmptyString(values ...string) string {
 	return ""
 }
 
+// parseDockerHubRateLimit reads the X-RateLimit headers of the Hub API and
+// falls back to the RateLimit headers the registry sends on manifest pulls,
+// which carry the window after the count ("100;w=21600").
 func parseDockerHubRateLimit(headers http.Header) DockerHubRateLimit {
 	limit := parseHeaderInt(headers.Get("X-RateLimit-Limit"))
 	remaining := parseHeaderInt(headers.Get("X-RateLimit-Remaining"))
+	if limit < 0 {
+		limit = parseHeaderInt(rateLimitCount(headers.Get("RateLimit-Limit")))
+		remaining = parseHeaderInt(rateLimitCount(headers.Get("RateLimit-Remaining")))
+	}
 	resetUnix := parseHeaderInt(headers.Get("X-RateLimit-Reset"))
 
 	resetAt := time.Time{}
@@ -357,6 +436,11 @@ func parseDockerHubRateLimit(headers http.Header) DockerHubRateLimit {
 	}
 }
 
+func rateLimitCount(value string) string {
+	count, _, _ := strings.Cut(value, ";")
+	return count
+}
+
 func parseHeaderInt(value string) int {
 	trimmed := strings.TrimSpace(value)
 	if trimmed == "" {
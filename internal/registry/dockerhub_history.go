@@ -7,20 +7,31 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 )
 
 const dockerHubRegistryBaseURL = "https://registry-1.docker.io"
 
 func (c *DockerHubClient) ListTagHistory(ctx context.Context, image, tag string) ([]HistoryEntry, error) {
+	history, _, err := c.ListTagHistoryWithDigest(ctx, image, tag)
+	return history, err
+}
+
+func (c *DockerHubClient) ListTagHistoryWithDigest(ctx context.Context, image, tag string) ([]HistoryEntry, string, error) {
+	details, err := c.InspectTag(ctx, image, tag)
+	return details.History, details.Digest, err
+}
+
+func (c *DockerHubClient) InspectTag(ctx context.Context, image, tag string) (TagDetails, error) {
 	image = strings.Trim(strings.TrimSpace(image), "/")
 	tag = strings.TrimSpace(tag)
 	if image == "" {
-		return nil, fmt.Errorf("docker hub image is required")
+		return TagDetails{}, fmt.Errorf("docker hub image is required")
 	}
 	if tag == "" {
-		return nil, fmt.Errorf("docker hub tag is required")
+		return TagDetails{}, fmt.Errorf("docker hub tag is required")
 	}
-	return listTagHistoryFromManifest(ctx, "docker hub", image, tag, c.getRegistryManifest, c.getRegistryConfig)
+	return inspectTag(ctx, "docker hub", image, tag, c.getRegistryManifest, c.getRegistryConfig)
 }
 
 func (c *DockerHubClient) getRegistryManifest(ctx context.Context, image, reference string) (ManifestV2, error) {
@@ -29,12 +40,7 @@ func (c *DockerHubClient) getRegistryManifest(ctx context.Context, image, refere
 	if err != nil {
 		return ManifestV2{}, err
 	}
-	req.Header.Set("Accept", strings.Join([]string{
-		"application/vnd.docker.distribution.manifest.v2+json",
-		"application/vnd.oci.image.manifest.v1+json",
-		"application/vnd.docker.distribution.manifest.list.v2+json",
-		"application/vnd.oci.image.index.v1+json",
-	}, ", "))
+	req.Header.Set("Accept", manifestAcceptHeader(nil))
 
 	resp, err := c.doRegistryRequest(ctx, req, image)
 	if err != nil {
@@ -46,11 +52,7 @@ func (c *DockerHubClient) getRegistryManifest(ctx context.Context, image, refere
 		return ManifestV2{}, fmt.Errorf("docker hub manifest request failed: %s", resp.Status)
 	}
 
-	var manifest ManifestV2
-	if err := json.NewDecoder(resp.Body).Decode(&manifest); err != nil {
-		return ManifestV2{}, err
-	}
-	return manifest, nil
+	return decodeManifest(resp)
 }
 
 func (c *DockerHubClient) getRegistryConfig(ctx context.Context, image, digest string) (ConfigV2, error) {
@@ -78,11 +80,16 @@ func (c *DockerHubClient) getRegistryConfig(ctx context.Context, image, digest s
 }
 
 func (c *DockerHubClient) doRegistryRequest(ctx context.Context, req *http.Request, image string) (*http.Response, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	start := time.Now()
 	resp, err := c.httpClient.Do(req)
-	c.logRequest(req, resp)
+	c.logRequest(req, resp, start)
 	if err != nil {
 		return nil, err
 	}
+	c.observeRateLimit(resp.Header)
 	if resp.StatusCode != http.StatusUnauthorized {
 		return resp, nil
 	}
@@ -90,10 +97,11 @@ func (c *DockerHubClient) doRegistryRequest(ctx context.Context, req *http.Reque
 	challenge := resp.Header.Get("Www-Authenticate")
 	resp.Body.Close()
 
-	realm, service, scope, ok := parseBearerChallenge(challenge)
-	if !ok {
+	parsed, ok := parseBearerChallenge(challenge)
+	if !ok || parsed.realm == "" {
 		return nil, fmt.Errorf("docker hub registry requires bearer auth")
 	}
+	service, scope := parsed.service, parsed.scope
 	if service == "" {
 		service = "registry.docker.io"
 	}
@@ -101,7 +109,7 @@ func (c *DockerHubClient) doRegistryRequest(ctx context.Context, req *http.Reque
 		scope = fmt.Sprintf("repository:%s:pull", image)
 	}
 
-	token, _, err := fetchBearerToken(ctx, c.httpClient, c.logger, realm, service, scope)
+	token, _, err := fetchBearerToken(ctx, c.httpClient, c.logger, parsed.realm, service, scope)
 	if err != nil {
 		return nil, err
 	}
@@ -113,10 +121,19 @@ func (c *DockerHubClient) doRegistryRequest(ctx context.Context, req *http.Reque
 	retryReq.Header = req.Header.Clone()
 	retryReq.Header.Set("Authorization", "Bearer "+token)
 
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	retryStart := time.Now()
 	retryResp, retryErr := c.httpClient.Do(retryReq)
-	c.logRequest(retryReq, retryResp)
+	c.logRequest(retryReq, retryResp, retryStart)
 	if retryErr != nil {
 		return nil, retryErr
 	}
+	c.observeRateLimit(retryResp.Header)
+	if err := challengeError(retryResp); err != nil {
+		retryResp.Body.Close()
+		return nil, err
+	}
 	return retryResp, nil
 }
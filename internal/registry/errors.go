@@ -3,3 +3,23 @@ package registry
 import "errors"
 
 var ErrNotSupported = errors.New("operation not supported by registry")
+
+// ErrCatalogForbidden is returned when the registry answers 401 or 403 to a
+// catalog listing, usually because listing is disabled for the account.
+var ErrCatalogForbidden = errors.New("catalog listing not permitted")
+
+// ErrCatalogDisabled is returned when the registry answers 404 or 405 to a
+// catalog listing, meaning the endpoint is turned off entirely.
+var ErrCatalogDisabled = errors.New("catalog listing disabled")
+
+// ErrUnauthorized is wrapped into errors for requests the registry answered
+// with 401, so callers can ask for credentials.
+var ErrUnauthorized = errors.New("registry requires authentication")
+
+// ErrRepositoryNotFound is wrapped into errors for tag listings the registry
+// answered with 404, as opposed to a repository that exists without tags.
+var ErrRepositoryNotFound = errors.New("repository not found")
+
+// ErrTagDeleteUnsupported is wrapped into errors for tag deletions the
+// registry refuses with 405, as stock distribution does for tag references.
+var ErrTagDeleteUnsupported = errors.New("registry cannot delete a tag by name")
@@ -0,0 +1,64 @@
+package registry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestNormalizeDockerHubInputDigest(t *testing.T) {
+	tests := []struct {
+		input      string
+		wantImage  string
+		wantDigest string
+	}{
+		{input: "nginx:1.27", wantImage: "nginx"},
+		{input: "docker.io/library/nginx@sha256:abc123", wantImage: "library/nginx", wantDigest: "sha256:abc123"},
+		{input: "bitnami/redis:7@sha256:def456", wantImage: "bitnami/redis", wantDigest: "sha256:def456"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			image, digest := normalizeDockerHubInput(tt.input)
+			if image != tt.wantImage || digest != tt.wantDigest {
+				t.Fatalf("expected (%q, %q), got (%q, %q)", tt.wantImage, tt.wantDigest, image, digest)
+			}
+		})
+	}
+}
+
+func TestNormalizeGitHubContainerInputDigest(t *testing.T) {
+	image, digest, err := normalizeGitHubContainerInput("ghcr.io/owner/app@sha256:abc123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if image != "owner/app" || digest != "sha256:abc123" {
+		t.Fatalf("expected owner/app and digest, got %q %q", image, digest)
+	}
+
+	image, digest, err = normalizeGitHubContainerInput("owner/app:v1")
+	if err != nil || image != "owner/app" || digest != "" {
+		t.Fatalf("expected owner/app without digest, got %q %q %v", image, digest, err)
+	}
+}
+
+func TestDockerHubSkipResolveUsesLibrary(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	client := NewDockerHubClient(nil, "")
+	client.baseURL, _ = url.Parse(server.URL)
+	client.SkipResolve = true
+
+	ns, repo, err := client.resolveRepository(context.Background(), "Nginx:1.27")
+	if err != nil {
+		t.Fatalf("resolveRepository: %v", err)
+	}
+	if ns != "library" || repo != "nginx" {
+		t.Fatalf("expected library/nginx without a search request, got %s/%s", ns, repo)
+	}
+}
@@ -11,24 +11,45 @@ func ProviderForKind(kind string) Provider {
 	switch strings.ToLower(strings.TrimSpace(kind)) {
 	case "harbor":
 		return HarborProvider{}
+	case "acr", "azure":
+		return ACRProvider{}
+	case "gcr", "gar", "google":
+		return GCRProvider{}
 	default:
 		return RegistryV2Provider{}
 	}
 }
 
-func ProviderForAuth(auth Auth) Provider {
+// ProviderForAuth picks the provider for auth, falling back to the kind
+// implied by registryHost when auth does not name one.
+func ProviderForAuth(registryHost string, auth Auth) Provider {
 	kind := strings.ToLower(strings.TrimSpace(auth.Kind))
 	if kind == "" || kind == "none" || kind == "anonymous" {
-		kind = "registry_v2"
+		kind = KindForHost(registryHost)
 	}
 	return ProviderForKind(kind)
 }
 
-func NewClient(registryHost string, auth Auth) (Client, error) {
-	return NewClientWithLogger(registryHost, auth, nil)
+// KindForHost guesses a registry kind from its host name.
+func KindForHost(registryHost string) string {
+	host := strings.TrimSpace(registryHost)
+	if parsed, err := ParseRegistryURL(host); err == nil {
+		host = parsed.Hostname()
+	}
+	host = strings.ToLower(host)
+	switch {
+	case strings.HasSuffix(host, ".azurecr.io"):
+		return "acr"
+	case host == "gcr.io", strings.HasSuffix(host, ".gcr.io"), strings.HasSuffix(host, "-docker.pkg.dev"):
+		return "gcr"
+	}
+	return "registry_v2"
 }
 
-func NewClientWithLogger(registryHost string, auth Auth, logger RequestLogger) (Client, error) {
+// ParseRegistryURL parses a registry host into a base URL. Hosts without a
+// scheme default to https; an explicit http:// is kept as-is for plain-HTTP
+// registries such as a local registry:2 on localhost:5000.
+func ParseRegistryURL(registryHost string) (*url.URL, error) {
 	trimmed := strings.TrimSpace(registryHost)
 	if trimmed == "" {
 		return nil, errors.New("registry host is required")
@@ -41,16 +62,33 @@ func NewClientWithLogger(registryHost string, auth Auth, logger RequestLogger) (
 	if err != nil {
 		return nil, fmt.Errorf("invalid registry host: %w", err)
 	}
+	switch parsed.Scheme {
+	case "http", "https":
+	default:
+		return nil, fmt.Errorf("unsupported registry scheme %q (use http or https)", parsed.Scheme)
+	}
 	if parsed.Host == "" {
 		return nil, errors.New("registry host must include a host name")
 	}
 	parsed.Path = strings.TrimSuffix(parsed.Path, "/")
+	return parsed, nil
+}
+
+func NewClient(registryHost string, auth Auth) (Client, error) {
+	return NewClientWithLogger(registryHost, auth, nil)
+}
+
+func NewClientWithLogger(registryHost string, auth Auth, logger RequestLogger) (Client, error) {
+	parsed, err := ParseRegistryURL(registryHost)
+	if err != nil {
+		return nil, err
+	}
 
 	auth.Normalize()
-	provider := ProviderForAuth(auth)
+	provider := ProviderForAuth(registryHost, auth)
 	if auth.Kind == "none" {
 		auth.Kind = provider.Kind()
-		if auth.Kind == "registry_v2" {
+		if auth.UsesRegistryV2Auth() && auth.Kind != "gcr" {
 			auth.RegistryV2.Anonymous = true
 		}
 	}
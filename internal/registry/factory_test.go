@@ -0,0 +1,144 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseRegistryURL(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "registry.example.com", want: "https://registry.example.com"},
+		{input: "http://localhost:5000/", want: "http://localhost:5000"},
+		{input: "HTTP://localhost:5000", want: "http://localhost:5000"},
+		{input: "ftp://registry.example.com", wantErr: true},
+		{input: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			parsed, err := ParseRegistryURL(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", parsed)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if parsed.String() != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, parsed.String())
+			}
+		})
+	}
+}
+
+func TestRegistryV2ClientOverPlainHTTP(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.URL.Path == "/token" {
+			_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "secret"})
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/v2/_catalog":
+			_ = json.NewEncoder(w).Encode(map[string][]string{"repositories": {"app"}})
+		case "/v2/app/tags/list":
+			_ = json.NewEncoder(w).Encode(map[string][]string{"tags": {"dev"}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	if !strings.HasPrefix(server.URL, "http://") {
+		t.Fatalf("expected plain HTTP test server, got %s", server.URL)
+	}
+
+	auth := Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Username = "user"
+	auth.RegistryV2.Password = "pass"
+	client, err := NewClientWithLogger(server.URL, auth, nil)
+	if err != nil {
+		t.Fatalf("NewClientWithLogger: %v", err)
+	}
+
+	images, err := client.ListImages(context.Background())
+	if err != nil || len(images) != 1 || images[0].Name != "app" {
+		t.Fatalf("expected catalog over http, got %v (err %v)", images, err)
+	}
+	tags, err := client.ListTags(context.Background(), "app")
+	if err != nil || len(tags) != 1 || tags[0].Name != "dev" {
+		t.Fatalf("expected tags over http, got %v (err %v)", tags, err)
+	}
+	if requests[0] != "POST /token" {
+		t.Fatalf("expected token request against the http base URL first, got %v", requests)
+	}
+}
+
+func TestAuthCacheKeyKeepsScheme(t *testing.T) {
+	if cacheKey("https://localhost:5000", "registry_v2") != cacheKey("localhost:5000", "registry_v2") {
+		t.Fatalf("expected a bare host to share the https cache key")
+	}
+	if cacheKey("http://localhost:5000", "registry_v2") == cacheKey("https://localhost:5000", "registry_v2") {
+		t.Fatalf("expected http and https registries on one host to keep separate cache keys")
+	}
+}
+
+func TestAuthCacheMigratesOldKeys(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	if err := saveAuthCache(map[string]authCacheEntry{
+		"localhost:5000|registry_v2":               {Username: "bare", RefreshToken: "old-token"},
+		"https://registry.example.com|registry_v2": {Username: "current"},
+		"registry.example.com|registry_v2":         {Username: "stale"},
+		"http://registry.example.com/v2|harbor":    {Username: "plain"},
+	}); err != nil {
+		t.Fatalf("saveAuthCache: %v", err)
+	}
+
+	auth := Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Remember = true
+	ApplyAuthCache(&auth, "https://localhost:5000")
+	if auth.RegistryV2.Username != "bare" || auth.RegistryV2.RefreshToken != "old-token" {
+		t.Fatalf("expected the bare-host entry to apply to its https URL, got %+v", auth.RegistryV2)
+	}
+	insecure := Auth{Kind: "registry_v2"}
+	insecure.RegistryV2.Remember = true
+	ApplyAuthCache(&insecure, "http://localhost:5000")
+	if insecure.RegistryV2.RefreshToken != "" {
+		t.Fatalf("expected the https token not to reach the http registry")
+	}
+
+	PersistAuthCache("https://localhost:5000", auth)
+	entries, err := loadAuthCache()
+	if err != nil {
+		t.Fatalf("loadAuthCache: %v", err)
+	}
+	want := map[string]string{
+		"https://localhost:5000|registry_v2":       "bare",
+		"https://registry.example.com|registry_v2": "current",
+		"http://registry.example.com|harbor":       "plain",
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("expected %d migrated entries, got %v", len(want), entries)
+	}
+	for key, username := range want {
+		if entries[key].Username != username {
+			t.Fatalf("expected %s to keep %q, got %v", key, username, entries)
+		}
+	}
+}
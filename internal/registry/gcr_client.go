@@ -0,0 +1,130 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// gcloudTokenLifetime is shorter than the hour gcloud tokens live for, so a
+// cached token is refreshed before the registry starts rejecting it.
+const gcloudTokenLifetime = 45 * time.Minute
+
+// gcloudAccessToken is swapped out in tests.
+var gcloudAccessToken = printGcloudAccessToken
+
+// GCRClient talks to gcr.io and Artifact Registry. Requests go through the
+// v2 client; only the token source differs.
+type GCRClient struct {
+	*HTTPClient
+}
+
+func newGCRClient(baseURL *url.URL, auth Auth, logger RequestLogger) *GCRClient {
+	client := newRegistryV2Client(baseURL, auth, logger)
+	client.scope = "registry:catalog:* repository:*:pull"
+	gcr := &GCRClient{HTTPClient: client}
+	client.bearer = gcr.accessToken
+	return gcr
+}
+
+func (c *GCRClient) accessToken(ctx context.Context) (string, error) {
+	c.tokenMu.Lock()
+	if c.registryToken != "" && time.Until(c.registryExpiry) > 30*time.Second {
+		token := c.registryToken
+		c.tokenMu.Unlock()
+		return token, nil
+	}
+	c.tokenMu.Unlock()
+
+	var (
+		token  string
+		expiry time.Time
+		err    error
+	)
+	if keyPath := strings.TrimSpace(os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")); keyPath != "" {
+		token, expiry, err = c.exchangeJSONKey(ctx, keyPath)
+	} else {
+		token, err = gcloudAccessToken(ctx)
+		expiry = time.Now().Add(gcloudTokenLifetime)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	c.tokenMu.Lock()
+	c.registryToken = token
+	c.registryExpiry = expiry
+	c.tokenMu.Unlock()
+	return token, nil
+}
+
+// exchangeJSONKey trades a service account key for a registry token using the
+// _json_key basic auth flow docker login uses.
+func (c *GCRClient) exchangeJSONKey(ctx context.Context, keyPath string) (string, time.Time, error) {
+	key, err := os.ReadFile(keyPath)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("read GOOGLE_APPLICATION_CREDENTIALS: %w", err)
+	}
+
+	query := url.Values{}
+	query.Set("scope", c.scope)
+	query.Set("service", firstNonEmptyToken(c.auth.RegistryV2.Service, c.baseURL.Host))
+	tokenURL := c.auth.RegistryV2.TokenURL
+	if tokenURL == "" {
+		tokenURL = c.resolve("/v2/token", query)
+	} else {
+		tokenURL += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL, nil)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	req.SetBasicAuth("_json_key", string(key))
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	c.logRequest(req, resp, start)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return "", time.Time{}, fmt.Errorf("gcr token request failed: %s", resp.Status)
+	}
+
+	token, _, expiry, err := decodeTokenResponse(resp)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if token == "" {
+		return "", time.Time{}, errors.New("gcr token response missing token")
+	}
+	return token, expiry, nil
+}
+
+func printGcloudAccessToken(ctx context.Context) (string, error) {
+	out, err := exec.CommandContext(ctx, "gcloud", "auth", "print-access-token").Output()
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return "", errors.New("gcloud not found; install the Google Cloud SDK or set GOOGLE_APPLICATION_CREDENTIALS")
+		}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("gcloud auth print-access-token: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("gcloud auth print-access-token: %w", err)
+	}
+	token := strings.TrimSpace(string(out))
+	if token == "" {
+		return "", errors.New("gcloud returned an empty access token")
+	}
+	return token, nil
+}
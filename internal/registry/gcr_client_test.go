@@ -0,0 +1,80 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGCRClientUsesGcloudToken(t *testing.T) {
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", "")
+	calls := 0
+	original := gcloudAccessToken
+	gcloudAccessToken = func(context.Context) (string, error) {
+		calls++
+		return "gcloud-token", nil
+	}
+	defer func() { gcloudAccessToken = original }()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer gcloud-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string][]string{"repositories": {"proj/app"}, "tags": {"v1"}})
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithLogger(server.URL, Auth{Kind: "gcr"}, nil)
+	if err != nil {
+		t.Fatalf("NewClientWithLogger: %v", err)
+	}
+	if _, err := client.ListImages(context.Background()); err != nil {
+		t.Fatalf("ListImages: %v", err)
+	}
+	if _, err := client.ListTags(context.Background(), "proj/app"); err != nil {
+		t.Fatalf("ListTags: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected the gcloud token to be cached, got %d calls", calls)
+	}
+}
+
+func TestGCRClientExchangesJSONKey(t *testing.T) {
+	keyPath := filepath.Join(t.TempDir(), "key.json")
+	if err := os.WriteFile(keyPath, []byte(`{"type":"service_account"}`), 0o600); err != nil {
+		t.Fatalf("write key: %v", err)
+	}
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", keyPath)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/token" {
+			user, pass, ok := r.BasicAuth()
+			if !ok || user != "_json_key" || pass != `{"type":"service_account"}` {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]string{"token": "exchanged"})
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer exchanged" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string][]string{"repositories": {"proj/app"}})
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithLogger(server.URL, Auth{Kind: "gcr"}, nil)
+	if err != nil {
+		t.Fatalf("NewClientWithLogger: %v", err)
+	}
+	images, err := client.ListImages(context.Background())
+	if err != nil || len(images) != 1 {
+		t.Fatalf("expected catalog with exchanged token, got %v (err %v)", images, err)
+	}
+}
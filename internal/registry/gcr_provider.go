@@ -0,0 +1,37 @@
+package registry
+
+import "net/url"
+
+// GCRProvider covers gcr.io and Artifact Registry (*-docker.pkg.dev). Tokens
+// come from gcloud or GOOGLE_APPLICATION_CREDENTIALS, so there is no prompt.
+type GCRProvider struct{}
+
+func (GCRProvider) Kind() string {
+	return "gcr"
+}
+
+func (GCRProvider) TableSpec() TableSpec {
+	return RegistryV2Provider{}.TableSpec()
+}
+
+func (GCRProvider) NeedsAuthPrompt(Auth) bool {
+	return false
+}
+
+func (GCRProvider) AuthUI(Auth) AuthUI {
+	return AuthUI{}
+}
+
+func (GCRProvider) PrepareAuth(baseURL *url.URL, auth *Auth) error {
+	if auth.Kind == "" || auth.Kind == "none" {
+		auth.Kind = "gcr"
+	}
+	if auth.RegistryV2.Service == "" && baseURL != nil && baseURL.Host != "" {
+		auth.RegistryV2.Service = baseURL.Host
+	}
+	return nil
+}
+
+func (GCRProvider) NewClient(baseURL *url.URL, auth Auth, logger RequestLogger) (Client, error) {
+	return newGCRClient(baseURL, auth, logger), nil
+}
@@ -18,6 +18,8 @@ type GitHubContainerClient struct {
 	baseURL    *url.URL
 	httpClient *http.Client
 	logger     RequestLogger
+	apiBaseURL *url.URL
+	apiToken   string
 
 	tokenMu     sync.Mutex
 	token       string
@@ -28,23 +30,32 @@ type GitHubContainerTagsPage struct {
 	Image string
 	Tags  []Tag
 	Next  string
+	// Digest is the digest pinned in the search input (image@sha256:...), if any.
+	Digest string
 }
 
-func NewGitHubContainerClient(logger RequestLogger) *GitHubContainerClient {
+func NewGitHubContainerClient(logger RequestLogger, proxy string) *GitHubContainerClient {
 	parsed, _ := url.Parse(githubContainerBaseURL)
+	apiBase, _ := url.Parse(githubAPIBaseURL)
 	return &GitHubContainerClient{
 		baseURL:    parsed,
-		httpClient: &http.Client{Timeout: 15 * time.Second},
+		httpClient: newHTTPClient(proxy),
 		logger:     logger,
+		apiBaseURL: apiBase,
 	}
 }
 
 func (c *GitHubContainerClient) SearchTagsPage(ctx context.Context, input string) (GitHubContainerTagsPage, error) {
-	image, err := normalizeGitHubContainerInput(input)
+	image, digest, err := normalizeGitHubContainerInput(input)
 	if err != nil {
 		return GitHubContainerTagsPage{}, err
 	}
-	return c.listTagsPage(ctx, image, "")
+	page, err := c.listTagsPage(ctx, image, "")
+	if err != nil {
+		return page, err
+	}
+	page.Digest = digest
+	return page, nil
 }
 
 func (c *GitHubContainerClient) NextTagsPage(ctx context.Context, image, next string) (GitHubContainerTagsPage, error) {
@@ -82,7 +93,7 @@ func (c *GitHubContainerClient) listTagsPage(ctx context.Context, image, next st
 
 	tags := make([]Tag, 0, len(payload.Tags))
 	for _, tagName := range payload.Tags {
-		tags = append(tags, Tag{Name: tagName})
+		tags = append(tags, Tag{Name: tagName, ArtifactType: ArtifactTypeFromTagName(tagName)})
 	}
 
 	resolvedImage := strings.TrimSpace(payload.Name)
@@ -98,15 +109,25 @@ func (c *GitHubContainerClient) listTagsPage(ctx context.Context, image, next st
 }
 
 func (c *GitHubContainerClient) ListTagHistory(ctx context.Context, image, tag string) ([]HistoryEntry, error) {
+	history, _, err := c.ListTagHistoryWithDigest(ctx, image, tag)
+	return history, err
+}
+
+func (c *GitHubContainerClient) ListTagHistoryWithDigest(ctx context.Context, image, tag string) ([]HistoryEntry, string, error) {
+	details, err := c.InspectTag(ctx, image, tag)
+	return details.History, details.Digest, err
+}
+
+func (c *GitHubContainerClient) InspectTag(ctx context.Context, image, tag string) (TagDetails, error) {
 	image = strings.Trim(strings.TrimSpace(image), "/")
 	tag = strings.TrimSpace(tag)
 	if image == "" {
-		return nil, errors.New("github container image is required")
+		return TagDetails{}, errors.New("github container image is required")
 	}
 	if tag == "" {
-		return nil, errors.New("github container tag is required")
+		return TagDetails{}, errors.New("github container tag is required")
 	}
-	return listTagHistoryFromManifest(ctx, "github", image, tag, c.getManifest, c.getConfig)
+	return inspectTag(ctx, "github", image, tag, c.getManifest, c.getConfig)
 }
 
 func (c *GitHubContainerClient) doJSON(ctx context.Context, endpoint, image string, out interface{}) (http.Header, error) {
@@ -139,8 +160,9 @@ func (c *GitHubContainerClient) doWithAuth(ctx context.Context, req *http.Reques
 	if token := c.cachedToken(); token != "" {
 		req.Header.Set("Authorization", "Bearer "+token)
 	}
+	start := time.Now()
 	resp, err := c.httpClient.Do(req)
-	c.logRequest(req, resp)
+	c.logRequest(req, resp, start)
 	if err != nil {
 		return nil, err
 	}
@@ -151,10 +173,11 @@ func (c *GitHubContainerClient) doWithAuth(ctx context.Context, req *http.Reques
 	challenge := resp.Header.Get("Www-Authenticate")
 	resp.Body.Close()
 
-	realm, service, scope, ok := parseBearerChallenge(challenge)
-	if !ok {
+	parsed, ok := parseBearerChallenge(challenge)
+	if !ok || parsed.realm == "" {
 		return nil, errors.New("github container registry requires bearer auth")
 	}
+	service, scope := parsed.service, parsed.scope
 	if service == "" && c.baseURL != nil {
 		service = c.baseURL.Host
 	}
@@ -162,7 +185,7 @@ func (c *GitHubContainerClient) doWithAuth(ctx context.Context, req *http.Reques
 		scope = fmt.Sprintf("repository:%s:pull", strings.Trim(image, "/"))
 	}
 
-	token, expiry, err := c.fetchToken(ctx, realm, service, scope)
+	token, expiry, err := c.fetchToken(ctx, parsed.realm, service, scope)
 	if err != nil {
 		return nil, err
 	}
@@ -175,11 +198,16 @@ func (c *GitHubContainerClient) doWithAuth(ctx context.Context, req *http.Reques
 	retryReq.Header = req.Header.Clone()
 	retryReq.Header.Set("Authorization", "Bearer "+token)
 
+	retryStart := time.Now()
 	retryResp, retryErr := c.httpClient.Do(retryReq)
-	c.logRequest(retryReq, retryResp)
+	c.logRequest(retryReq, retryResp, retryStart)
 	if retryErr != nil {
 		return nil, retryErr
 	}
+	if err := challengeError(retryResp); err != nil {
+		retryResp.Body.Close()
+		return nil, err
+	}
 	return retryResp, nil
 }
 
@@ -189,12 +217,7 @@ func (c *GitHubContainerClient) getManifest(ctx context.Context, image, referenc
 	if err != nil {
 		return ManifestV2{}, err
 	}
-	req.Header.Set("Accept", strings.Join([]string{
-		"application/vnd.docker.distribution.manifest.v2+json",
-		"application/vnd.oci.image.manifest.v1+json",
-		"application/vnd.docker.distribution.manifest.list.v2+json",
-		"application/vnd.oci.image.index.v1+json",
-	}, ", "))
+	req.Header.Set("Accept", manifestAcceptHeader(nil))
 
 	resp, err := c.doWithAuth(ctx, req, image)
 	if err != nil {
@@ -206,11 +229,7 @@ func (c *GitHubContainerClient) getManifest(ctx context.Context, image, referenc
 		return ManifestV2{}, fmt.Errorf("github manifest request failed: %s", resp.Status)
 	}
 
-	var manifest ManifestV2
-	if err := json.NewDecoder(resp.Body).Decode(&manifest); err != nil {
-		return ManifestV2{}, err
-	}
-	return manifest, nil
+	return decodeManifest(resp)
 }
 
 func (c *GitHubContainerClient) getConfig(ctx context.Context, image, digest string) (ConfigV2, error) {
@@ -276,7 +295,7 @@ func (c *GitHubContainerClient) resolveNext(next string) string {
 	return resolveNextURL(c.baseURL, next)
 }
 
-func (c *GitHubContainerClient) logRequest(req *http.Request, resp *http.Response) {
+func (c *GitHubContainerClient) logRequest(req *http.Request, resp *http.Response, start time.Time) {
 	if c.logger == nil {
 		return
 	}
@@ -285,10 +304,12 @@ func (c *GitHubContainerClient) logRequest(req *http.Request, resp *http.Respons
 		status = resp.StatusCode
 	}
 	c.logger(RequestLog{
-		Method:  req.Method,
-		URL:     req.URL.String(),
-		Headers: cloneHeader(req.Header),
-		Status:  status,
+		Method:    req.Method,
+		URL:       req.URL.String(),
+		Headers:   cloneHeader(req.Header),
+		Status:    status,
+		StartedAt: start,
+		Duration:  time.Since(start),
 	})
 }
 
@@ -297,10 +318,10 @@ type githubContainerTagsResponse struct {
 	Tags []string `json:"tags"`
 }
 
-func normalizeGitHubContainerInput(input string) (string, error) {
+func normalizeGitHubContainerInput(input string) (string, string, error) {
 	trimmed := strings.TrimSpace(input)
 	if trimmed == "" {
-		return "", errors.New("github search requires an image name (owner/image)")
+		return "", "", errors.New("github search requires an image name (owner/image)")
 	}
 	trimmed = strings.TrimPrefix(trimmed, "ghcr.io/")
 	trimmed = strings.TrimPrefix(trimmed, "https://ghcr.io/")
@@ -312,9 +333,7 @@ func normalizeGitHubContainerInput(input string) (string, error) {
 			trimmed = strings.TrimPrefix(parsed.Path, "/")
 		}
 	}
-	if at := strings.Index(trimmed, "@"); at != -1 {
-		trimmed = trimmed[:at]
-	}
+	trimmed, digest := splitDigest(trimmed)
 	if colon := strings.LastIndex(trimmed, ":"); colon != -1 {
 		if slash := strings.LastIndex(trimmed, "/"); slash == -1 || colon > slash {
 			trimmed = trimmed[:colon]
@@ -323,19 +342,19 @@ func normalizeGitHubContainerInput(input string) (string, error) {
 
 	trimmed = strings.Trim(trimmed, "/")
 	if trimmed == "" {
-		return "", errors.New("github search requires an image name (owner/image)")
+		return "", "", errors.New("github search requires an image name (owner/image)")
 	}
 
 	parts := strings.Split(trimmed, "/")
 	if len(parts) < 2 {
-		return "", fmt.Errorf("invalid GitHub container image %q (expected owner/image)", trimmed)
+		return "", "", fmt.Errorf("invalid GitHub container image %q (expected owner/image)", trimmed)
 	}
 	for _, part := range parts {
 		if strings.TrimSpace(part) == "" {
-			return "", fmt.Errorf("invalid GitHub container image %q", trimmed)
+			return "", "", fmt.Errorf("invalid GitHub container image %q", trimmed)
 		}
 	}
-	return trimmed, nil
+	return trimmed, digest, nil
 }
 
 func parseGitHubContainerNext(headerValue string, baseURL *url.URL) string {
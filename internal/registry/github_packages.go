@@ -0,0 +1,145 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const githubAPIBaseURL = "https://api.github.com"
+
+// ErrGitHubTokenRequired is returned when listing packages without a GitHub
+// API token. The packages API does not allow anonymous access.
+var ErrGitHubTokenRequired = errors.New("listing GHCR packages requires a GitHub token (set GITHUB_TOKEN)")
+
+type GitHubPackage struct {
+	Name       string
+	Owner      string
+	Visibility string
+	UpdatedAt  time.Time
+}
+
+// Image returns the owner/name path used by the container registry.
+func (p GitHubPackage) Image() string {
+	return p.Owner + "/" + p.Name
+}
+
+// GitHubAPIToken returns the token used for GitHub REST API calls, preferring
+// an explicit value over GITHUB_TOKEN.
+func GitHubAPIToken(explicit string) string {
+	if token := strings.TrimSpace(explicit); token != "" {
+		return token
+	}
+	return strings.TrimSpace(os.Getenv("GITHUB_TOKEN"))
+}
+
+// WithAPIToken sets the GitHub REST API token used by ListPackages.
+func (c *GitHubContainerClient) WithAPIToken(token string) *GitHubContainerClient {
+	c.apiToken = strings.TrimSpace(token)
+	return c
+}
+
+// ListPackages lists the container packages owned by a user or organization.
+func (c *GitHubContainerClient) ListPackages(ctx context.Context, owner string) ([]GitHubPackage, error) {
+	owner = strings.Trim(strings.TrimSpace(owner), "/")
+	if owner == "" {
+		return nil, errors.New("github owner is required")
+	}
+	if c.apiToken == "" {
+		return nil, ErrGitHubTokenRequired
+	}
+
+	packages, err := c.listPackages(ctx, "users", owner)
+	if errors.Is(err, errGitHubOwnerNotFound) {
+		packages, err = c.listPackages(ctx, "orgs", owner)
+	}
+	if errors.Is(err, errGitHubOwnerNotFound) {
+		return nil, fmt.Errorf("no GitHub user or organization found for %q", owner)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].Name < packages[j].Name
+	})
+	return packages, nil
+}
+
+var errGitHubOwnerNotFound = errors.New("github owner not found")
+
+func (c *GitHubContainerClient) listPackages(ctx context.Context, ownerType, owner string) ([]GitHubPackage, error) {
+	base := c.apiBaseURL
+	query := url.Values{}
+	query.Set("package_type", "container")
+	query.Set("per_page", "100")
+	endpoint := resolveURL(base, fmt.Sprintf("/%s/%s/packages", ownerType, url.PathEscape(owner)), query)
+
+	var out []GitHubPackage
+	for endpoint != "" {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", "application/vnd.github+json")
+		req.Header.Set("Authorization", "Bearer "+c.apiToken)
+
+		start := time.Now()
+		resp, err := c.httpClient.Do(req)
+		c.logRequest(req, resp, start)
+		if err != nil {
+			return nil, err
+		}
+
+		var payload []githubPackageResponse
+		switch {
+		case resp.StatusCode == http.StatusNotFound:
+			resp.Body.Close()
+			return nil, errGitHubOwnerNotFound
+		case resp.StatusCode >= 300:
+			resp.Body.Close()
+			return nil, fmt.Errorf("github packages request failed: %s", resp.Status)
+		}
+		err = json.NewDecoder(resp.Body).Decode(&payload)
+		next := parseGitHubContainerNext(resp.Header.Get("Link"), base)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pkg := range payload {
+			name := strings.TrimSpace(pkg.Name)
+			if name == "" {
+				continue
+			}
+			pkgOwner := strings.TrimSpace(pkg.Owner.Login)
+			if pkgOwner == "" {
+				pkgOwner = owner
+			}
+			out = append(out, GitHubPackage{
+				Name:       name,
+				Owner:      strings.ToLower(pkgOwner),
+				Visibility: pkg.Visibility,
+				UpdatedAt:  parseDockerTime(pkg.UpdatedAt),
+			})
+		}
+		endpoint = next
+	}
+	return out, nil
+}
+
+type githubPackageResponse struct {
+	Name       string `json:"name"`
+	Visibility string `json:"visibility"`
+	UpdatedAt  string `json:"updated_at"`
+	Owner      struct {
+		Login string `json:"login"`
+	} `json:"owner"`
+}
@@ -0,0 +1,59 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestGitHubListPackages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("unexpected authorization header %q", got)
+		}
+		if got := r.URL.Query().Get("package_type"); got != "container" {
+			t.Errorf("expected package_type=container, got %q", got)
+		}
+		switch r.URL.Path {
+		case "/users/acme/packages":
+			http.NotFound(w, r)
+		case "/orgs/acme/packages":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`[
+				{"name": "worker", "visibility": "private", "updated_at": "2024-01-02T03:04:05Z", "owner": {"login": "Acme"}},
+				{"name": "api", "visibility": "public", "owner": {"login": "Acme"}}
+			]`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewGitHubContainerClient(nil, "").WithAPIToken("secret")
+	client.apiBaseURL, _ = url.Parse(server.URL)
+
+	packages, err := client.ListPackages(context.Background(), "acme")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(packages) != 2 {
+		t.Fatalf("expected 2 packages, got %d", len(packages))
+	}
+	if packages[0].Image() != "acme/api" || packages[1].Image() != "acme/worker" {
+		t.Fatalf("unexpected packages: %+v", packages)
+	}
+	if packages[1].UpdatedAt.IsZero() {
+		t.Fatalf("expected updated_at to be parsed")
+	}
+}
+
+func TestGitHubListPackagesRequiresToken(t *testing.T) {
+	client := NewGitHubContainerClient(nil, "")
+	if _, err := client.ListPackages(context.Background(), "acme"); !errors.Is(err, ErrGitHubTokenRequired) {
+		t.Fatalf("expected ErrGitHubTokenRequired, got %v", err)
+	}
+}
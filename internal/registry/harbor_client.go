@@ -8,11 +8,16 @@ import (
 	"net/http"
 	"net/url"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
-const harborPageSize = 100
+const (
+	harborPageSize       = 100
+	harborProjectWorkers = 4
+)
 
 // HarborClient implements Harbor API v2.0.
 type HarborClient struct {
@@ -24,28 +29,21 @@ type HarborClient struct {
 
 func newHarborClient(baseURL *url.URL, auth Auth, logger RequestLogger) *HarborClient {
 	return &HarborClient{
-		baseURL: baseURL,
-		httpClient: &http.Client{
-			Timeout: 15 * time.Second,
-		},
-		auth:   auth,
-		logger: logger,
+		baseURL:    baseURL,
+		httpClient: newHTTPClient(auth.Proxy),
+		auth:       auth,
+		logger:     logger,
 	}
 }
 
 func (c *HarborClient) ListImages(ctx context.Context) ([]Image, error) {
-	projects, err := c.ListProjects(ctx)
+	images := make([]Image, 0)
+	err := c.StreamImages(ctx, func(batch []Image) {
+		images = append(images, batch...)
+	})
 	if err != nil {
 		return nil, err
 	}
-	images := make([]Image, 0)
-	for _, project := range projects {
-		projectImages, err := c.ListProjectImages(ctx, project.Name)
-		if err != nil {
-			return nil, err
-		}
-		images = append(images, projectImages...)
-	}
 
 	sort.Slice(images, func(i, j int) bool {
 		return images[i].Name < images[j].Name
@@ -54,17 +52,84 @@ func (c *HarborClient) ListImages(ctx context.Context) ([]Image, error) {
 	return images, nil
 }
 
+// StreamImages lists the repositories of up to harborProjectWorkers projects
+// at a time and emits each project's images as soon as they arrive. The first
+// error cancels the remaining projects. Cross-registry tag search uses it to
+// start on the first project instead of waiting for the whole listing.
+func (c *HarborClient) StreamImages(ctx context.Context, emit func([]Image)) error {
+	return c.StreamImagesWithTotal(ctx, func(batch []Image, _ int) {
+		emit(batch)
+	})
+}
+
+// StreamImagesWithTotal is StreamImages with the expected repository count,
+// summed from the projects' repo_count, passed along with every batch.
+func (c *HarborClient) StreamImagesWithTotal(ctx context.Context, emit func(batch []Image, total int)) error {
+	projects, err := c.listProjects(ctx)
+	if err != nil {
+		return err
+	}
+	total := 0
+	for _, project := range projects {
+		total += project.RepoCount
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	jobs := make(chan string)
+	for i := 0; i < harborProjectWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for project := range jobs {
+				images, err := c.ListProjectImages(ctx, project)
+				mu.Lock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = err
+						cancel()
+					}
+				} else if firstErr == nil && len(images) > 0 {
+					emit(images, total)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, project := range projects {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- project.Name
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
 func (c *HarborClient) ListProjects(ctx context.Context) ([]Project, error) {
 	rawProjects, err := c.listProjects(ctx)
 	if err != nil {
 		return nil, err
 	}
+	// Artifact totals need every repository; ProjectArtifactCounts loads them.
 	projects := make([]Project, 0, len(rawProjects))
 	for _, project := range rawProjects {
 		projects = append(projects, Project{
-			Name:       project.Name,
-			ImageCount: project.RepoCount,
-			UpdatedAt:  parseHarborTime(project.UpdateTime),
+			Name:          project.Name,
+			ImageCount:    project.RepoCount,
+			ArtifactCount: -1,
+			UpdatedAt:     parseHarborTime(project.UpdateTime),
 		})
 	}
 	sort.Slice(projects, func(i, j int) bool {
@@ -73,6 +138,29 @@ func (c *HarborClient) ListProjects(ctx context.Context) ([]Project, error) {
 	return projects, nil
 }
 
+// ListProjectsPage fetches a single page of projects without artifact
+// totals, which would need the full repository listing.
+func (c *HarborClient) ListProjectsPage(ctx context.Context, page, limit int) ([]Project, error) {
+	var batch []harborProject
+	endpoint := c.resolve("/api/v2.0/projects", url.Values{
+		"page":      []string{fmt.Sprintf("%d", page)},
+		"page_size": []string{fmt.Sprintf("%d", limit)},
+	})
+	if err := c.doJSON(ctx, http.MethodGet, endpoint, nil, &batch); err != nil {
+		return nil, err
+	}
+	projects := make([]Project, 0, len(batch))
+	for _, project := range batch {
+		projects = append(projects, Project{
+			Name:          project.Name,
+			ImageCount:    project.RepoCount,
+			ArtifactCount: -1,
+			UpdatedAt:     parseHarborTime(project.UpdateTime),
+		})
+	}
+	return projects, nil
+}
+
 func (c *HarborClient) ListProjectImages(ctx context.Context, project string) ([]Image, error) {
 	repos, err := c.listProjectRepos(ctx, project)
 	if err != nil {
@@ -95,60 +183,131 @@ func (c *HarborClient) ListProjectImages(ctx context.Context, project string) ([
 }
 
 func (c *HarborClient) ListTags(ctx context.Context, image string) ([]Tag, error) {
+	var tags []Tag
+	err := c.StreamTags(ctx, image, "", func(batch []Tag) {
+		tags = append(tags, batch...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// StreamTags emits the tags of each artifact page as it arrives. A filter is
+// sent as Harbor's fuzzy q=tags=~<filter> query.
+func (c *HarborClient) StreamTags(ctx context.Context, image, filter string, emit func([]Tag)) error {
 	project, repo := splitHarborImage(image)
 	if project == "" || repo == "" {
-		return nil, nil
+		return nil
 	}
 
-	var all []harborArtifact
-	page := 1
-	for {
+	pushers := c.tagPushers(ctx, project, repo)
+	filter = strings.TrimSpace(filter)
+	loaded := 0
+	for page := 1; ; page++ {
+		query := url.Values{
+			"page":       []string{fmt.Sprintf("%d", page)},
+			"page_size":  []string{fmt.Sprintf("%d", harborPageSize)},
+			"with_label": []string{"true"},
+		}
+		if filter != "" {
+			query.Set("q", "tags=~"+filter)
+		}
 		var batch []harborArtifact
-		endpoint := c.resolve(fmt.Sprintf("/api/v2.0/projects/%s/repositories/%s/artifacts", url.PathEscape(project), url.PathEscape(repo)), url.Values{
-			"page":      []string{fmt.Sprintf("%d", page)},
-			"page_size": []string{fmt.Sprintf("%d", harborPageSize)},
-		})
-		if err := c.doJSON(ctx, http.MethodGet, endpoint, nil, &batch); err != nil {
-			return nil, err
+		endpoint := c.resolve(fmt.Sprintf("/api/v2.0/projects/%s/repositories/%s/artifacts", url.PathEscape(project), url.PathEscape(repo)), query)
+		header, err := c.doJSONWithHeader(ctx, http.MethodGet, endpoint, nil, &batch)
+		if err != nil {
+			return err
 		}
-		all = append(all, batch...)
-		if len(batch) < harborPageSize {
-			break
+		if tags := harborArtifactTags(batch, pushers); len(tags) > 0 {
+			emit(tags)
+		}
+		loaded += len(batch)
+		if harborLastPage(len(batch), loaded, harborTotalCount(header)) {
+			return nil
 		}
-		page++
 	}
+}
 
-	var tags []Tag
-	for _, artifact := range all {
-		if len(artifact.Tags) == 0 {
+// tagPushers maps the tags of a repository to the user who pushed them last,
+// read from the newest page of the project audit log. It is best effort:
+// users who can't read the log, and tags pushed before that page, get none.
+func (c *HarborClient) tagPushers(ctx context.Context, project, repo string) map[string]string {
+	prefix := project + "/" + repo + ":"
+	query := url.Values{
+		"page":      []string{"1"},
+		"page_size": []string{fmt.Sprintf("%d", harborPageSize)},
+		"sort":      []string{"-op_time"},
+		"q":         []string{"operation=create,resource=~" + prefix},
+	}
+	var logs []harborAuditLog
+	endpoint := c.resolve(fmt.Sprintf("/api/v2.0/projects/%s/logs", url.PathEscape(project)), query)
+	if err := c.doJSON(ctx, http.MethodGet, endpoint, nil, &logs); err != nil {
+		return nil
+	}
+	pushers := make(map[string]string)
+	for _, entry := range logs {
+		tag, ok := strings.CutPrefix(entry.Resource, prefix)
+		if !ok || tag == "" || entry.Operation != "create" || entry.Username == "" {
 			continue
 		}
+		if _, seen := pushers[tag]; !seen {
+			pushers[tag] = entry.Username
+		}
+	}
+	return pushers
+}
+
+func harborArtifactTags(artifacts []harborArtifact, pushers map[string]string) []Tag {
+	var tags []Tag
+	for _, artifact := range artifacts {
 		for _, t := range artifact.Tags {
 			tags = append(tags, Tag{
 				Name:         t.Name,
 				Digest:       artifact.Digest,
 				SizeBytes:    artifact.Size,
 				UpdatedAt:    parseHarborTime(artifact.UpdateTime),
-				PushedAt:     parseHarborTime(t.PushTime),
-				LastPulledAt: parseHarborTime(t.PullTime),
+				PushedAt:     parseHarborTime(firstNonEmptyString(t.PushTime, artifact.PushTime)),
+				LastPulledAt: parseHarborTime(firstNonEmptyString(t.PullTime, artifact.PullTime)),
+				ArtifactType: harborArtifactType(artifact, t.Name),
+				Platforms:    artifact.platformCount(),
+				Labels:       artifact.labelNames(),
+				PushedBy:     pushers[t.Name],
 			})
 		}
 	}
-
-	return tags, nil
+	return tags
 }
 
 func (c *HarborClient) ListTagHistory(ctx context.Context, image, tag string) ([]HistoryEntry, error) {
+	history, _, err := c.ListTagHistoryWithDigest(ctx, image, tag)
+	return history, err
+}
+
+func (c *HarborClient) ListTagHistoryWithDigest(ctx context.Context, image, tag string) ([]HistoryEntry, string, error) {
+	details, err := c.InspectTag(ctx, image, tag)
+	return details.History, details.Digest, err
+}
+
+func (c *HarborClient) InspectTag(ctx context.Context, image, tag string) (TagDetails, error) {
 	image = strings.TrimSpace(image)
 	tag = strings.TrimSpace(tag)
 	if image == "" || tag == "" {
-		return nil, nil
+		return TagDetails{}, nil
 	}
-	return listTagHistoryFromManifest(ctx, "harbor", image, tag, c.getManifest, c.getConfig)
+	return inspectTag(ctx, "harbor", image, tag, c.getManifest, c.getConfig)
 }
 
+// DeleteTag removes the tag from its artifact; the artifact itself is kept.
 func (c *HarborClient) DeleteTag(ctx context.Context, image, tag string) error {
-	return ErrNotSupported
+	project, repo := splitHarborImage(image)
+	tag = strings.TrimSpace(tag)
+	if project == "" || repo == "" || tag == "" {
+		return fmt.Errorf("invalid harbor image %q or tag %q", image, tag)
+	}
+	endpoint := c.resolve(fmt.Sprintf("/api/v2.0/projects/%s/repositories/%s/artifacts/%s/tags/%s",
+		url.PathEscape(project), url.PathEscape(repo), url.PathEscape(tag), url.PathEscape(tag)), nil)
+	return c.doJSON(ctx, http.MethodDelete, endpoint, nil, nil)
 }
 
 func (c *HarborClient) RenameTag(ctx context.Context, image, from, to string) error {
@@ -160,29 +319,55 @@ func (c *HarborClient) resolve(path string, query url.Values) string {
 }
 
 func (c *HarborClient) doJSON(ctx context.Context, method, endpoint string, body io.Reader, out interface{}) error {
+	_, err := c.doJSONWithHeader(ctx, method, endpoint, body, out)
+	return err
+}
+
+// doJSONWithHeader is doJSON that also returns the response headers, for the
+// paged listings that read X-Total-Count.
+func (c *HarborClient) doJSONWithHeader(ctx context.Context, method, endpoint string, body io.Reader, out interface{}) (http.Header, error) {
 	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if !c.auth.Harbor.Anonymous {
 		req.SetBasicAuth(c.auth.Harbor.Username, c.auth.Harbor.Password)
 	}
 
+	start := time.Now()
 	resp, err := c.httpClient.Do(req)
-	c.logRequest(req, resp)
+	c.logRequest(req, resp, start)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusUnauthorized {
+		return resp.Header, fmt.Errorf("harbor request failed: %w: %s", ErrUnauthorized, resp.Status)
+	}
 	if resp.StatusCode >= 300 {
-		return fmt.Errorf("harbor request failed: %s", resp.Status)
+		return resp.Header, fmt.Errorf("harbor request failed: %s", resp.Status)
 	}
 
 	if out == nil {
-		return nil
+		return resp.Header, nil
+	}
+	return resp.Header, json.NewDecoder(resp.Body).Decode(out)
+}
+
+// harborTotalCount reads X-Total-Count, or -1 when Harbor didn't send it.
+func harborTotalCount(header http.Header) int {
+	total, err := strconv.Atoi(strings.TrimSpace(header.Get("X-Total-Count")))
+	if err != nil || total < 0 {
+		return -1
 	}
-	return json.NewDecoder(resp.Body).Decode(out)
+	return total
+}
+
+// harborLastPage reports whether a paged listing is complete: the page came
+// back short, or everything X-Total-Count announced has been read.
+func harborLastPage(batchLen, loaded, total int) bool {
+	return batchLen < harborPageSize || (total >= 0 && loaded >= total)
 }
 
 func (c *HarborClient) getManifest(ctx context.Context, image, reference string) (ManifestV2, error) {
@@ -191,18 +376,14 @@ func (c *HarborClient) getManifest(ctx context.Context, image, reference string)
 	if err != nil {
 		return ManifestV2{}, err
 	}
-	req.Header.Set("Accept", strings.Join([]string{
-		"application/vnd.docker.distribution.manifest.v2+json",
-		"application/vnd.oci.image.manifest.v1+json",
-		"application/vnd.docker.distribution.manifest.list.v2+json",
-		"application/vnd.oci.image.index.v1+json",
-	}, ", "))
+	req.Header.Set("Accept", manifestAcceptHeader(c.auth.ManifestAccept))
 	if !c.auth.Harbor.Anonymous {
 		req.SetBasicAuth(c.auth.Harbor.Username, c.auth.Harbor.Password)
 	}
 
+	start := time.Now()
 	resp, err := c.httpClient.Do(req)
-	c.logRequest(req, resp)
+	c.logRequest(req, resp, start)
 	if err != nil {
 		return ManifestV2{}, err
 	}
@@ -212,11 +393,7 @@ func (c *HarborClient) getManifest(ctx context.Context, image, reference string)
 		return ManifestV2{}, fmt.Errorf("harbor manifest request failed: %s", resp.Status)
 	}
 
-	var manifest ManifestV2
-	if err := json.NewDecoder(resp.Body).Decode(&manifest); err != nil {
-		return ManifestV2{}, err
-	}
-	return manifest, nil
+	return decodeManifest(resp)
 }
 
 func (c *HarborClient) getConfig(ctx context.Context, image, digest string) (ConfigV2, error) {
@@ -229,8 +406,9 @@ func (c *HarborClient) getConfig(ctx context.Context, image, digest string) (Con
 		req.SetBasicAuth(c.auth.Harbor.Username, c.auth.Harbor.Password)
 	}
 
+	start := time.Now()
 	resp, err := c.httpClient.Do(req)
-	c.logRequest(req, resp)
+	c.logRequest(req, resp, start)
 	if err != nil {
 		return ConfigV2{}, err
 	}
@@ -247,7 +425,7 @@ func (c *HarborClient) getConfig(ctx context.Context, image, digest string) (Con
 	return cfg, nil
 }
 
-func (c *HarborClient) logRequest(req *http.Request, resp *http.Response) {
+func (c *HarborClient) logRequest(req *http.Request, resp *http.Response, start time.Time) {
 	if c.logger == nil {
 		return
 	}
@@ -256,10 +434,12 @@ func (c *HarborClient) logRequest(req *http.Request, resp *http.Response) {
 		status = resp.StatusCode
 	}
 	c.logger(RequestLog{
-		Method:  req.Method,
-		URL:     req.URL.String(),
-		Headers: cloneHeader(req.Header),
-		Status:  status,
+		Method:    req.Method,
+		URL:       req.URL.String(),
+		Headers:   cloneHeader(req.Header),
+		Status:    status,
+		StartedAt: start,
+		Duration:  time.Since(start),
 	})
 }
 
@@ -278,15 +458,53 @@ type harborRepository struct {
 }
 
 type harborArtifact struct {
-	Digest     string        `json:"digest"`
-	Size       int64         `json:"size"`
-	Tags       []harborTag   `json:"tags"`
-	UpdateTime string        `json:"update_time"`
-	PushTime   string        `json:"push_time"`
-	PullTime   string        `json:"pull_time"`
-	ExtraAttrs harborAttrs   `json:"extra_attrs"`
-	Type       string        `json:"type"`
-	References []interface{} `json:"references"`
+	Digest     string            `json:"digest"`
+	Size       int64             `json:"size"`
+	Tags       []harborTag       `json:"tags"`
+	UpdateTime string            `json:"update_time"`
+	PushTime   string            `json:"push_time"`
+	PullTime   string            `json:"pull_time"`
+	ExtraAttrs harborAttrs       `json:"extra_attrs"`
+	Type       string            `json:"type"`
+	MediaType  string            `json:"media_type"`
+	References []harborReference `json:"references"`
+	Labels     []harborLabel     `json:"labels"`
+}
+
+type harborLabel struct {
+	Name string `json:"name"`
+}
+
+type harborAuditLog struct {
+	Username  string `json:"username"`
+	Resource  string `json:"resource"`
+	Operation string `json:"operation"`
+}
+
+type harborReference struct {
+	ChildDigest string           `json:"child_digest"`
+	Platform    ManifestPlatform `json:"platform"`
+}
+
+func (a harborArtifact) platformCount() int {
+	descriptors := make([]ManifestDescriptor, 0, len(a.References))
+	for _, ref := range a.References {
+		descriptors = append(descriptors, ManifestDescriptor{Digest: ref.ChildDigest, Platform: ref.Platform})
+	}
+	return platformCount(descriptors)
+}
+
+func (a harborArtifact) labelNames() []string {
+	if len(a.Labels) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(a.Labels))
+	for _, label := range a.Labels {
+		if name := strings.TrimSpace(label.Name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
 }
 
 type harborTag struct {
@@ -310,6 +528,37 @@ func parseHarborTime(value string) time.Time {
 	return time.Time{}
 }
 
+// ProjectArtifactCounts sums artifact_count per project from the global
+// repository listing, which is one paged call instead of one per project.
+func (c *HarborClient) ProjectArtifactCounts(ctx context.Context) (map[string]int, error) {
+	counts := make(map[string]int)
+	page, loaded := 1, 0
+	for {
+		var batch []harborRepository
+		endpoint := c.resolve("/api/v2.0/repositories", url.Values{
+			"page":      []string{fmt.Sprintf("%d", page)},
+			"page_size": []string{fmt.Sprintf("%d", harborPageSize)},
+		})
+		header, err := c.doJSONWithHeader(ctx, http.MethodGet, endpoint, nil, &batch)
+		if err != nil {
+			return nil, err
+		}
+		loaded += len(batch)
+		for _, repo := range batch {
+			project, _, ok := strings.Cut(repo.Name, "/")
+			if !ok {
+				continue
+			}
+			counts[project] += repo.ArtifactCount
+		}
+		if harborLastPage(len(batch), loaded, harborTotalCount(header)) {
+			break
+		}
+		page++
+	}
+	return counts, nil
+}
+
 func (c *HarborClient) listProjects(ctx context.Context) ([]harborProject, error) {
 	var all []harborProject
 	page := 1
@@ -319,11 +568,16 @@ func (c *HarborClient) listProjects(ctx context.Context) ([]harborProject, error
 			"page":      []string{fmt.Sprintf("%d", page)},
 			"page_size": []string{fmt.Sprintf("%d", harborPageSize)},
 		})
-		if err := c.doJSON(ctx, http.MethodGet, endpoint, nil, &batch); err != nil {
+		header, err := c.doJSONWithHeader(ctx, http.MethodGet, endpoint, nil, &batch)
+		if err != nil {
 			return nil, err
 		}
+		total := harborTotalCount(header)
+		if all == nil && total > 0 {
+			all = make([]harborProject, 0, total)
+		}
 		all = append(all, batch...)
-		if len(batch) < harborPageSize {
+		if harborLastPage(len(batch), len(all), total) {
 			break
 		}
 		page++
@@ -343,11 +597,16 @@ func (c *HarborClient) listProjectRepos(ctx context.Context, project string) ([]
 			"page":      []string{fmt.Sprintf("%d", page)},
 			"page_size": []string{fmt.Sprintf("%d", harborPageSize)},
 		})
-		if err := c.doJSON(ctx, http.MethodGet, endpoint, nil, &batch); err != nil {
+		header, err := c.doJSONWithHeader(ctx, http.MethodGet, endpoint, nil, &batch)
+		if err != nil {
 			return nil, err
 		}
+		total := harborTotalCount(header)
+		if all == nil && total > 0 {
+			all = make([]harborRepository, 0, total)
+		}
 		all = append(all, batch...)
-		if len(batch) < harborPageSize {
+		if harborLastPage(len(batch), len(all), total) {
 			break
 		}
 		page++
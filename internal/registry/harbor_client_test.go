@@ -0,0 +1,391 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHarborListProjectsArtifactCounts(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	failRepositories := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2.0/projects":
+			_ = json.NewEncoder(w).Encode([]harborProject{
+				{Name: "library", RepoCount: 2},
+				{Name: "team", RepoCount: 1},
+			})
+		case "/api/v2.0/repositories":
+			if failRepositories {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			_ = json.NewEncoder(w).Encode([]harborRepository{
+				{Name: "library/nginx", ArtifactCount: 5},
+				{Name: "library/redis", ArtifactCount: 3},
+				{Name: "team/api", ArtifactCount: 7},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	auth := Auth{Kind: "harbor"}
+	auth.Harbor.Anonymous = true
+	client, err := NewClientWithLogger(server.URL, auth, nil)
+	if err != nil {
+		t.Fatalf("NewClientWithLogger: %v", err)
+	}
+	failRepositories = true
+	projects, err := client.(ProjectClient).ListProjects(context.Background())
+	if err != nil {
+		t.Fatalf("expected the project list not to need the repository listing, got %v", err)
+	}
+	if len(projects) != 2 || projects[0].ArtifactCount != -1 {
+		t.Fatalf("expected projects with unknown artifact counts, got %+v", projects)
+	}
+
+	failRepositories = false
+	counts, err := client.(ProjectArtifactCounter).ProjectArtifactCounts(context.Background())
+	if err != nil {
+		t.Fatalf("ProjectArtifactCounts: %v", err)
+	}
+	if counts["library"] != 8 || counts["team"] != 7 {
+		t.Fatalf("expected artifact totals 8 and 7, got %v", counts)
+	}
+}
+
+func TestHarborStreamImagesPerProject(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	repos := map[string][]harborRepository{
+		"library": {{Name: "library/nginx"}, {Name: "library/redis"}},
+		"team":    {{Name: "team/api"}},
+		"empty":   nil,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2.0/projects" {
+			_ = json.NewEncoder(w).Encode([]harborProject{{Name: "team"}, {Name: "library"}, {Name: "empty"}})
+			return
+		}
+		project := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v2.0/projects/"), "/repositories")
+		list, ok := repos[project]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(list)
+	}))
+	defer server.Close()
+
+	auth := Auth{Kind: "harbor"}
+	auth.Harbor.Anonymous = true
+	client, err := NewClientWithLogger(server.URL, auth, nil)
+	if err != nil {
+		t.Fatalf("NewClientWithLogger: %v", err)
+	}
+
+	batches := 0
+	err = client.(ImageStreamer).StreamImages(context.Background(), func(images []Image) {
+		batches++
+	})
+	if err != nil {
+		t.Fatalf("StreamImages: %v", err)
+	}
+	if batches != 2 {
+		t.Fatalf("expected one batch per non-empty project, got %d", batches)
+	}
+
+	images, err := client.ListImages(context.Background())
+	if err != nil {
+		t.Fatalf("ListImages: %v", err)
+	}
+	var names []string
+	for _, image := range images {
+		names = append(names, image.Name)
+	}
+	if strings.Join(names, ",") != "library/nginx,library/redis,team/api" {
+		t.Fatalf("expected sorted images, got %v", names)
+	}
+
+	delete(repos, "team")
+	if _, err := client.ListImages(context.Background()); err == nil {
+		t.Fatalf("expected a failing project to fail the listing")
+	}
+}
+
+func TestHarborDeleteTag(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		if user, pass, ok := r.BasicAuth(); !ok || user != "admin" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	auth := Auth{Kind: "harbor"}
+	auth.Harbor.Username = "admin"
+	auth.Harbor.Password = "secret"
+	client, err := NewClientWithLogger(server.URL, auth, nil)
+	if err != nil {
+		t.Fatalf("NewClientWithLogger: %v", err)
+	}
+	if err := client.DeleteTag(context.Background(), "library/nginx", "1.25"); err != nil {
+		t.Fatalf("DeleteTag: %v", err)
+	}
+	want := "DELETE /api/v2.0/projects/library/repositories/nginx/artifacts/1.25/tags/1.25"
+	if len(calls) != 1 || calls[0] != want {
+		t.Fatalf("expected %q, got %v", want, calls)
+	}
+}
+
+func TestHarborStreamTagsPagesWithQuery(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/logs") {
+			_ = json.NewEncoder(w).Encode([]harborAuditLog{})
+			return
+		}
+		queries = append(queries, r.URL.Query().Get("q"))
+		count := harborPageSize
+		if r.URL.Query().Get("page") == "2" {
+			count = 1
+		}
+		artifacts := make([]harborArtifact, count)
+		for i := range artifacts {
+			artifacts[i].Tags = []harborTag{{Name: fmt.Sprintf("v1.%d", i)}}
+		}
+		_ = json.NewEncoder(w).Encode(artifacts)
+	}))
+	defer server.Close()
+
+	auth := Auth{Kind: "harbor"}
+	auth.Harbor.Anonymous = true
+	client, err := NewClientWithLogger(server.URL, auth, nil)
+	if err != nil {
+		t.Fatalf("NewClientWithLogger: %v", err)
+	}
+
+	var batches []int
+	err = client.(TagStreamer).StreamTags(context.Background(), "library/nginx", "v1", func(tags []Tag) {
+		batches = append(batches, len(tags))
+	})
+	if err != nil {
+		t.Fatalf("StreamTags: %v", err)
+	}
+	if len(batches) != 2 || batches[0] != harborPageSize || batches[1] != 1 {
+		t.Fatalf("expected one batch per page, got %v", batches)
+	}
+	for _, q := range queries {
+		if q != "tags=~v1" {
+			t.Fatalf("expected q=tags=~v1 on every page, got %v", queries)
+		}
+	}
+
+	queries = nil
+	if _, err := client.ListTags(context.Background(), "library/nginx"); err != nil {
+		t.Fatalf("ListTags: %v", err)
+	}
+	if len(queries) != 2 || queries[0] != "" {
+		t.Fatalf("expected an unfiltered listing, got %v", queries)
+	}
+}
+
+func TestHarborStreamTagsLabelsAndPushers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2.0/projects/library/logs":
+			if q := r.URL.Query().Get("q"); q != "operation=create,resource=~library/nginx:" {
+				t.Errorf("unexpected audit log query %q", q)
+			}
+			_ = json.NewEncoder(w).Encode([]harborAuditLog{
+				{Username: "ci-bot", Resource: "library/nginx:v2", Operation: "create"},
+				{Username: "alice", Resource: "library/nginx:v1", Operation: "create"},
+				{Username: "bob", Resource: "library/nginx:v2", Operation: "create"},
+				{Username: "carol", Resource: "library/nginx-extra:v1", Operation: "create"},
+			})
+		case "/api/v2.0/projects/library/repositories/nginx/artifacts":
+			artifact := harborArtifact{Tags: []harborTag{{Name: "v1"}, {Name: "v2"}}}
+			if r.URL.Query().Get("with_label") == "true" {
+				artifact.Labels = []harborLabel{{Name: "prod"}}
+			}
+			_ = json.NewEncoder(w).Encode([]harborArtifact{artifact})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	auth := Auth{Kind: "harbor"}
+	auth.Harbor.Anonymous = true
+	client, err := NewClientWithLogger(server.URL, auth, nil)
+	if err != nil {
+		t.Fatalf("NewClientWithLogger: %v", err)
+	}
+
+	tags, err := client.ListTags(context.Background(), "library/nginx")
+	if err != nil {
+		t.Fatalf("ListTags: %v", err)
+	}
+	if len(tags) != 2 {
+		t.Fatalf("expected two tags, got %+v", tags)
+	}
+	for _, tag := range tags {
+		if len(tag.Labels) != 1 || tag.Labels[0] != "prod" {
+			t.Fatalf("expected the prod label on %s, got %v", tag.Name, tag.Labels)
+		}
+	}
+	if tags[0].PushedBy != "alice" || tags[1].PushedBy != "ci-bot" {
+		t.Fatalf("expected the latest pusher per tag, got %q and %q", tags[0].PushedBy, tags[1].PushedBy)
+	}
+}
+
+func TestHarborStreamTagsWithoutAuditLog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/logs") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_ = json.NewEncoder(w).Encode([]harborArtifact{{Tags: []harborTag{{Name: "v1"}}}})
+	}))
+	defer server.Close()
+
+	auth := Auth{Kind: "harbor"}
+	auth.Harbor.Anonymous = true
+	client, err := NewClientWithLogger(server.URL, auth, nil)
+	if err != nil {
+		t.Fatalf("NewClientWithLogger: %v", err)
+	}
+
+	tags, err := client.ListTags(context.Background(), "library/nginx")
+	if err != nil {
+		t.Fatalf("expected a forbidden audit log not to fail the listing: %v", err)
+	}
+	if len(tags) != 1 || tags[0].PushedBy != "" {
+		t.Fatalf("expected the tag without a pusher, got %+v", tags)
+	}
+}
+
+func TestHarborRobotAccountAuth(t *testing.T) {
+	tests := []struct {
+		name     string
+		username string
+		wantErr  bool
+	}{
+		{name: "project robot", username: "robot$team+ci"},
+		{name: "system robot", username: "robot$ci"},
+		{name: "missing prefix", username: "team+ci", wantErr: true},
+		{name: "bare prefix", username: "robot$", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotUser, gotPass string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotUser, gotPass, _ = r.BasicAuth()
+				_, _ = w.Write([]byte(`[]`))
+			}))
+			defer server.Close()
+
+			auth := Auth{Kind: "harbor"}
+			auth.Harbor.Robot = true
+			auth.Harbor.Username = tt.username
+			auth.Harbor.Password = "s3cr3t+/="
+			client, err := NewClientWithLogger(server.URL, auth, nil)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "robot$") {
+					t.Fatalf("expected a robot name error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewClientWithLogger: %v", err)
+			}
+			if _, err := client.ListTags(context.Background(), "team/app"); err != nil {
+				t.Fatalf("ListTags: %v", err)
+			}
+			if gotUser != tt.username || gotPass != "s3cr3t+/=" {
+				t.Fatalf("expected basic auth %q/%q, got %q/%q", tt.username, "s3cr3t+/=", gotUser, gotPass)
+			}
+		})
+	}
+}
+
+func TestHarborPagingStopsAtTotalCount(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2.0/projects" {
+			_ = json.NewEncoder(w).Encode([]harborProject{{Name: "team", RepoCount: harborPageSize}})
+			return
+		}
+		if page := r.URL.Query().Get("page"); page != "1" {
+			t.Errorf("unexpected request for page %s after X-Total-Count was reached", page)
+		}
+		repos := make([]harborRepository, harborPageSize)
+		for i := range repos {
+			repos[i].Name = fmt.Sprintf("team/app-%03d", i)
+		}
+		w.Header().Set("X-Total-Count", fmt.Sprintf("%d", harborPageSize))
+		_ = json.NewEncoder(w).Encode(repos)
+	}))
+	defer server.Close()
+
+	auth := Auth{Kind: "harbor"}
+	auth.Harbor.Anonymous = true
+	client, err := NewClientWithLogger(server.URL, auth, nil)
+	if err != nil {
+		t.Fatalf("NewClientWithLogger: %v", err)
+	}
+
+	var loaded, total int
+	err = client.(ImageTotalStreamer).StreamImagesWithTotal(context.Background(), func(batch []Image, batchTotal int) {
+		loaded += len(batch)
+		total = batchTotal
+	})
+	if err != nil {
+		t.Fatalf("StreamImagesWithTotal: %v", err)
+	}
+	if loaded != harborPageSize || total != harborPageSize {
+		t.Fatalf("expected %d of %d repositories, got %d of %d", harborPageSize, harborPageSize, loaded, total)
+	}
+}
+
+func TestHarborListProjectsPageSendsOneRequest(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RequestURI())
+		w.Header().Set("X-Total-Count", "3")
+		_ = json.NewEncoder(w).Encode([]harborProject{{Name: "team", RepoCount: 2}})
+	}))
+	defer server.Close()
+
+	auth := Auth{Kind: "harbor"}
+	auth.Harbor.Anonymous = true
+	client, err := NewClientWithLogger(server.URL, auth, nil)
+	if err != nil {
+		t.Fatalf("NewClientWithLogger: %v", err)
+	}
+
+	projects, err := client.(ProjectPager).ListProjectsPage(context.Background(), 1, 1)
+	if err != nil {
+		t.Fatalf("ListProjectsPage: %v", err)
+	}
+	if len(projects) != 1 || projects[0].Name != "team" || projects[0].ImageCount != 2 || projects[0].ArtifactCount != -1 {
+		t.Fatalf("unexpected projects %+v", projects)
+	}
+	if len(requests) != 1 || requests[0] != "/api/v2.0/projects?page=1&page_size=1" {
+		t.Fatalf("expected a single one-project request, got %v", requests)
+	}
+}
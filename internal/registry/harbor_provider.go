@@ -11,6 +11,9 @@ func (HarborProvider) Kind() string {
 func (HarborProvider) TableSpec() TableSpec {
 	return TableSpec{
 		SupportsProjects: true,
+		Project: ProjectTableSpec{
+			ShowArtifactCount: true,
+		},
 		Image: ImageTableSpec{
 			ShowTagCount: true,
 			ShowPulls:    true,
@@ -19,7 +22,10 @@ func (HarborProvider) TableSpec() TableSpec {
 		Tag: TagTableSpec{
 			ShowSize:       true,
 			ShowPushed:     true,
+			ShowPushedBy:   true,
 			ShowLastPulled: true,
+			ShowPlatforms:  true,
+			ShowLabels:     true,
 		},
 		History: HistoryTableSpec{
 			ShowSize:    true,
@@ -42,11 +48,16 @@ func (HarborProvider) AuthUI(auth Auth) AuthUI {
 	if auth.Kind == "none" || auth.Harbor.Anonymous {
 		return AuthUI{}
 	}
-	return AuthUI{
+	ui := AuthUI{
 		ShowUsername: true,
 		ShowPassword: true,
 		ShowRemember: false,
 	}
+	if auth.Harbor.Robot {
+		ui.UsernamePrefix = HarborRobotPrefix
+		ui.Hint = "Robot account: enter the full name (robot$project+name) and paste its secret as the password"
+	}
+	return ui
 }
 
 func (HarborProvider) PrepareAuth(_ *url.URL, auth *Auth) error {
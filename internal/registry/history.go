@@ -1,19 +1,65 @@
 package registry
 
 import (
+	"encoding/json"
 	"strings"
 	"time"
 )
 
+// ManifestAccept lists the manifest media types beacon can read, most
+// specific first. It is sent as the Accept header of manifest requests unless
+// a context overrides it.
+var ManifestAccept = []string{
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.oci.artifact.manifest.v1+json",
+}
+
+// manifestAcceptHeader joins override, or ManifestAccept when it is empty,
+// followed by extra.
+func manifestAcceptHeader(override []string, extra ...string) string {
+	types := override
+	if len(types) == 0 {
+		types = ManifestAccept
+	}
+	return strings.Join(append(append([]string(nil), types...), extra...), ", ")
+}
+
 type ManifestV2 struct {
-	MediaType string               `json:"mediaType"`
-	Config    ManifestConfig       `json:"config"`
-	Layers    []ManifestLayer      `json:"layers"`
-	Manifests []ManifestDescriptor `json:"manifests"`
+	SchemaVersion int                  `json:"schemaVersion"`
+	MediaType     string               `json:"mediaType"`
+	ArtifactType  string               `json:"artifactType"`
+	Config        ManifestConfig       `json:"config"`
+	Layers        []ManifestLayer      `json:"layers"`
+	Manifests     []ManifestDescriptor `json:"manifests"`
+	Annotations   map[string]string    `json:"annotations"`
+	// History is only set on legacy schema 1 manifests.
+	History []ManifestV1History `json:"history"`
+	// Digest is the manifest's own digest, taken from Docker-Content-Digest
+	// or computed from the response body.
+	Digest string `json:"-"`
+}
+
+// ManifestV1History holds one layer's v1Compatibility JSON document.
+type ManifestV1History struct {
+	V1Compatibility string `json:"v1Compatibility"`
+}
+
+type manifestV1Compatibility struct {
+	Created         string `json:"created"`
+	Comment         string `json:"comment"`
+	Throwaway       bool   `json:"throwaway"`
+	Size            *int64 `json:"Size"`
+	ContainerConfig struct {
+		Cmd []string `json:"Cmd"`
+	} `json:"container_config"`
 }
 
 type ManifestConfig struct {
-	Digest string `json:"digest"`
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
 }
 
 type ManifestLayer struct {
@@ -34,6 +80,9 @@ type ManifestPlatform struct {
 
 type ConfigV2 struct {
 	History []ConfigHistory `json:"history"`
+	Config  struct {
+		Labels map[string]string `json:"Labels"`
+	} `json:"config"`
 }
 
 type ConfigHistory struct {
@@ -49,6 +98,8 @@ type Entry struct {
 	Comment    string
 	SizeBytes  int64
 	EmptyLayer bool
+	// Legacy marks entries read from a schema 1 manifest.
+	Legacy bool
 }
 
 func Build(manifest ManifestV2, cfg ConfigV2) []Entry {
@@ -87,6 +138,40 @@ func Build(manifest ManifestV2, cfg ConfigV2) []Entry {
 	return entries
 }
 
+// IsSchemaV1 reports whether manifest is a legacy schema 1 manifest, which
+// carries its history inline instead of referencing a config blob.
+func (m ManifestV2) IsSchemaV1() bool {
+	if m.SchemaVersion == 1 || strings.HasPrefix(m.MediaType, "application/vnd.docker.distribution.manifest.v1") {
+		return true
+	}
+	return m.Config.Digest == "" && len(m.History) > 0
+}
+
+// buildHistoryFromV1 reads the v1Compatibility blobs of a schema 1 manifest.
+// They are already ordered newest first, like Build's output.
+func buildHistoryFromV1(manifest ManifestV2) []Entry {
+	entries := make([]Entry, 0, len(manifest.History))
+	for _, item := range manifest.History {
+		var compat manifestV1Compatibility
+		if err := json.Unmarshal([]byte(item.V1Compatibility), &compat); err != nil {
+			continue
+		}
+		h := Entry{
+			CreatedAt:  parseDockerTime(compat.Created),
+			CreatedBy:  strings.TrimSpace(strings.Join(compat.ContainerConfig.Cmd, " ")),
+			Comment:    strings.TrimSpace(compat.Comment),
+			SizeBytes:  -1,
+			EmptyLayer: compat.Throwaway,
+			Legacy:     true,
+		}
+		if compat.Size != nil {
+			h.SizeBytes = *compat.Size
+		}
+		entries = append(entries, h)
+	}
+	return entries
+}
+
 func PreferredManifestDigest(manifest ManifestV2) string {
 	if len(manifest.Manifests) == 0 {
 		return ""
@@ -6,35 +6,64 @@ import (
 	"strings"
 )
 
-func listTagHistoryFromManifest(
+// inspectTag builds a tag's history along with the digest the tag pointed to
+// (the index digest for multi-platform tags), the config labels and the
+// manifest annotations. Schema 1 manifests carry no config blob, so they
+// never have labels.
+func inspectTag(
 	ctx context.Context,
 	provider string,
 	image string,
 	tag string,
 	getManifest func(context.Context, string, string) (ManifestV2, error),
 	getConfig func(context.Context, string, string) (ConfigV2, error),
-) ([]HistoryEntry, error) {
+) (TagDetails, error) {
 	manifest, err := getManifest(ctx, image, tag)
 	if err != nil {
-		return nil, err
+		return TagDetails{}, err
 	}
+	digest := manifest.Digest
+	annotations := manifest.Annotations
 	if manifest.Config.Digest == "" {
 		resolvedDigest := PreferredManifestDigest(manifest)
 		if resolvedDigest != "" {
 			manifest, err = getManifest(ctx, image, resolvedDigest)
 			if err != nil {
-				return nil, err
+				return TagDetails{}, err
 			}
+			annotations = mergeAnnotations(annotations, manifest.Annotations)
 		}
 	}
+	if manifest.IsSchemaV1() {
+		return TagDetails{History: toHistoryEntries(buildHistoryFromV1(manifest)), Digest: digest}, nil
+	}
 	if manifest.Config.Digest == "" {
-		return nil, fmt.Errorf("%s config digest missing for %s:%s", strings.TrimSpace(provider), image, tag)
+		return TagDetails{}, fmt.Errorf("%s config digest missing for %s:%s", strings.TrimSpace(provider), image, tag)
 	}
 	cfg, err := getConfig(ctx, image, manifest.Config.Digest)
 	if err != nil {
-		return nil, err
+		return TagDetails{}, err
+	}
+	return TagDetails{
+		History:     toHistoryEntries(Build(manifest, cfg)),
+		Digest:      digest,
+		Labels:      cfg.Config.Labels,
+		Annotations: annotations,
+	}, nil
+}
+
+func mergeAnnotations(base, override map[string]string) map[string]string {
+	if len(base) == 0 {
+		return override
+	}
+	merged := make(map[string]string, len(base)+len(override))
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range override {
+		merged[key] = value
 	}
-	return toHistoryEntries(Build(manifest, cfg)), nil
+	return merged
 }
 
 func toHistoryEntries(entries []Entry) []HistoryEntry {
@@ -49,6 +78,7 @@ func toHistoryEntries(entries []Entry) []HistoryEntry {
 			Comment:    entry.Comment,
 			SizeBytes:  entry.SizeBytes,
 			EmptyLayer: entry.EmptyLayer,
+			Legacy:     entry.Legacy,
 		})
 	}
 	return out
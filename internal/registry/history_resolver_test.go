@@ -2,6 +2,7 @@ package registry
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 )
 
@@ -35,12 +36,12 @@ func TestListTagHistoryFromManifest_ResolvesManifestList(t *testing.T) {
 		}, nil
 	}
 
-	history, err := listTagHistoryFromManifest(context.Background(), "docker hub", "library/nginx", "latest", getManifest, getConfig)
+	details, err := inspectTag(context.Background(), "docker hub", "library/nginx", "latest", getManifest, getConfig)
 	if err != nil {
-		t.Fatalf("listTagHistoryFromManifest returned error: %v", err)
+		t.Fatalf("inspectTag returned error: %v", err)
 	}
-	if len(history) != 1 {
-		t.Fatalf("expected 1 history entry, got %d", len(history))
+	if len(details.History) != 1 {
+		t.Fatalf("expected 1 history entry, got %d", len(details.History))
 	}
 	if len(calls) != 2 || calls[0] != "latest" || calls[1] != "sha256:child" {
 		t.Fatalf("unexpected manifest resolution calls: %v", calls)
@@ -55,8 +56,111 @@ func TestListTagHistoryFromManifest_MissingConfigDigest(t *testing.T) {
 		return ConfigV2{}, nil
 	}
 
-	_, err := listTagHistoryFromManifest(context.Background(), "github", "owner/image", "latest", getManifest, getConfig)
+	_, err := inspectTag(context.Background(), "github", "owner/image", "latest", getManifest, getConfig)
 	if err == nil {
 		t.Fatalf("expected missing config digest error")
 	}
 }
+
+func TestListTagHistoryFromManifest_SchemaV1(t *testing.T) {
+	raw := `{
+		"schemaVersion": 1,
+		"name": "legacy/app",
+		"tag": "old",
+		"fsLayers": [{"blobSum": "sha256:b"}, {"blobSum": "sha256:a"}],
+		"history": [
+			{"v1Compatibility": "{\"created\":\"2016-01-02T03:04:05Z\",\"container_config\":{\"Cmd\":[\"/bin/sh\",\"-c\",\"#(nop) CMD [\\\"app\\\"]\"]},\"throwaway\":true}"},
+			{"v1Compatibility": "{\"created\":\"2016-01-01T03:04:05Z\",\"container_config\":{\"Cmd\":[\"/bin/sh\",\"-c\",\"apk add curl\"]},\"Size\":1024}"}
+		]
+	}`
+	var manifest ManifestV2
+	if err := json.Unmarshal([]byte(raw), &manifest); err != nil {
+		t.Fatalf("decode manifest: %v", err)
+	}
+	getManifest := func(context.Context, string, string) (ManifestV2, error) {
+		return manifest, nil
+	}
+	getConfig := func(context.Context, string, string) (ConfigV2, error) {
+		t.Fatalf("schema 1 manifests have no config blob to fetch")
+		return ConfigV2{}, nil
+	}
+
+	details, err := inspectTag(context.Background(), "registry", "legacy/app", "old", getManifest, getConfig)
+	if err != nil {
+		t.Fatalf("inspectTag returned error: %v", err)
+	}
+	if len(details.History) != 2 {
+		t.Fatalf("expected 2 entries, got %+v", details.History)
+	}
+	if details.History[0].CreatedBy != `/bin/sh -c #(nop) CMD ["app"]` || !details.History[0].EmptyLayer || details.History[0].SizeBytes != -1 {
+		t.Fatalf("unexpected newest entry: %+v", details.History[0])
+	}
+	if details.History[1].CreatedBy != "/bin/sh -c apk add curl" || details.History[1].SizeBytes != 1024 || !details.History[1].Legacy {
+		t.Fatalf("unexpected oldest entry: %+v", details.History[1])
+	}
+}
+
+func TestInspectTagReturnsConfigLabels(t *testing.T) {
+	getManifest := func(_ context.Context, _ string, _ string) (ManifestV2, error) {
+		manifest := ManifestV2{Digest: "sha256:top"}
+		manifest.Config.Digest = "sha256:cfg"
+		return manifest, nil
+	}
+	getConfig := func(_ context.Context, _ string, _ string) (ConfigV2, error) {
+		var cfg ConfigV2
+		err := json.Unmarshal([]byte(`{"config":{"Labels":{"org.opencontainers.image.source":"https://github.com/acme/app"}},"history":[{"created_by":"RUN true"}]}`), &cfg)
+		return cfg, err
+	}
+
+	details, err := inspectTag(context.Background(), "registry", "app", "v1", getManifest, getConfig)
+	if err != nil {
+		t.Fatalf("inspectTag: %v", err)
+	}
+	if details.Digest != "sha256:top" || len(details.History) != 1 {
+		t.Fatalf("unexpected details %+v", details)
+	}
+	if got := details.Labels["org.opencontainers.image.source"]; got != "https://github.com/acme/app" {
+		t.Fatalf("expected the source label, got %q", got)
+	}
+}
+
+func TestInspectTagMergesManifestAnnotations(t *testing.T) {
+	getManifest := func(_ context.Context, _ string, reference string) (ManifestV2, error) {
+		if reference == "v1" {
+			return ManifestV2{
+				Digest: "sha256:index",
+				Manifests: []ManifestDescriptor{{
+					Digest:   "sha256:amd64",
+					Platform: ManifestPlatform{OS: "linux", Architecture: "amd64"},
+				}},
+				Annotations: map[string]string{
+					"org.opencontainers.image.title":   "app",
+					"org.opencontainers.image.version": "index",
+				},
+			}, nil
+		}
+		manifest := ManifestV2{Annotations: map[string]string{"org.opencontainers.image.version": "1.2.3"}}
+		manifest.Config.Digest = "sha256:cfg"
+		return manifest, nil
+	}
+	getConfig := func(_ context.Context, _ string, _ string) (ConfigV2, error) {
+		return ConfigV2{}, nil
+	}
+
+	details, err := inspectTag(context.Background(), "harbor", "app", "v1", getManifest, getConfig)
+	if err != nil {
+		t.Fatalf("inspectTag: %v", err)
+	}
+	want := map[string]string{
+		"org.opencontainers.image.title":   "app",
+		"org.opencontainers.image.version": "1.2.3",
+	}
+	if len(details.Annotations) != len(want) {
+		t.Fatalf("unexpected annotations %v", details.Annotations)
+	}
+	for key, value := range want {
+		if details.Annotations[key] != value {
+			t.Fatalf("expected %s=%s, got %v", key, value, details.Annotations)
+		}
+	}
+}
@@ -1,11 +1,71 @@
 package registry
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 )
 
+func newHTTPClient(proxy string) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = proxyFunc(proxy)
+	return &http.Client{
+		Timeout:       15 * time.Second,
+		Transport:     transport,
+		CheckRedirect: stripAuthOnRedirect,
+	}
+}
+
+// maxRedirects matches the net/http default.
+const maxRedirects = 10
+
+// stripAuthOnRedirect drops the Authorization header once a redirect leaves
+// the registry host. Blob stores behind signed URLs (S3, GCS) reject requests
+// that carry registry credentials, and net/http keeps them for subdomains.
+func stripAuthOnRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+	if !strings.EqualFold(req.URL.Host, via[0].URL.Host) {
+		req.Header.Del("Authorization")
+	}
+	return nil
+}
+
+func proxyFunc(proxy string) func(*http.Request) (*url.URL, error) {
+	trimmed := strings.TrimSpace(proxy)
+	if trimmed == "" {
+		return http.ProxyFromEnvironment
+	}
+	parsed, err := url.Parse(trimmed)
+	if err != nil || parsed.Host == "" {
+		return http.ProxyFromEnvironment
+	}
+	return http.ProxyURL(parsed)
+}
+
+// ValidateProxy reports whether proxy is empty or an absolute proxy URL.
+func ValidateProxy(proxy string) error {
+	trimmed := strings.TrimSpace(proxy)
+	if trimmed == "" {
+		return nil
+	}
+	parsed, err := url.Parse(trimmed)
+	if err != nil {
+		return fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	if parsed.Scheme == "" || parsed.Host == "" {
+		return fmt.Errorf("invalid proxy URL: %s", trimmed)
+	}
+	return nil
+}
+
 func cloneHeader(header http.Header) map[string][]string {
 	if len(header) == 0 {
 		return nil
@@ -56,3 +116,21 @@ func resolveNextURL(base *url.URL, next string) string {
 	}
 	return base.ResolveReference(parsed).String()
 }
+
+// decodeManifest reads a manifest response and records its digest.
+func decodeManifest(resp *http.Response) (ManifestV2, error) {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return ManifestV2{}, err
+	}
+	var manifest ManifestV2
+	if err := json.Unmarshal(body, &manifest); err != nil {
+		return ManifestV2{}, err
+	}
+	manifest.Digest = strings.TrimSpace(resp.Header.Get("Docker-Content-Digest"))
+	if manifest.Digest == "" {
+		sum := sha256.Sum256(body)
+		manifest.Digest = "sha256:" + hex.EncodeToString(sum[:])
+	}
+	return manifest, nil
+}
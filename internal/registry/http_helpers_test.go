@@ -0,0 +1,77 @@
+package registry
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestNewHTTPClientProxy(t *testing.T) {
+	client := newHTTPClient("http://proxy.example.com:8080")
+	transport, ok := client.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("expected *http.Transport, got %T", client.Transport)
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://registry.example.com/v2/", nil)
+	got, err := transport.Proxy(req)
+	if err != nil {
+		t.Fatalf("proxy func returned error: %v", err)
+	}
+	if got == nil || got.String() != "http://proxy.example.com:8080" {
+		t.Fatalf("expected explicit proxy, got %v", got)
+	}
+
+	// net/http caches the environment on first use, so compare against
+	// ProxyFromEnvironment itself rather than setting HTTPS_PROXY here.
+	fallback := newHTTPClient("").Transport.(*http.Transport)
+	if reflect.ValueOf(fallback.Proxy).Pointer() != reflect.ValueOf(http.ProxyFromEnvironment).Pointer() {
+		t.Fatalf("expected empty proxy to fall back to http.ProxyFromEnvironment")
+	}
+}
+
+func TestValidateProxy(t *testing.T) {
+	if err := ValidateProxy(""); err != nil {
+		t.Fatalf("expected empty proxy to be valid, got %v", err)
+	}
+	if err := ValidateProxy("http://proxy.example.com:8080"); err != nil {
+		t.Fatalf("expected proxy URL to be valid, got %v", err)
+	}
+	if err := ValidateProxy("proxy.example.com"); err == nil {
+		t.Fatalf("expected proxy without scheme to be rejected")
+	}
+}
+
+func TestStripAuthOnRedirect(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		keepAuth bool
+	}{
+		{name: "same host", from: "https://registry.example.com/v2/app/blobs/sha256:a", to: "https://registry.example.com/v2/app/blobs/sha256:b", keepAuth: true},
+		{name: "subdomain", from: "https://registry.example.com/v2/app/blobs/sha256:a", to: "https://blobs.registry.example.com/sha256:a"},
+		{name: "object store", from: "https://registry.example.com/v2/app/blobs/sha256:a", to: "https://bucket.s3.amazonaws.com/sha256:a?X-Amz-Signature=x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first := httptest.NewRequest(http.MethodGet, tt.from, nil)
+			next := httptest.NewRequest(http.MethodGet, tt.to, nil)
+			next.Header.Set("Authorization", "Bearer token")
+			if err := stripAuthOnRedirect(next, []*http.Request{first}); err != nil {
+				t.Fatalf("stripAuthOnRedirect: %v", err)
+			}
+			if got := next.Header.Get("Authorization") != ""; got != tt.keepAuth {
+				t.Fatalf("expected Authorization kept=%v, got %v", tt.keepAuth, got)
+			}
+		})
+	}
+
+	via := make([]*http.Request, maxRedirects)
+	for i := range via {
+		via[i] = httptest.NewRequest(http.MethodGet, "https://registry.example.com/", nil)
+	}
+	if err := stripAuthOnRedirect(httptest.NewRequest(http.MethodGet, "https://registry.example.com/", nil), via); err == nil {
+		t.Fatalf("expected an error after %d redirects", maxRedirects)
+	}
+}
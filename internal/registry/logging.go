@@ -1,10 +1,16 @@
 package registry
 
+import "time"
+
 type RequestLog struct {
 	Method  string
 	URL     string
 	Headers map[string][]string
 	Status  int
+	// StartedAt is when the request was sent; Duration runs until the
+	// response headers (or a transport error) came back.
+	StartedAt time.Time
+	Duration  time.Duration
 }
 
 type RequestLogger func(RequestLog)
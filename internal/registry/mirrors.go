@@ -0,0 +1,116 @@
+package registry
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// mirrorDialTimeout keeps an unreachable host from using up the whole
+// request timeout before the next mirror is tried.
+const mirrorDialTimeout = 5 * time.Second
+
+// mirrorTransport sends a request to the next mirror when the host it
+// targets can't be reached. Only dial failures fail over, so a request is
+// never sent twice; any HTTP response, 5xx included, is returned as is.
+// Mirrors get the same headers, credentials included, and the host that last
+// answered is tried first on later requests.
+type mirrorTransport struct {
+	next  http.RoundTripper
+	hosts []*url.URL
+
+	mu     sync.Mutex
+	active int
+}
+
+// newMirrorTransport returns next unchanged when no mirror parses.
+func newMirrorTransport(next http.RoundTripper, primary *url.URL, mirrors []string) http.RoundTripper {
+	hosts := []*url.URL{primary}
+	for _, mirror := range mirrors {
+		parsed, err := ParseRegistryURL(mirror)
+		if err != nil || strings.EqualFold(parsed.Host, primary.Host) {
+			continue
+		}
+		hosts = append(hosts, parsed)
+	}
+	if len(hosts) < 2 {
+		return next
+	}
+	if transport, ok := next.(*http.Transport); ok {
+		dialer := &net.Dialer{Timeout: mirrorDialTimeout, KeepAlive: 30 * time.Second}
+		transport.DialContext = dialer.DialContext
+	}
+	return &mirrorTransport{next: next, hosts: hosts}
+}
+
+func (t *mirrorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.hostIndex(req.URL) < 0 {
+		return t.next.RoundTrip(req)
+	}
+	t.mu.Lock()
+	start := t.active
+	t.mu.Unlock()
+
+	var lastErr error
+	for i := range t.hosts {
+		index := (start + i) % len(t.hosts)
+		attempt, err := retargetRequest(req, t.hosts[index], i > 0)
+		if err != nil {
+			return nil, lastErr
+		}
+		resp, err := t.next.RoundTrip(attempt)
+		if err == nil {
+			t.mu.Lock()
+			t.active = index
+			t.mu.Unlock()
+			return resp, nil
+		}
+		if req.Context().Err() != nil || !isDialError(err) {
+			return nil, err
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+func (t *mirrorTransport) hostIndex(target *url.URL) int {
+	for i, host := range t.hosts {
+		if strings.EqualFold(host.Host, target.Host) {
+			return i
+		}
+	}
+	return -1
+}
+
+// retargetRequest points a copy of req at host. A retry needs a fresh body,
+// so requests whose body can't be replayed are not retried.
+func retargetRequest(req *http.Request, host *url.URL, retry bool) (*http.Request, error) {
+	out := req.Clone(req.Context())
+	out.URL.Scheme = host.Scheme
+	out.URL.Host = host.Host
+	out.Host = ""
+	if retry && req.Body != nil && req.Body != http.NoBody {
+		if req.GetBody == nil {
+			return nil, errors.New("request body can't be replayed")
+		}
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		out.Body = body
+	}
+	return out, nil
+}
+
+func isDialError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
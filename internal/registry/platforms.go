@@ -0,0 +1,67 @@
+package registry
+
+import (
+	"context"
+	"strings"
+)
+
+// PlatformCounter is implemented by clients that can count the platforms a
+// tag's manifest covers without loading its full history.
+type PlatformCounter interface {
+	CountPlatforms(ctx context.Context, image, tag string) (ManifestSummary, error)
+}
+
+// ManifestSummary is what one manifest fetch tells about a tag: the platforms
+// it covers and, for single manifests, the artifact type of its config.
+type ManifestSummary struct {
+	Platforms    int
+	ArtifactType string
+}
+
+func countPlatformsFromManifest(
+	ctx context.Context,
+	image string,
+	tag string,
+	getManifest func(context.Context, string, string) (ManifestV2, error),
+) (ManifestSummary, error) {
+	manifest, err := getManifest(ctx, image, tag)
+	if err != nil {
+		return ManifestSummary{}, err
+	}
+	return ManifestSummary{
+		Platforms:    platformCount(manifest.Manifests),
+		ArtifactType: ArtifactTypeFromMediaType(firstNonEmptyString(manifest.ArtifactType, manifest.Config.MediaType)),
+	}, nil
+}
+
+// platformCount returns 1 for single-platform manifests. Attestation entries
+// in an index are reported as unknown/unknown and are not counted.
+func platformCount(descriptors []ManifestDescriptor) int {
+	count := 0
+	for _, descriptor := range descriptors {
+		if strings.EqualFold(descriptor.Platform.OS, "unknown") {
+			continue
+		}
+		count++
+	}
+	if count == 0 {
+		return 1
+	}
+	return count
+}
+
+func (c *HTTPClient) CountPlatforms(ctx context.Context, image, tag string) (ManifestSummary, error) {
+	return countPlatformsFromManifest(ctx, image, tag, c.getManifest)
+}
+
+func (c *HarborClient) CountPlatforms(ctx context.Context, image, tag string) (ManifestSummary, error) {
+	return countPlatformsFromManifest(ctx, image, tag, c.getManifest)
+}
+
+func (c *DockerHubClient) CountPlatforms(ctx context.Context, image, tag string) (ManifestSummary, error) {
+	return countPlatformsFromManifest(ctx, image, tag, c.getRegistryManifest)
+}
+
+func (c *GitHubContainerClient) CountPlatforms(ctx context.Context, image, tag string) (ManifestSummary, error) {
+	return countPlatformsFromManifest(ctx, image, tag, c.getManifest)
+}
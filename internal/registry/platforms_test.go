@@ -0,0 +1,66 @@
+package registry
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCountPlatformsFromManifest(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest ManifestV2
+		want     int
+		wantType string
+	}{
+		{
+			name:     "single platform manifest",
+			manifest: ManifestV2{Config: ManifestConfig{Digest: "sha256:cfg"}},
+			want:     1,
+		},
+		{
+			name:     "helm chart config",
+			manifest: ManifestV2{Config: ManifestConfig{MediaType: "application/vnd.cncf.helm.config.v1+json", Digest: "sha256:cfg"}},
+			want:     1,
+			wantType: ArtifactTypeHelmChart,
+		},
+		{
+			name:     "oci artifact type wins over an empty config",
+			manifest: ManifestV2{ArtifactType: "application/spdx+json", Config: ManifestConfig{MediaType: "application/vnd.oci.empty.v1+json"}},
+			want:     1,
+			wantType: ArtifactTypeSBOM,
+		},
+		{
+			name:     "image config",
+			manifest: ManifestV2{Config: ManifestConfig{MediaType: "application/vnd.oci.image.config.v1+json"}},
+			want:     1,
+			wantType: ArtifactTypeImage,
+		},
+		{
+			name: "index skips attestation entries",
+			manifest: ManifestV2{Manifests: []ManifestDescriptor{
+				{Digest: "sha256:a", Platform: ManifestPlatform{OS: "linux", Architecture: "amd64"}},
+				{Digest: "sha256:b", Platform: ManifestPlatform{OS: "linux", Architecture: "arm64"}},
+				{Digest: "sha256:c", Platform: ManifestPlatform{OS: "unknown", Architecture: "unknown"}},
+			}},
+			want: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getManifest := func(context.Context, string, string) (ManifestV2, error) {
+				return tt.manifest, nil
+			}
+			got, err := countPlatformsFromManifest(context.Background(), "team/app", "latest", getManifest)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Platforms != tt.want {
+				t.Fatalf("expected %d platforms, got %d", tt.want, got.Platforms)
+			}
+			if got.ArtifactType != tt.wantType {
+				t.Fatalf("expected artifact type %q, got %q", tt.wantType, got.ArtifactType)
+			}
+		})
+	}
+}
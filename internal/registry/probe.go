@@ -0,0 +1,29 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Probe sends an unauthenticated HEAD /v2/ to registryHost. Any HTTP
+// response below 500, including 401, means the registry is reachable.
+func Probe(ctx context.Context, registryHost, proxy string) error {
+	baseURL, err := ParseRegistryURL(registryHost)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, baseURL.String()+"/v2/", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := newHTTPClient(proxy).Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 500 {
+		return fmt.Errorf("registry returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package registry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProbe(t *testing.T) {
+	status := http.StatusUnauthorized
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead || r.URL.Path != "/v2/" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	if err := Probe(context.Background(), server.URL, ""); err != nil {
+		t.Fatalf("expected 401 to count as reachable, got %v", err)
+	}
+
+	status = http.StatusBadGateway
+	if err := Probe(context.Background(), server.URL, ""); err == nil {
+		t.Fatalf("expected 502 to be reported")
+	}
+
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closedURL := closed.URL
+	closed.Close()
+	if err := Probe(context.Background(), closedURL, ""); err == nil {
+		t.Fatalf("expected closed server to be unreachable")
+	}
+}
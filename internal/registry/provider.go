@@ -4,11 +4,16 @@ import "net/url"
 
 type TableSpec struct {
 	SupportsProjects bool
+	Project          ProjectTableSpec
 	Image            ImageTableSpec
 	Tag              TagTableSpec
 	History          HistoryTableSpec
 }
 
+type ProjectTableSpec struct {
+	ShowArtifactCount bool
+}
+
 type ImageTableSpec struct {
 	ShowTagCount bool
 	ShowPulls    bool
@@ -18,7 +23,12 @@ type ImageTableSpec struct {
 type TagTableSpec struct {
 	ShowSize       bool
 	ShowPushed     bool
+	ShowPushedBy   bool
 	ShowLastPulled bool
+	ShowPlatforms  bool
+	ShowLabels     bool
+	// ShowDigest adds a short digest column; tags without one show "-".
+	ShowDigest bool
 }
 
 type HistoryTableSpec struct {
@@ -30,6 +40,10 @@ type AuthUI struct {
 	ShowUsername bool
 	ShowPassword bool
 	ShowRemember bool
+	// UsernamePrefix prefills an empty username, and Hint is shown under the
+	// fields.
+	UsernamePrefix string
+	Hint           string
 }
 
 type Provider interface {
@@ -6,8 +6,40 @@ import (
 	"strings"
 )
 
+const githubContainerHost = "ghcr.io"
+
 func PullCommand(registryHost, project, image, tag string) string {
-	return fmt.Sprintf("docker pull %s", PullReference(registryHost, project, image, tag))
+	return PullCommandWith("docker", PullReference(registryHost, project, image, tag))
+}
+
+// PullCommandWith formats a pull command for tool ("docker" or "podman").
+func PullCommandWith(tool, reference string) string {
+	tool = strings.TrimSpace(tool)
+	if tool == "" {
+		tool = "docker"
+	}
+	return fmt.Sprintf("%s pull %s", tool, reference)
+}
+
+// DockerHubPullReference drops the implicit library/ namespace so official
+// images read the way Docker Hub documents them (nginx:alpine).
+func DockerHubPullReference(image, tag string) string {
+	image = strings.TrimPrefix(strings.Trim(image, " /"), "library/")
+	return PullReference("", "", image, tag)
+}
+
+// DockerHubQualifiedReference spells out the docker.io host and the library/
+// namespace of official images, as Kubernetes manifests expect.
+func DockerHubQualifiedReference(image, tag string) string {
+	image = strings.Trim(image, " /")
+	if image != "" && !strings.Contains(image, "/") {
+		image = "library/" + image
+	}
+	return PullReference("docker.io", "", image, tag)
+}
+
+func GitHubPullReference(image, tag string) string {
+	return PullReference(githubContainerHost, "", image, tag)
 }
 
 func PullReference(registryHost, project, image, tag string) string {
@@ -22,6 +54,12 @@ func PullReference(registryHost, project, image, tag string) string {
 	return fmt.Sprintf("%s/%s:%s", registryHost, image, tag)
 }
 
+// RegistryHostName strips the scheme and any path from registryHost, leaving
+// the host as it appears in image references.
+func RegistryHostName(registryHost string) string {
+	return normalizeRegistryHost(registryHost)
+}
+
 func normalizeRegistryHost(registryHost string) string {
 	registryHost = strings.TrimSpace(registryHost)
 	if registryHost == "" {
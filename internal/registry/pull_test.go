@@ -58,3 +58,24 @@ func TestPullCommand(t *testing.T) {
 		t.Fatalf("expected %q, got %q", want, got)
 	}
 }
+
+func TestExternalPullReferences(t *testing.T) {
+	if got := DockerHubPullReference("library/nginx", "alpine"); got != "nginx:alpine" {
+		t.Fatalf("expected official image without library/, got %q", got)
+	}
+	if got := DockerHubPullReference("bitnami/redis", "7"); got != "bitnami/redis:7" {
+		t.Fatalf("expected namespaced image unchanged, got %q", got)
+	}
+	if got := GitHubPullReference("org/service", ""); got != "ghcr.io/org/service:latest" {
+		t.Fatalf("expected ghcr.io prefix, got %q", got)
+	}
+	if got := DockerHubQualifiedReference("nginx", "alpine"); got != "docker.io/library/nginx:alpine" {
+		t.Fatalf("expected docker.io and library/ for official images, got %q", got)
+	}
+	if got := DockerHubQualifiedReference("bitnami/redis", "7"); got != "docker.io/bitnami/redis:7" {
+		t.Fatalf("expected docker.io prefix, got %q", got)
+	}
+	if got := PullCommandWith("podman", "nginx:alpine"); got != "podman pull nginx:alpine" {
+		t.Fatalf("unexpected podman command %q", got)
+	}
+}
@@ -0,0 +1,89 @@
+package registry
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// dockerHubLimiter is shared by every DockerHubClient so search, paging and
+// history lookups draw from one budget even though each command builds its
+// own client.
+var dockerHubLimiter = newRequestLimiter(250*time.Millisecond, 5*time.Second)
+
+// requestLimiter spaces out outbound requests. It runs at minInterval until
+// the advertised remaining quota drops below half, then spreads what is left
+// over the time until the window resets.
+type requestLimiter struct {
+	mu          sync.Mutex
+	minInterval time.Duration
+	maxInterval time.Duration
+	interval    time.Duration
+	next        time.Time
+}
+
+func newRequestLimiter(minInterval, maxInterval time.Duration) *requestLimiter {
+	return &requestLimiter{
+		minInterval: minInterval,
+		maxInterval: maxInterval,
+		interval:    minInterval,
+	}
+}
+
+// Wait blocks until the caller's slot comes up or ctx is done. A caller that
+// gives up hands its slot back when nobody reserved one after it.
+func (l *requestLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	now := time.Now()
+	slot := l.next
+	if slot.Before(now) {
+		slot = now
+	}
+	reserved := slot.Add(l.interval)
+	l.next = reserved
+	l.mu.Unlock()
+
+	wait := slot.Sub(now)
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		l.mu.Lock()
+		if l.next.Equal(reserved) {
+			l.next = slot
+		}
+		l.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Observe adjusts the spacing from the rate limit headers of a response.
+func (l *requestLimiter) Observe(limit DockerHubRateLimit) {
+	if l == nil || limit.Limit <= 0 || limit.Remaining < 0 {
+		return
+	}
+	interval := l.minInterval
+	if limit.Remaining*2 < limit.Limit && !limit.ResetAt.IsZero() {
+		if until := time.Until(limit.ResetAt); until > 0 {
+			interval = until / time.Duration(limit.Remaining+1)
+		}
+	}
+	if interval < l.minInterval {
+		interval = l.minInterval
+	}
+	if interval > l.maxInterval {
+		interval = l.maxInterval
+	}
+
+	l.mu.Lock()
+	l.interval = interval
+	l.mu.Unlock()
+}
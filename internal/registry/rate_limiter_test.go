@@ -0,0 +1,96 @@
+package registry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRequestLimiterSpacesRequests(t *testing.T) {
+	limiter := newRequestLimiter(20*time.Millisecond, time.Second)
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatalf("Wait returned error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Fatalf("expected three requests to take at least 40ms, took %s", elapsed)
+	}
+}
+
+func TestRequestLimiterObserve(t *testing.T) {
+	limiter := newRequestLimiter(10*time.Millisecond, 5*time.Second)
+
+	limiter.Observe(DockerHubRateLimit{Limit: 100, Remaining: 90, ResetAt: time.Now().Add(time.Minute)})
+	if limiter.interval != 10*time.Millisecond {
+		t.Fatalf("expected min interval with plenty of quota, got %s", limiter.interval)
+	}
+
+	limiter.Observe(DockerHubRateLimit{Limit: 100, Remaining: 9, ResetAt: time.Now().Add(10 * time.Second)})
+	if limiter.interval < 900*time.Millisecond || limiter.interval > time.Second {
+		t.Fatalf("expected remaining quota to be spread over the window, got %s", limiter.interval)
+	}
+
+	limiter.Observe(DockerHubRateLimit{Limit: 100, Remaining: 0, ResetAt: time.Now().Add(time.Hour)})
+	if limiter.interval != 5*time.Second {
+		t.Fatalf("expected interval capped at max, got %s", limiter.interval)
+	}
+}
+
+func TestRequestLimiterWaitHonorsContext(t *testing.T) {
+	limiter := newRequestLimiter(time.Hour, time.Hour)
+	_ = limiter.Wait(context.Background())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := limiter.Wait(ctx); err == nil {
+		t.Fatalf("expected cancelled context to abort the wait")
+	}
+}
+
+func TestRequestLimiterCancelReturnsSlot(t *testing.T) {
+	limiter := newRequestLimiter(50*time.Millisecond, time.Second)
+	_ = limiter.Wait(context.Background())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := limiter.Wait(ctx); err == nil {
+		t.Fatalf("expected cancelled context to abort the wait")
+	}
+
+	start := time.Now()
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait returned error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 75*time.Millisecond {
+		t.Fatalf("expected the cancelled slot to be reused, waited %s", elapsed)
+	}
+}
+
+func TestDockerHubClientRecordsRegistryRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("RateLimit-Limit", "100;w=21600")
+		w.Header().Set("RateLimit-Remaining", "76;w=21600")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &DockerHubClient{httpClient: server.Client(), limiter: newRequestLimiter(time.Millisecond, time.Millisecond)}
+	if limit := client.RateLimit(); limit.Limit != -1 {
+		t.Fatalf("expected no rate limit before any response, got %+v", limit)
+	}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+"/v2/library/nginx/manifests/latest", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+	resp, err := client.doRegistryRequest(context.Background(), req, "library/nginx")
+	if err != nil {
+		t.Fatalf("doRegistryRequest returned error: %v", err)
+	}
+	resp.Body.Close()
+
+	if limit := client.RateLimit(); limit.Limit != 100 || limit.Remaining != 76 {
+		t.Fatalf("expected the manifest response quota, got %+v", limit)
+	}
+}
@@ -1,10 +1,14 @@
 package registry
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"sort"
@@ -24,16 +28,25 @@ type HTTPClient struct {
 	tokenMu        sync.Mutex
 	registryToken  string
 	registryExpiry time.Time
+	// tokenPath and scope describe the token exchange; ACR overrides both.
+	tokenPath string
+	scope     string
+	// bearer supplies tokens for kinds that don't use the password flow (GCR).
+	bearer func(context.Context) (string, error)
 }
 
 func newRegistryV2Client(baseURL *url.URL, auth Auth, logger RequestLogger) *HTTPClient {
+	httpClient := newHTTPClient(auth.Proxy)
+	if len(auth.Mirrors) > 0 {
+		httpClient.Transport = newMirrorTransport(httpClient.Transport, baseURL, auth.Mirrors)
+	}
 	return &HTTPClient{
-		baseURL: baseURL,
-		httpClient: &http.Client{
-			Timeout: 15 * time.Second,
-		},
-		auth:   auth,
-		logger: logger,
+		baseURL:    baseURL,
+		httpClient: httpClient,
+		auth:       auth,
+		logger:     logger,
+		tokenPath:  "/token",
+		scope:      registryScope(),
 	}
 }
 
@@ -43,6 +56,33 @@ func (c *HTTPClient) ListImages(ctx context.Context) ([]Image, error) {
 		return nil, err
 	}
 
+	images := repositoryImages(repos)
+	sort.Slice(images, func(i, j int) bool {
+		return images[i].Name < images[j].Name
+	})
+
+	return images, nil
+}
+
+// StreamImages emits each catalog page as it arrives, so a full listing of a
+// large registry shows progress instead of waiting for the last page.
+func (c *HTTPClient) StreamImages(ctx context.Context, emit func([]Image)) error {
+	return c.walkCatalog(ctx, func(page []string) {
+		emit(repositoryImages(page))
+	})
+}
+
+// ListImagesPage fetches up to limit repositories after last using the
+// catalog's n/last pagination.
+func (c *HTTPClient) ListImagesPage(ctx context.Context, last string, limit int) ([]Image, string, error) {
+	repos, next, err := c.listRepositoriesPage(ctx, last, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	return repositoryImages(repos), next, nil
+}
+
+func repositoryImages(repos []string) []Image {
 	images := make([]Image, 0, len(repos))
 	for _, repo := range repos {
 		images = append(images, Image{
@@ -52,12 +92,7 @@ func (c *HTTPClient) ListImages(ctx context.Context) ([]Image, error) {
 			PullCount:  -1,
 		})
 	}
-
-	sort.Slice(images, func(i, j int) bool {
-		return images[i].Name < images[j].Name
-	})
-
-	return images, nil
+	return images
 }
 
 func (c *HTTPClient) ListTags(ctx context.Context, image string) ([]Tag, error) {
@@ -65,55 +100,272 @@ func (c *HTTPClient) ListTags(ctx context.Context, image string) ([]Tag, error)
 }
 
 func (c *HTTPClient) ListTagHistory(ctx context.Context, image, tag string) ([]HistoryEntry, error) {
+	history, _, err := c.ListTagHistoryWithDigest(ctx, image, tag)
+	return history, err
+}
+
+func (c *HTTPClient) ListTagHistoryWithDigest(ctx context.Context, image, tag string) ([]HistoryEntry, string, error) {
+	details, err := c.InspectTag(ctx, image, tag)
+	return details.History, details.Digest, err
+}
+
+func (c *HTTPClient) InspectTag(ctx context.Context, image, tag string) (TagDetails, error) {
 	image = strings.TrimSpace(image)
 	tag = strings.TrimSpace(tag)
 	if image == "" || tag == "" {
-		return nil, nil
+		return TagDetails{}, nil
 	}
-	return listTagHistoryFromManifest(ctx, "registry", image, tag, c.getManifest, c.getConfig)
+	return inspectTag(ctx, "registry", image, tag, c.getManifest, c.getConfig)
 }
 
+// DeleteTag deletes the tag by name, so other tags sharing its manifest are
+// kept. Registries that only delete by digest refuse the request.
 func (c *HTTPClient) DeleteTag(ctx context.Context, image, tag string) error {
-	return ErrNotSupported
+	image = strings.TrimSpace(image)
+	tag = strings.TrimSpace(tag)
+	if image == "" || tag == "" {
+		return errors.New("image and tag are required")
+	}
+	authorize, err := c.pushAuth(ctx, image)
+	if err != nil {
+		return err
+	}
+	return c.deleteManifest(ctx, image, tag, authorize)
 }
 
+// RenameTag re-tags from as to: the manifest is copied under the new tag, then
+// the old tag is deleted by name. Registries that refuse tag deletion keep
+// both tags and the error says so. Registries that resolve the tag to its
+// digest delete the manifest both tags share; the new tag is then uploaded
+// again and the error reports it.
 func (c *HTTPClient) RenameTag(ctx context.Context, image, from, to string) error {
-	return ErrNotSupported
+	image = strings.TrimSpace(image)
+	from = strings.TrimSpace(from)
+	to = strings.TrimSpace(to)
+	if image == "" || from == "" || to == "" {
+		return errors.New("image, source and target tag are required")
+	}
+	if from == to {
+		return fmt.Errorf("%s already has tag %s", image, to)
+	}
+
+	authorize, err := c.pushAuth(ctx, image)
+	if err != nil {
+		return err
+	}
+	body, mediaType, err := c.getRawManifest(ctx, image, from, authorize)
+	if err != nil {
+		return err
+	}
+	if err := c.putManifest(ctx, image, to, body, mediaType, authorize); err != nil {
+		return err
+	}
+	sum := sha256.Sum256(body)
+	digest := "sha256:" + hex.EncodeToString(sum[:])
+	if err := c.deleteManifest(ctx, image, from, authorize); err != nil {
+		if errors.Is(err, ErrTagDeleteUnsupported) {
+			return fmt.Errorf("tagged %s as %s but the registry cannot delete %s without its manifest %s; both tags kept: %w", from, to, from, digest, err)
+		}
+		return fmt.Errorf("tagged %s as %s but could not delete %s: %w", from, to, from, err)
+	}
+	if _, _, err := c.getRawManifest(ctx, image, to, authorize); err == nil {
+		return nil
+	}
+	if err := c.putManifest(ctx, image, to, body, mediaType, authorize); err != nil {
+		return fmt.Errorf("the registry deleted manifest %s shared by %s and %s, and restoring %s failed: %w", digest, from, to, to, err)
+	}
+	return fmt.Errorf("the registry deleted manifest %s shared by %s and %s; restored %s, %s is gone", digest, from, to, to, from)
+}
+
+// pushAuth returns a request authorizer with pull, push and delete rights on
+// image. Password logins fetch a dedicated token for that scope.
+func (c *HTTPClient) pushAuth(ctx context.Context, image string) (func(*http.Request) error, error) {
+	if (c.auth.Kind != "registry_v2" && c.auth.Kind != "acr") || c.auth.RegistryV2.Anonymous || c.auth.RegistryV2.Token != "" {
+		return func(req *http.Request) error {
+			return c.applyAuth(ctx, req)
+		}, nil
+	}
+	token, _, _, err := c.fetchRegistryV2TokenForScope(ctx, "repository:"+image+":pull,push,delete")
+	if err != nil {
+		return nil, err
+	}
+	return func(req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	}, nil
+}
+
+func (c *HTTPClient) getRawManifest(ctx context.Context, image, reference string, authorize func(*http.Request) error) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.resolve("/v2/"+image+"/manifests/"+reference, nil), nil)
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("Accept", manifestAcceptHeader(c.auth.ManifestAccept))
+	if err := authorize(req); err != nil {
+		return nil, "", err
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	c.logRequest(req, resp, start)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, "", fmt.Errorf("manifest request failed: %s", resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	return body, resp.Header.Get("Content-Type"), nil
+}
+
+func (c *HTTPClient) putManifest(ctx context.Context, image, reference string, body []byte, mediaType string, authorize func(*http.Request) error) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.resolve("/v2/"+image+"/manifests/"+reference, nil), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if mediaType != "" {
+		req.Header.Set("Content-Type", mediaType)
+	}
+	if err := authorize(req); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	c.logRequest(req, resp, start)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("manifest upload failed: %s", resp.Status)
+	}
+	return nil
+}
+
+func (c *HTTPClient) deleteManifest(ctx context.Context, image, reference string, authorize func(*http.Request) error) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.resolve("/v2/"+image+"/manifests/"+reference, nil), nil)
+	if err != nil {
+		return err
+	}
+	if err := authorize(req); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	c.logRequest(req, resp, start)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusMethodNotAllowed {
+		return fmt.Errorf("tag delete failed: %w: %s", ErrTagDeleteUnsupported, resp.Status)
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("tag delete failed: %s", resp.Status)
+	}
+	return nil
 }
 
 func (c *HTTPClient) listRepositories(ctx context.Context) ([]string, error) {
-	endpoint := c.resolve("/v2/_catalog", url.Values{
-		"n": []string{fmt.Sprintf("%d", defaultCatalogPageSize)},
+	var repos []string
+	err := c.walkCatalog(ctx, func(page []string) {
+		repos = append(repos, page...)
 	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(repos)
+	return repos, nil
+}
+
+// walkCatalog follows the catalog's Link header page by page, handing each
+// page to fn as it arrives.
+func (c *HTTPClient) walkCatalog(ctx context.Context, fn func([]string)) error {
+	var last string
+	for {
+		page, next, err := c.listRepositoriesPage(ctx, last, defaultCatalogPageSize)
+		if err != nil {
+			return err
+		}
+		if len(page) > 0 {
+			fn(page)
+		}
+		if next == "" || next == last {
+			return nil
+		}
+		last = next
+	}
+}
+
+// listRepositoriesPage returns one catalog page and the last= cursor of the
+// next page, taken from the Link header.
+func (c *HTTPClient) listRepositoriesPage(ctx context.Context, last string, n int) ([]string, string, error) {
+	query := url.Values{}
+	if n > 0 {
+		query.Set("n", fmt.Sprintf("%d", n))
+	}
+	if last != "" {
+		query.Set("last", last)
+	}
+	endpoint := c.resolve("/v2/_catalog", query)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	if err := c.applyAuth(ctx, req); err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
+	start := time.Now()
 	resp, err := c.httpClient.Do(req)
-	c.logRequest(req, resp)
+	c.logRequest(req, resp, start)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, "", fmt.Errorf("%w: %w: %s", ErrCatalogForbidden, ErrUnauthorized, resp.Status)
+	}
+	if resp.StatusCode == http.StatusForbidden {
+		return nil, "", fmt.Errorf("%w: %s", ErrCatalogForbidden, resp.Status)
+	}
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusMethodNotAllowed {
+		return nil, "", fmt.Errorf("%w: %s", ErrCatalogDisabled, resp.Status)
+	}
 	if resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("catalog request failed: %s", resp.Status)
+		return nil, "", fmt.Errorf("catalog request failed: %s", resp.Status)
 	}
 
 	var payload struct {
 		Repositories []string `json:"repositories"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
-		return nil, err
+		return nil, "", err
 	}
+	return payload.Repositories, catalogNextCursor(resp.Header.Get("Link"), c.baseURL), nil
+}
 
-	sort.Strings(payload.Repositories)
-	return payload.Repositories, nil
+func catalogNextCursor(link string, baseURL *url.URL) string {
+	next := parseGitHubContainerNext(link, baseURL)
+	if next == "" {
+		return ""
+	}
+	parsed, err := url.Parse(next)
+	if err != nil {
+		return ""
+	}
+	return parsed.Query().Get("last")
 }
 
 func (c *HTTPClient) listTags(ctx context.Context, repository string) ([]Tag, error) {
@@ -126,17 +378,28 @@ func (c *HTTPClient) listTags(ctx context.Context, repository string) ([]Tag, er
 		return nil, err
 	}
 
+	start := time.Now()
 	resp, err := c.httpClient.Do(req)
-	c.logRequest(req, resp)
+	c.logRequest(req, resp, start)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
+	if err := challengeError(resp); err != nil {
+		return nil, fmt.Errorf("tags request failed: %w", err)
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, fmt.Errorf("tags request failed: %w: %s", ErrUnauthorized, resp.Status)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("tags request failed: %w: %s", ErrRepositoryNotFound, resp.Status)
+	}
 	if resp.StatusCode >= 300 {
 		return nil, fmt.Errorf("tags request failed: %s", resp.Status)
 	}
 
+	// An existing repository without tags may answer {"tags": null}.
 	var payload struct {
 		Tags []string `json:"tags"`
 	}
@@ -150,7 +413,7 @@ func (c *HTTPClient) listTags(ctx context.Context, repository string) ([]Tag, er
 
 	tags := make([]Tag, 0, len(payload.Tags))
 	for _, name := range payload.Tags {
-		tags = append(tags, Tag{Name: name, SizeBytes: -1})
+		tags = append(tags, Tag{Name: name, SizeBytes: -1, ArtifactType: ArtifactTypeFromTagName(name)})
 	}
 	return tags, nil
 }
@@ -161,18 +424,14 @@ func (c *HTTPClient) getManifest(ctx context.Context, image, reference string) (
 	if err != nil {
 		return ManifestV2{}, err
 	}
-	req.Header.Set("Accept", strings.Join([]string{
-		"application/vnd.docker.distribution.manifest.v2+json",
-		"application/vnd.oci.image.manifest.v1+json",
-		"application/vnd.docker.distribution.manifest.list.v2+json",
-		"application/vnd.oci.image.index.v1+json",
-	}, ", "))
+	req.Header.Set("Accept", manifestAcceptHeader(c.auth.ManifestAccept, "application/vnd.docker.distribution.manifest.v1+prettyjws"))
 	if err := c.applyAuth(ctx, req); err != nil {
 		return ManifestV2{}, err
 	}
 
+	start := time.Now()
 	resp, err := c.httpClient.Do(req)
-	c.logRequest(req, resp)
+	c.logRequest(req, resp, start)
 	if err != nil {
 		return ManifestV2{}, err
 	}
@@ -182,11 +441,7 @@ func (c *HTTPClient) getManifest(ctx context.Context, image, reference string) (
 		return ManifestV2{}, fmt.Errorf("manifest request failed: %s", resp.Status)
 	}
 
-	var manifest ManifestV2
-	if err := json.NewDecoder(resp.Body).Decode(&manifest); err != nil {
-		return ManifestV2{}, err
-	}
-	return manifest, nil
+	return decodeManifest(resp)
 }
 
 func (c *HTTPClient) getConfig(ctx context.Context, image, digest string) (ConfigV2, error) {
@@ -199,8 +454,9 @@ func (c *HTTPClient) getConfig(ctx context.Context, image, digest string) (Confi
 		return ConfigV2{}, err
 	}
 
+	start := time.Now()
 	resp, err := c.httpClient.Do(req)
-	c.logRequest(req, resp)
+	c.logRequest(req, resp, start)
 	if err != nil {
 		return ConfigV2{}, err
 	}
@@ -223,7 +479,11 @@ func (c *HTTPClient) resolve(path string, query url.Values) string {
 
 func (c *HTTPClient) applyAuth(ctx context.Context, req *http.Request) error {
 	switch c.auth.Kind {
-	case "registry_v2":
+	case "registry_v2", "acr":
+		if c.auth.RegistryV2.Token != "" {
+			req.Header.Set("Authorization", "Bearer "+c.auth.RegistryV2.Token)
+			return nil
+		}
 		if c.auth.RegistryV2.Anonymous {
 			return nil
 		}
@@ -232,11 +492,20 @@ func (c *HTTPClient) applyAuth(ctx context.Context, req *http.Request) error {
 			return err
 		}
 		req.Header.Set("Authorization", "Bearer "+token)
+	case "gcr":
+		if c.bearer == nil {
+			return nil
+		}
+		token, err := c.bearer(ctx)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
 	}
 	return nil
 }
 
-func (c *HTTPClient) logRequest(req *http.Request, resp *http.Response) {
+func (c *HTTPClient) logRequest(req *http.Request, resp *http.Response, start time.Time) {
 	if c.logger == nil {
 		return
 	}
@@ -245,10 +514,12 @@ func (c *HTTPClient) logRequest(req *http.Request, resp *http.Response) {
 		status = resp.StatusCode
 	}
 	c.logger(RequestLog{
-		Method:  req.Method,
-		URL:     req.URL.String(),
-		Headers: cloneHeader(req.Header),
-		Status:  status,
+		Method:    req.Method,
+		URL:       req.URL.String(),
+		Headers:   cloneHeader(req.Header),
+		Status:    status,
+		StartedAt: start,
+		Duration:  time.Since(start),
 	})
 }
 
@@ -279,9 +550,12 @@ func (c *HTTPClient) getRegistryV2Token(ctx context.Context) (string, error) {
 }
 
 func (c *HTTPClient) fetchRegistryV2Token(ctx context.Context) (string, time.Time, string, error) {
+	return c.fetchRegistryV2TokenForScope(ctx, c.scope)
+}
+
+func (c *HTTPClient) fetchRegistryV2TokenForScope(ctx context.Context, scope string) (string, time.Time, string, error) {
 	auth := c.auth.RegistryV2
 	form := url.Values{}
-	scope := registryScope()
 	form.Set("scope", scope)
 	if auth.Service != "" {
 		form.Set("service", auth.Service)
@@ -307,7 +581,7 @@ func (c *HTTPClient) fetchRegistryV2Token(ctx context.Context) (string, time.Tim
 
 	tokenURL := auth.TokenURL
 	if tokenURL == "" {
-		tokenURL = c.resolve("/token", nil)
+		tokenURL = c.resolve(c.tokenPath, nil)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
@@ -316,15 +590,22 @@ func (c *HTTPClient) fetchRegistryV2Token(ctx context.Context) (string, time.Tim
 	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
+	start := time.Now()
 	resp, err := c.httpClient.Do(req)
-	c.logRequest(req, resp)
+	c.logRequest(req, resp, start)
 	if err != nil {
 		return "", time.Time{}, "", err
 	}
 	defer resp.Body.Close()
 
+	if err := challengeError(resp); err != nil {
+		return "", time.Time{}, "", err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		return "", time.Time{}, "", fmt.Errorf("%s token request failed: %w: %s", c.auth.Kind, ErrUnauthorized, resp.Status)
+	}
 	if resp.StatusCode >= 300 {
-		return "", time.Time{}, "", fmt.Errorf("registry_v2 token request failed: %s", resp.Status)
+		return "", time.Time{}, "", fmt.Errorf("%s token request failed: %s", c.auth.Kind, resp.Status)
 	}
 
 	token, refresh, expiry, err := decodeTokenResponse(resp)
@@ -332,7 +613,7 @@ func (c *HTTPClient) fetchRegistryV2Token(ctx context.Context) (string, time.Tim
 		return "", time.Time{}, "", err
 	}
 	if token == "" {
-		return "", time.Time{}, "", errors.New("registry_v2 token response missing token")
+		return "", time.Time{}, "", fmt.Errorf("%s token response missing token", c.auth.Kind)
 	}
 	return token, expiry, refresh, nil
 }
@@ -0,0 +1,535 @@
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHTTPClientCatalogPages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/_catalog" {
+			http.NotFound(w, r)
+			return
+		}
+		switch r.URL.Query().Get("last") {
+		case "":
+			w.Header().Set("Link", `</v2/_catalog?last=b&n=2>; rel="next"`)
+			_, _ = w.Write([]byte(`{"repositories":["a","b"]}`))
+		case "b":
+			_, _ = w.Write([]byte(`{"repositories":["c"]}`))
+		default:
+			http.Error(w, "unexpected cursor", http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	auth := Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	client, err := NewClientWithLogger(server.URL, auth, nil)
+	if err != nil {
+		t.Fatalf("NewClientWithLogger: %v", err)
+	}
+	pager, ok := client.(ImagePager)
+	if !ok {
+		t.Fatalf("expected %T to implement ImagePager", client)
+	}
+
+	page, next, err := pager.ListImagesPage(context.Background(), "", 2)
+	if err != nil {
+		t.Fatalf("ListImagesPage: %v", err)
+	}
+	if len(page) != 2 || page[0].Name != "a" || next != "b" {
+		t.Fatalf("expected first page a,b with cursor b, got %+v next=%q", page, next)
+	}
+	page, next, err = pager.ListImagesPage(context.Background(), next, 2)
+	if err != nil {
+		t.Fatalf("ListImagesPage: %v", err)
+	}
+	if len(page) != 1 || page[0].Name != "c" || next != "" {
+		t.Fatalf("expected last page c without cursor, got %+v next=%q", page, next)
+	}
+
+	images, err := client.ListImages(context.Background())
+	if err != nil {
+		t.Fatalf("ListImages: %v", err)
+	}
+	if len(images) != 3 {
+		t.Fatalf("expected ListImages to follow every page, got %+v", images)
+	}
+
+	var batches []int
+	err = client.(ImageStreamer).StreamImages(context.Background(), func(batch []Image) {
+		batches = append(batches, len(batch))
+	})
+	if err != nil {
+		t.Fatalf("StreamImages: %v", err)
+	}
+	if len(batches) != 2 || batches[0] != 2 || batches[1] != 1 {
+		t.Fatalf("expected one batch per catalog page, got %v", batches)
+	}
+}
+
+func TestHTTPClientCatalogForbidden(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	auth := Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	client, err := NewClientWithLogger(server.URL, auth, nil)
+	if err != nil {
+		t.Fatalf("NewClientWithLogger: %v", err)
+	}
+	if _, err := client.ListImages(context.Background()); !errors.Is(err, ErrCatalogForbidden) {
+		t.Fatalf("expected ErrCatalogForbidden, got %v", err)
+	}
+}
+
+func TestHTTPClientCatalogDisabled(t *testing.T) {
+	for _, status := range []int{http.StatusNotFound, http.StatusMethodNotAllowed} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(status)
+			}))
+			defer server.Close()
+
+			auth := Auth{Kind: "registry_v2"}
+			auth.RegistryV2.Anonymous = true
+			client, err := NewClientWithLogger(server.URL, auth, nil)
+			if err != nil {
+				t.Fatalf("NewClientWithLogger: %v", err)
+			}
+			if _, err := client.ListImages(context.Background()); !errors.Is(err, ErrCatalogDisabled) {
+				t.Fatalf("expected ErrCatalogDisabled, got %v", err)
+			}
+		})
+	}
+}
+
+func TestHTTPClientTagsNotFoundVersusEmpty(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/empty/tags/list":
+			_, _ = w.Write([]byte(`{"name":"empty","tags":null}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	auth := Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	client, err := NewClientWithLogger(server.URL, auth, nil)
+	if err != nil {
+		t.Fatalf("NewClientWithLogger: %v", err)
+	}
+	tags, err := client.ListTags(context.Background(), "empty")
+	if err != nil || len(tags) != 0 {
+		t.Fatalf("expected an empty repository to list no tags, got %v, %v", tags, err)
+	}
+	if _, err := client.ListTags(context.Background(), "missing"); !errors.Is(err, ErrRepositoryNotFound) {
+		t.Fatalf("expected ErrRepositoryNotFound, got %v", err)
+	}
+}
+
+func TestHTTPClientStaticBearerToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/_catalog" {
+			t.Errorf("unexpected request to %s; the token exchange should be skipped", r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer tok" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="http://example.invalid/token"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"repositories":["a"]}`))
+	}))
+	defer server.Close()
+
+	auth := Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Token = " tok "
+	auth.Normalize()
+	if err := auth.Validate(); err != nil {
+		t.Fatalf("expected token-only auth to validate, got %v", err)
+	}
+	if ProviderForAuth(server.URL, auth).NeedsAuthPrompt(auth) {
+		t.Fatalf("expected no auth prompt when a token is configured")
+	}
+	client, err := NewClientWithLogger(server.URL, auth, nil)
+	if err != nil {
+		t.Fatalf("NewClientWithLogger: %v", err)
+	}
+	images, err := client.ListImages(context.Background())
+	if err != nil {
+		t.Fatalf("ListImages: %v", err)
+	}
+	if len(images) != 1 || images[0].Name != "a" {
+		t.Fatalf("unexpected images %+v", images)
+	}
+}
+
+func TestHTTPClientHistoryWithDigest(t *testing.T) {
+	manifest := `{"schemaVersion":2,"config":{"digest":"sha256:cfg"},"layers":[{"size":1}]}`
+	sum := sha256.Sum256([]byte(manifest))
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{name: "content digest header", header: "sha256:fromheader", want: "sha256:fromheader"},
+		{name: "computed from body", want: "sha256:" + hex.EncodeToString(sum[:])},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/v2/app/manifests/v1":
+					if tt.header != "" {
+						w.Header().Set("Docker-Content-Digest", tt.header)
+					}
+					_, _ = w.Write([]byte(manifest))
+				case "/v2/app/blobs/sha256:cfg":
+					_, _ = w.Write([]byte(`{"history":[{"created_by":"RUN true"}]}`))
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer server.Close()
+
+			auth := Auth{Kind: "registry_v2"}
+			auth.RegistryV2.Anonymous = true
+			client, err := NewClientWithLogger(server.URL, auth, nil)
+			if err != nil {
+				t.Fatalf("NewClientWithLogger: %v", err)
+			}
+			resolver, ok := client.(TagHistoryResolver)
+			if !ok {
+				t.Fatalf("expected %T to implement TagHistoryResolver", client)
+			}
+			history, digest, err := resolver.ListTagHistoryWithDigest(context.Background(), "app", "v1")
+			if err != nil {
+				t.Fatalf("ListTagHistoryWithDigest: %v", err)
+			}
+			if len(history) != 1 || digest != tt.want {
+				t.Fatalf("expected 1 entry with digest %q, got %d entries and %q", tt.want, len(history), digest)
+			}
+		})
+	}
+}
+
+func TestHTTPClientRenameTag(t *testing.T) {
+	const manifest = `{"schemaVersion":2,"config":{"digest":"sha256:cfg"}}`
+	const mediaType = "application/vnd.oci.image.manifest.v1+json"
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch {
+		case r.URL.Path == "/token":
+			if err := r.ParseForm(); err != nil || r.Form.Get("scope") != "repository:team/app:pull,push,delete" {
+				t.Errorf("expected a push-scoped token request, got scope %q", r.Form.Get("scope"))
+			}
+			_, _ = w.Write([]byte(`{"token":"push-token"}`))
+		case r.Header.Get("Authorization") != "Bearer push-token":
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/team/app/manifests/old":
+			w.Header().Set("Content-Type", mediaType)
+			_, _ = w.Write([]byte(manifest))
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/team/app/manifests/new":
+			w.Header().Set("Content-Type", mediaType)
+			_, _ = w.Write([]byte(manifest))
+		case r.Method == http.MethodPut && r.URL.Path == "/v2/team/app/manifests/new":
+			body, _ := io.ReadAll(r.Body)
+			if string(body) != manifest || r.Header.Get("Content-Type") != mediaType {
+				t.Errorf("unexpected manifest upload %q (%s)", body, r.Header.Get("Content-Type"))
+			}
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodDelete && r.URL.Path == "/v2/team/app/manifests/old":
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	auth := Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Username = "user"
+	auth.RegistryV2.Password = "pass"
+	client, err := NewClientWithLogger(server.URL, auth, nil)
+	if err != nil {
+		t.Fatalf("NewClientWithLogger: %v", err)
+	}
+	if err := client.RenameTag(context.Background(), "team/app", "old", "new"); err != nil {
+		t.Fatalf("RenameTag: %v", err)
+	}
+	want := []string{"POST /token", "GET /v2/team/app/manifests/old", "PUT /v2/team/app/manifests/new", "DELETE /v2/team/app/manifests/old", "GET /v2/team/app/manifests/new"}
+	if strings.Join(calls, ", ") != strings.Join(want, ", ") {
+		t.Fatalf("unexpected request sequence %v", calls)
+	}
+}
+
+// fakeDigestRegistry deletes tags the way registries that resolve a tag to
+// its digest do: every tag sharing the manifest goes with it.
+type fakeDigestRegistry struct {
+	tags          map[string]string
+	refuseDeletes bool
+}
+
+func (f *fakeDigestRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	tag := strings.TrimPrefix(r.URL.Path, "/v2/team/app/manifests/")
+	switch r.Method {
+	case http.MethodGet:
+		body, ok := f.tags[tag]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		f.tags[tag] = string(body)
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodDelete:
+		if f.refuseDeletes {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		shared := f.tags[tag]
+		for name, body := range f.tags {
+			if body == shared {
+				delete(f.tags, name)
+			}
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+func TestHTTPClientRenameTagSharedDigest(t *testing.T) {
+	const manifest = `{"schemaVersion":2,"config":{"digest":"sha256:cfg"}}`
+	tests := []struct {
+		name     string
+		refuse   bool
+		wantErr  string
+		wantTags []string
+	}{
+		{name: "delete by digest", wantErr: "restored new, old is gone", wantTags: []string{"new"}},
+		{name: "tag delete refused", refuse: true, wantErr: "both tags kept", wantTags: []string{"new", "old"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeDigestRegistry{tags: map[string]string{"old": manifest}, refuseDeletes: tt.refuse}
+			server := httptest.NewServer(fake)
+			defer server.Close()
+
+			auth := Auth{Kind: "registry_v2"}
+			auth.RegistryV2.Anonymous = true
+			client, err := NewClientWithLogger(server.URL, auth, nil)
+			if err != nil {
+				t.Fatalf("NewClientWithLogger: %v", err)
+			}
+			err = client.RenameTag(context.Background(), "team/app", "old", "new")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) || !strings.Contains(err.Error(), "sha256:") {
+				t.Fatalf("expected an error mentioning %q and the digest, got %v", tt.wantErr, err)
+			}
+			var tags []string
+			for name := range fake.tags {
+				tags = append(tags, name)
+			}
+			sort.Strings(tags)
+			if strings.Join(tags, ",") != strings.Join(tt.wantTags, ",") {
+				t.Fatalf("expected tags %v to remain, got %v", tt.wantTags, tags)
+			}
+		})
+	}
+}
+
+func TestHTTPClientUnauthorizedErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	auth := Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	client, err := NewClientWithLogger(server.URL, auth, nil)
+	if err != nil {
+		t.Fatalf("NewClientWithLogger: %v", err)
+	}
+	_, err = client.ListImages(context.Background())
+	if !errors.Is(err, ErrUnauthorized) || !errors.Is(err, ErrCatalogForbidden) {
+		t.Fatalf("expected a catalog 401 to wrap ErrUnauthorized and ErrCatalogForbidden, got %v", err)
+	}
+	if _, err := client.ListTags(context.Background(), "team/app"); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected a tags 401 to wrap ErrUnauthorized, got %v", err)
+	}
+}
+
+func TestHTTPClientDeleteTag(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodDelete && r.URL.Path == "/v2/team/app/manifests/old" {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}))
+	defer server.Close()
+
+	auth := Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	client, err := NewClientWithLogger(server.URL, auth, nil)
+	if err != nil {
+		t.Fatalf("NewClientWithLogger: %v", err)
+	}
+	if err := client.DeleteTag(context.Background(), "team/app", "old"); err != nil {
+		t.Fatalf("DeleteTag: %v", err)
+	}
+	if err := client.DeleteTag(context.Background(), "team/app", "pinned"); err == nil || !strings.Contains(err.Error(), "405") {
+		t.Fatalf("expected the refused delete to report 405, got %v", err)
+	}
+	want := []string{"DELETE /v2/team/app/manifests/old", "DELETE /v2/team/app/manifests/pinned"}
+	if strings.Join(calls, ", ") != strings.Join(want, ", ") {
+		t.Fatalf("unexpected requests %v", calls)
+	}
+}
+
+func TestHTTPClientManifestAccept(t *testing.T) {
+	tests := []struct {
+		name     string
+		override []string
+		want     []string
+		reject   string
+	}{
+		{
+			name: "default types",
+			want: []string{"application/vnd.oci.artifact.manifest.v1+json", "application/vnd.oci.image.index.v1+json", "application/vnd.docker.distribution.manifest.v1+prettyjws"},
+		},
+		{
+			name:     "context override",
+			override: []string{" application/vnd.oci.image.manifest.v1+json ", ""},
+			want:     []string{"application/vnd.oci.image.manifest.v1+json"},
+			reject:   "application/vnd.docker.distribution.manifest.v2+json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var accept string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/v2/app/manifests/v1":
+					accept = r.Header.Get("Accept")
+					_, _ = w.Write([]byte(`{"schemaVersion":2,"config":{"digest":"sha256:cfg"},"layers":[{"size":1}]}`))
+				case "/v2/app/blobs/sha256:cfg":
+					_, _ = w.Write([]byte(`{"history":[{"created_by":"RUN true"}]}`))
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer server.Close()
+
+			auth := Auth{Kind: "registry_v2", ManifestAccept: tt.override}
+			auth.RegistryV2.Anonymous = true
+			auth.Normalize()
+			client, err := NewClientWithLogger(server.URL, auth, nil)
+			if err != nil {
+				t.Fatalf("NewClientWithLogger: %v", err)
+			}
+			if _, err := client.ListTagHistory(context.Background(), "app", "v1"); err != nil {
+				t.Fatalf("ListTagHistory: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(accept, want) {
+					t.Fatalf("expected Accept %q to contain %q", accept, want)
+				}
+			}
+			if tt.reject != "" && strings.Contains(accept, tt.reject) {
+				t.Fatalf("expected Accept %q to leave out %q", accept, tt.reject)
+			}
+		})
+	}
+}
+
+func TestHTTPClientLogsRequestTiming(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(5 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"repositories":["app"]}`))
+	}))
+	defer server.Close()
+
+	var logs []RequestLog
+	auth := Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	client, err := NewClientWithLogger(server.URL, auth, func(log RequestLog) { logs = append(logs, log) })
+	if err != nil {
+		t.Fatalf("NewClientWithLogger: %v", err)
+	}
+	before := time.Now()
+	if _, err := client.ListImages(context.Background()); err != nil {
+		t.Fatalf("ListImages: %v", err)
+	}
+	if len(logs) == 0 {
+		t.Fatalf("expected the catalog request to be logged")
+	}
+	if logs[0].StartedAt.Before(before) || logs[0].Duration < 5*time.Millisecond {
+		t.Fatalf("expected start time and duration, got %v and %v", logs[0].StartedAt, logs[0].Duration)
+	}
+}
+
+func TestHTTPClientFailsOverToMirror(t *testing.T) {
+	down := httptest.NewServer(http.NotFoundHandler())
+	downURL := down.URL
+	down.Close()
+
+	var primaryCalls, mirrorCalls int
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mirrorCalls++
+		_, _ = io.WriteString(w, `{"name":"app","tags":["v1"]}`)
+	}))
+	defer mirror.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		primaryCalls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+
+	newClient := func(host string) Client {
+		auth := Auth{Kind: "registry_v2", Mirrors: []string{" ", mirror.URL}}
+		auth.RegistryV2.Anonymous = true
+		client, err := NewClientWithLogger(host, auth, nil)
+		if err != nil {
+			t.Fatalf("NewClientWithLogger: %v", err)
+		}
+		return client
+	}
+
+	client := newClient(downURL)
+	for i := 0; i < 2; i++ {
+		tags, err := client.ListTags(context.Background(), "app")
+		if err != nil || len(tags) != 1 || tags[0].Name != "v1" {
+			t.Fatalf("expected the mirror to answer, got %+v (%v)", tags, err)
+		}
+	}
+	if mirrorCalls != 2 {
+		t.Fatalf("expected both requests on the mirror, got %d", mirrorCalls)
+	}
+
+	mirrorCalls = 0
+	if _, err := newClient(failing.URL).ListTags(context.Background(), "app"); err == nil {
+		t.Fatalf("expected an HTTP error from a reachable registry to be returned")
+	}
+	if primaryCalls != 1 || mirrorCalls != 0 {
+		t.Fatalf("expected no failover on an HTTP error, got %d primary and %d mirror calls", primaryCalls, mirrorCalls)
+	}
+}
@@ -22,6 +22,7 @@ func (RegistryV2Provider) TableSpec() TableSpec {
 			ShowSize:       false,
 			ShowPushed:     false,
 			ShowLastPulled: false,
+			ShowPlatforms:  true,
 		},
 		History: HistoryTableSpec{
 			ShowSize:    true,
@@ -34,7 +35,7 @@ func (RegistryV2Provider) NeedsAuthPrompt(auth Auth) bool {
 	if auth.Kind == "none" {
 		return false
 	}
-	if auth.RegistryV2.Anonymous {
+	if auth.RegistryV2.Anonymous || auth.RegistryV2.Token != "" {
 		return false
 	}
 	if auth.RegistryV2.Username == "" {
@@ -13,7 +13,10 @@ type Image struct {
 type Project struct {
 	Name       string
 	ImageCount int
-	UpdatedAt  time.Time
+	// ArtifactCount is the total number of artifacts across the project's
+	// repositories; -1 means unknown.
+	ArtifactCount int
+	UpdatedAt     time.Time
 }
 
 type Tag struct {
@@ -23,6 +26,29 @@ type Tag struct {
 	UpdatedAt    time.Time
 	PushedAt     time.Time
 	LastPulledAt time.Time
+	ArtifactType string
+	// Platforms is the number of platforms covered by the tag's manifest;
+	// 0 means unknown and a negative value that the lookup failed.
+	Platforms int
+	// Labels are registry-side labels attached to the tag's artifact (Harbor).
+	Labels []string
+	// PushedBy is the user who pushed the tag, when the registry records it
+	// (Harbor's audit log).
+	PushedBy string
+	// Signed is set when a cosign signature tag for Digest is in the same
+	// list; see MarkSignedTags.
+	Signed bool
+}
+
+// TagDetails is what inspecting a tag yields: its history, the digest it
+// resolved to, and the labels from the image config.
+type TagDetails struct {
+	History []HistoryEntry
+	Digest  string
+	Labels  map[string]string
+	// Annotations are the manifest annotations, merged over the index's
+	// for multi-platform tags.
+	Annotations map[string]string
 }
 
 type HistoryEntry struct {
@@ -31,4 +57,6 @@ type HistoryEntry struct {
 	Comment    string
 	SizeBytes  int64
 	EmptyLayer bool
+	// Legacy is set when the history came from a schema 1 manifest.
+	Legacy bool
 }
@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -18,10 +19,11 @@ func (m *Model) handleEnter() tea.Cmd {
 
 	switch m.focus {
 	case FocusProjects:
-		if index < 0 || index >= len(m.projects) {
+		projects := m.visibleProjects()
+		if index < 0 || index >= len(projects) {
 			return nil
 		}
-		selected := m.projects[index]
+		selected := projects[index]
 		if projectClient, ok := m.registryClient.(registry.ProjectClient); ok {
 			m.selectedProject = selected.Name
 			m.hasSelectedProject = true
@@ -31,7 +33,7 @@ func (m *Model) handleEnter() tea.Cmd {
 			m.tags = nil
 			m.focus = FocusImages
 			m.status = fmt.Sprintf("Loading images for %s...", selected.Name)
-			m.clearFilter()
+			m.resetFilterOnNavigate()
 			m.syncTable()
 			m.startLoading()
 			return loadProjectImagesCmd(projectClient, selected.Name)
@@ -41,6 +43,9 @@ func (m *Model) handleEnter() tea.Cmd {
 		return nil
 	case FocusImages:
 		visible := m.visibleImages()
+		if len(visible) == 0 && m.catalogForbidden {
+			return m.openRepoPrompt()
+		}
 		if index < 0 || index >= len(visible) {
 			return nil
 		}
@@ -50,12 +55,14 @@ func (m *Model) handleEnter() tea.Cmd {
 		m.selectedTag = registry.Tag{}
 		m.hasSelectedTag = false
 		m.tags = nil
+		m.tagsLoadErr = nil
 		m.focus = FocusTags
 		m.status = fmt.Sprintf("Loading tags for %s...", selected.Name)
-		m.clearFilter()
+		m.resetFilterOnNavigate()
+		m.tagsQuery = ""
 		m.syncTable()
 		m.startLoading()
-		return loadTagsCmd(m.registryClient, selected.Name)
+		return m.tagsLoadCmd(selected.Name)
 	case FocusTags:
 		selected := m.tags[index]
 		m.selectedTag = selected
@@ -63,10 +70,13 @@ func (m *Model) handleEnter() tea.Cmd {
 		m.history = nil
 		m.focus = FocusHistory
 		m.status = fmt.Sprintf("Loading history for %s:%s...", m.selectedImage.Name, selected.Name)
-		m.clearFilter()
+		m.resetFilterOnNavigate()
 		m.syncTable()
 		m.startLoading()
 		return loadHistoryCmd(m.registryClient, m.selectedImage.Name, selected.Name)
+	case FocusHistory:
+		m.openHistoryDetail()
+		return nil
 	default:
 		return nil
 	}
@@ -85,15 +95,22 @@ func (m *Model) handleEscape() tea.Cmd {
 		} else {
 			m.focus = FocusTags
 		}
-		m.clearFilter()
+		m.resetFilterOnNavigate()
+		if !m.stickyFilter {
+			// Keep showing which server-side query the tag list came from.
+			m.filterInput.SetValue(m.tagsQuery)
+		}
 		m.syncTable()
 		return nil
 	case FocusTags:
+		m.cancelTagStream()
 		m.tags = nil
+		m.tagsLoadErr = nil
+		m.tagsQuery = ""
 		m.hasSelectedImage = false
 		m.selectedImage = registry.Image{}
 		m.focus = FocusImages
-		m.clearFilter()
+		m.resetFilterOnNavigate()
 		m.syncTable()
 		return nil
 	case FocusImages:
@@ -101,7 +118,7 @@ func (m *Model) handleEscape() tea.Cmd {
 			m.selectedProject = ""
 			m.hasSelectedProject = false
 			m.focus = FocusProjects
-			m.clearFilter()
+			m.resetFilterOnNavigate()
 			m.syncTable()
 			return nil
 		}
@@ -127,6 +144,56 @@ func (m *Model) stopFilterEditing() {
 	m.filterActive = false
 }
 
+func (m *Model) toggleHideArtifacts() {
+	m.hideArtifacts = !m.hideArtifacts
+	if m.hideArtifacts {
+		m.status = "Hiding non-image artifacts"
+	} else {
+		m.status = "Showing all artifacts"
+	}
+	m.tableSetCursor(0)
+	m.syncTable()
+}
+
+func (m *Model) toggleCleanHistory() {
+	m.cleanHistory = !m.cleanHistory
+	if m.cleanHistory {
+		m.status = "Showing cleaned history commands"
+	} else {
+		m.status = "Showing raw history commands"
+	}
+	m.syncTable()
+}
+
+func (m *Model) toggleWrapNavigation() {
+	m.wrapNavigation = !m.wrapNavigation
+	if m.wrapNavigation {
+		m.status = "Navigation wraps at list edges"
+	} else {
+		m.status = "Navigation stops at list edges"
+	}
+}
+
+func (m *Model) setErrorStatus(status string) {
+	m.status = status
+	m.errorStatus = status
+}
+
+func (m Model) statusIsError() bool {
+	return m.errorStatus != "" && m.status == m.errorStatus
+}
+
+// dismissErrorStatus clears an error status, reporting whether there was one
+// so esc only goes back a level when no error is showing.
+func (m *Model) dismissErrorStatus() bool {
+	if !m.statusIsError() {
+		return false
+	}
+	m.status = ""
+	m.errorStatus = ""
+	return true
+}
+
 func (m *Model) startLoading() {
 	m.loadingCount++
 }
@@ -159,8 +226,19 @@ func (m Model) emptyBodyMessage() string {
 		if m.hasSelectedProject {
 			return fmt.Sprintf("No images found in project %s.", m.selectedProject)
 		}
+		if m.catalogForbidden {
+			return "Catalog listing unavailable. Press Enter to type a repository path."
+		}
 		return "No images to display."
 	case FocusTags:
+		switch {
+		case m.hasSelectedImage && errors.Is(m.tagsLoadErr, registry.ErrRepositoryNotFound):
+			return fmt.Sprintf("Repository %s not found.", m.selectedImage.Name)
+		case m.hasSelectedImage && m.tagsLoadErr != nil:
+			return fmt.Sprintf("Could not load tags for %s: %s", m.selectedImage.Name, loadErrorText(m.tagsLoadErr))
+		case m.hasSelectedImage && len(m.tags) == 0 && m.tagsQuery == "":
+			return fmt.Sprintf("No tags (repository %s is empty).", m.selectedImage.Name)
+		}
 		if m.hasSelectedImage {
 			return fmt.Sprintf("No tags found for %s.", m.selectedImage.Name)
 		}
@@ -179,6 +257,8 @@ func (m Model) emptyBodyMessage() string {
 			return "Type an image name and press Enter to search Docker Hub."
 		}
 		return fmt.Sprintf("No tags found for query %q.", query)
+	case FocusGitHubPackages:
+		return fmt.Sprintf("No container packages found for %s.", m.githubOwner)
 	case FocusGitHubTags:
 		query := strings.TrimSpace(m.githubInput.Value())
 		if m.githubImage != "" {
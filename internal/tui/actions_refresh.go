@@ -15,7 +15,7 @@ func (m *Model) refreshCurrent() tea.Cmd {
 		if m.focus == FocusHistory && m.hasSelectedTag && strings.TrimSpace(m.githubImage) != "" {
 			m.status = fmt.Sprintf("Refreshing history for %s:%s...", m.githubImage, m.selectedTag.Name)
 			m.startLoading()
-			return loadGitHubHistoryCmd(m.githubImage, m.selectedTag.Name, m.logger)
+			return loadGitHubHistoryCmd(m.githubImage, m.selectedTag.Name, m.logger, m.auth.Proxy)
 		}
 		return m.refreshGitHub()
 	}
@@ -23,7 +23,7 @@ func (m *Model) refreshCurrent() tea.Cmd {
 		if m.focus == FocusHistory && m.hasSelectedTag && strings.TrimSpace(m.dockerHubImage) != "" {
 			m.status = fmt.Sprintf("Refreshing history for %s:%s...", m.dockerHubImage, m.selectedTag.Name)
 			m.startLoading()
-			return loadDockerHubHistoryCmd(m.dockerHubImage, m.selectedTag.Name, m.logger)
+			return loadDockerHubHistoryCmd(m.dockerHubImage, m.selectedTag.Name, m.logger, m.auth.Proxy)
 		}
 		return m.refreshDockerHub()
 	}
@@ -56,7 +56,7 @@ func (m *Model) refreshCurrent() tea.Cmd {
 		}
 		m.status = fmt.Sprintf("Refreshing images from %s...", m.registryHost)
 		m.startLoading()
-		return loadImagesCmd(m.registryClient)
+		return m.loadCatalogCmd()
 	case FocusTags:
 		if !m.hasSelectedImage {
 			if m.registryClient == nil {
@@ -74,11 +74,11 @@ func (m *Model) refreshCurrent() tea.Cmd {
 			}
 			m.status = fmt.Sprintf("Refreshing images from %s...", m.registryHost)
 			m.startLoading()
-			return loadImagesCmd(m.registryClient)
+			return m.loadCatalogCmd()
 		}
 		m.status = fmt.Sprintf("Refreshing tags for %s...", m.selectedImage.Name)
 		m.startLoading()
-		return loadTagsCmd(m.registryClient, m.selectedImage.Name)
+		return m.tagsLoadCmd(m.selectedImage.Name)
 	case FocusHistory:
 		if !m.hasSelectedTag {
 			if m.registryClient == nil {
@@ -87,7 +87,7 @@ func (m *Model) refreshCurrent() tea.Cmd {
 			}
 			m.status = fmt.Sprintf("Refreshing tags for %s...", m.selectedImage.Name)
 			m.startLoading()
-			return loadTagsCmd(m.registryClient, m.selectedImage.Name)
+			return m.tagsLoadCmd(m.selectedImage.Name)
 		}
 		m.status = fmt.Sprintf("Refreshing history for %s:%s...", m.selectedImage.Name, m.selectedTag.Name)
 		m.startLoading()
@@ -135,6 +135,41 @@ func (m *Model) applyDockerHubRateLimit(retryAfter time.Duration) {
 	}
 }
 
+// deferDockerHubPage parks a next-page load until the rate limit window ends
+// and starts the countdown tick that resumes it.
+func (m *Model) deferDockerHubPage(forFilter bool) tea.Cmd {
+	if m.dockerHubRetryUntil.IsZero() || time.Now().After(m.dockerHubRetryUntil) {
+		m.dockerHubRetryUntil = time.Now().Add(dockerHubFallbackRetry)
+	}
+	m.status = m.dockerHubRateLimitStatus("Docker Hub rate limit reached")
+	m.dockerHubRetryForFilter = m.dockerHubRetryForFilter || forFilter
+	if m.dockerHubRetryPending {
+		return nil
+	}
+	m.dockerHubRetryPending = true
+	return retryPendingTick(m.dockerHubImage, m.dockerHubNext)
+}
+
+func (m Model) updateRetryPendingMsg(msg retryPendingMsg) (tea.Model, tea.Cmd) {
+	if !m.dockerHubRetryPending {
+		return m, nil
+	}
+	if !m.dockerHubActive || m.focus != FocusDockerHubTags || m.dockerHubImage != msg.image || m.dockerHubNext != msg.next {
+		m.dockerHubRetryPending = false
+		m.dockerHubRetryForFilter = false
+		return m, nil
+	}
+	if !m.dockerHubRetryUntil.IsZero() && time.Now().Before(m.dockerHubRetryUntil) {
+		m.status = m.dockerHubRateLimitStatus("Docker Hub rate limit reached")
+		return m, retryPendingTick(msg.image, msg.next)
+	}
+	forFilter := m.dockerHubRetryForFilter
+	m.dockerHubRetryPending = false
+	m.dockerHubRetryForFilter = false
+	m.dockerHubRetryUntil = time.Time{}
+	return m, m.requestNextDockerHubPage(forFilter)
+}
+
 func (m Model) dockerHubRateLimitStatus(prefix string) string {
 	now := time.Now()
 	if !m.dockerHubRetryUntil.IsZero() && now.Before(m.dockerHubRetryUntil) {
@@ -142,7 +177,7 @@ func (m Model) dockerHubRateLimitStatus(prefix string) string {
 		return fmt.Sprintf("%s. Retry in %s", prefix, wait)
 	}
 	if !m.dockerHubRateLimit.ResetAt.IsZero() && now.Before(m.dockerHubRateLimit.ResetAt) {
-		return fmt.Sprintf("%s. Resets at %s", prefix, m.dockerHubRateLimit.ResetAt.Local().Format("15:04:05"))
+		return fmt.Sprintf("%s. Resets at %s", prefix, m.dockerHubRateLimit.ResetAt.In(m.displayLocation()).Format("15:04:05"))
 	}
 	return prefix
 }
@@ -154,7 +189,7 @@ func (m Model) dockerHubRateLimitSuffix() string {
 	}
 	suffix := fmt.Sprintf(" | rate %d/%d", limit.Remaining, limit.Limit)
 	if !limit.ResetAt.IsZero() {
-		suffix += fmt.Sprintf(" reset %s", limit.ResetAt.Local().Format("15:04:05"))
+		suffix += fmt.Sprintf(" reset %s", limit.ResetAt.In(m.displayLocation()).Format("15:04:05"))
 	}
 	return suffix
 }
@@ -191,6 +226,30 @@ func (m Model) githubLoadedStatus() string {
 	return m.externalLoadedStatus(externalModeGitHub)
 }
 
+// reloadAll drops the current drilldown and reloads the registry from the
+// catalog root, unlike refreshCurrent which only refreshes the focused level.
+func (m *Model) reloadAll() tea.Cmd {
+	if m.dockerHubActive || m.githubActive {
+		kind := externalModeDockerHub
+		if m.githubActive {
+			kind = externalModeGitHub
+		}
+		model, _ := m.exitExternalMode(kind)
+		*m = model.(Model)
+	}
+	m.images = nil
+	m.projects = nil
+	m.tags = nil
+	m.history = nil
+	m.selectionState = selectionState{}
+	m.focus = m.defaultFocus()
+	m.clearFilter()
+	m.tableSetCursor(0)
+	cmd := m.initialLoadCmd()
+	m.syncTable()
+	return cmd
+}
+
 func (m *Model) initialLoadCmd() tea.Cmd {
 	if m.registryClient == nil {
 		m.status = "Registry not configured"
@@ -207,5 +266,50 @@ func (m *Model) initialLoadCmd() tea.Cmd {
 	}
 	m.status = fmt.Sprintf("Connecting to %s...", m.registryHost)
 	m.startLoading()
-	return loadImagesCmd(m.registryClient)
+	return m.loadCatalogCmd()
+}
+
+// platformLookupFailed marks a tag whose platform lookup already failed, so
+// later loads spend the capped lookups on tags not tried yet.
+const platformLookupFailed = -1
+
+// loadTagPlatforms starts platform enrichment for loaded tags that haven't
+// been looked up yet. The number of lookups is capped per load.
+func (m Model) loadTagPlatforms(focus Focus) tea.Cmd {
+	if !m.modeTableSpec().Tag.ShowPlatforms {
+		return nil
+	}
+	var (
+		counter registry.PlatformCounter
+		image   string
+		tags    []registry.Tag
+	)
+	switch focus {
+	case FocusGitHubTags:
+		counter = registry.NewGitHubContainerClient(m.logger, m.auth.Proxy)
+		image = m.githubImage
+		tags = m.githubTags
+	default:
+		client, ok := m.registryClient.(registry.PlatformCounter)
+		if !ok || !m.hasSelectedImage {
+			return nil
+		}
+		counter = client
+		image = m.selectedImage.Name
+		tags = m.tags
+	}
+	names := make([]string, 0, minInt(len(tags), maxPlatformLookups))
+	for _, tag := range tags {
+		if tag.Platforms != 0 {
+			continue
+		}
+		names = append(names, tag.Name)
+		if len(names) == maxPlatformLookups {
+			break
+		}
+	}
+	if len(names) == 0 || image == "" {
+		return nil
+	}
+	return loadTagPlatformsCmd(counter, focus, image, names)
 }
@@ -0,0 +1,258 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/scottbass3/beacon/internal/config"
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+// sortKey is the column a view is sorted on. Views without an entry keep
+// the order their rows were loaded in.
+type sortKey string
+
+const (
+	sortByName  sortKey = "name"
+	sortBySize  sortKey = "size"
+	sortByCount sortKey = "count"
+)
+
+func runSizeCommand(m Model, _ []string) (tea.Model, tea.Cmd) {
+	m.sortCurrentViewBySize()
+	return m, nil
+}
+
+// rememberSort records the sort of the current view so lists loaded into it
+// later in the session come back sorted the same way.
+func (m *Model) rememberSort(key sortKey) {
+	if m.viewSorts == nil {
+		m.viewSorts = make(map[Focus]sortKey)
+	}
+	if key == sortByName {
+		delete(m.viewSorts, m.focus)
+		return
+	}
+	m.viewSorts[m.focus] = key
+}
+
+// applySortSettings seeds the per-view sorts from the config file.
+func (m *Model) applySortSettings(settings config.SortSettings) {
+	m.viewSorts = make(map[Focus]sortKey)
+	if strings.EqualFold(strings.TrimSpace(settings.Tags), string(sortBySize)) {
+		for _, focus := range []Focus{FocusTags, FocusDockerHubTags, FocusGitHubTags} {
+			m.viewSorts[focus] = sortBySize
+		}
+	}
+	if strings.EqualFold(strings.TrimSpace(settings.History), string(sortBySize)) {
+		m.viewSorts[FocusHistory] = sortBySize
+	}
+	if strings.EqualFold(strings.TrimSpace(settings.Projects), string(sortByCount)) {
+		m.viewSorts[FocusProjects] = sortByCount
+		m.projectsByCount = true
+	}
+}
+
+// sortRowsBySize orders a tag or history list largest first when its view
+// is sorted by size. Only the rows move: the loaded slice keeps its order and
+// the indices still point into it.
+func (m Model) sortRowsBySize(list listView, sizeAt func(index int) int64) listView {
+	if m.viewSorts[m.focus] != sortBySize || len(list.rows) < 2 {
+		return list
+	}
+	size := func(index int) int64 {
+		if index < 0 {
+			return -1
+		}
+		return sizeAt(index)
+	}
+	order := make([]int, len(list.rows))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return size(list.indices[order[i]]) > size(list.indices[order[j]])
+	})
+	out := listView{headers: list.headers, rows: make([][]string, 0, len(order)), indices: make([]int, 0, len(order))}
+	for _, i := range order {
+		out.rows = append(out.rows, list.rows[i])
+		out.indices = append(out.indices, list.indices[i])
+	}
+	return out
+}
+
+// sortCurrentViewBySize shows the current tag or history list by size,
+// largest first, and reports the total size in the status line.
+func (m *Model) sortCurrentViewBySize() {
+	switch m.focus {
+	case FocusHistory:
+		if !historyHasSizeData(m.history) {
+			m.status = "Sort unavailable: no size data"
+			return
+		}
+		var total int64
+		for _, entry := range m.history {
+			if entry.SizeBytes > 0 {
+				total += entry.SizeBytes
+			}
+		}
+		m.status = fmt.Sprintf("Sorted %d history entries by size (total %s)", len(m.history), formatSize(total))
+	case FocusTags, FocusDockerHubTags, FocusGitHubTags:
+		tags := m.currentTags()
+		if !tagsHaveSizeData(tags) {
+			m.status = "Sort unavailable: no size data"
+			return
+		}
+		var total int64
+		for _, tag := range tags {
+			if tag.SizeBytes > 0 {
+				total += tag.SizeBytes
+			}
+		}
+		m.status = fmt.Sprintf("Sorted %d tags by size (total %s)", len(tags), formatSize(total))
+	default:
+		m.status = "Sort unavailable: no size data"
+		return
+	}
+	m.rememberSort(sortBySize)
+	m.tableSetCursor(0)
+	m.syncTable()
+}
+
+func (m Model) currentTags() []registry.Tag {
+	switch m.focus {
+	case FocusDockerHubTags:
+		return m.dockerHubTags
+	case FocusGitHubTags:
+		return m.githubTags
+	default:
+		return m.tags
+	}
+}
+
+func tagsHaveSizeData(tags []registry.Tag) bool {
+	for _, tag := range tags {
+		if tag.SizeBytes > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func historyHasSizeData(entries []registry.HistoryEntry) bool {
+	for _, entry := range entries {
+		if entry.SizeBytes > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// sortIndicator marks the column a view is sorted on, largest first.
+const sortIndicator = " ▼"
+
+func runSortCommand(m Model, args []string) (tea.Model, tea.Cmd) {
+	switch m.focus {
+	case FocusProjects:
+	case FocusTags, FocusDockerHubTags, FocusGitHubTags, FocusHistory:
+		return m.runListSortCommand(args)
+	default:
+		m.status = "Sorting by count is available on Projects (use :size for tags/history)"
+		return m, nil
+	}
+	switch {
+	case len(args) == 0:
+		m.projectsByCount = !m.projectsByCount
+	case len(args) == 1 && strings.EqualFold(args[0], "count"):
+		m.projectsByCount = true
+	case len(args) == 1 && strings.EqualFold(args[0], "name"):
+		m.projectsByCount = false
+	default:
+		m.status = "Usage: :sort [name|count]"
+		return m, nil
+	}
+	m.applyProjectSort()
+	return m, nil
+}
+
+// runListSortCommand handles :sort on tags and history, where size is the
+// only sort; :sort off drops it and shows the list in its loaded order.
+func (m Model) runListSortCommand(args []string) (tea.Model, tea.Cmd) {
+	switch {
+	case len(args) == 0 && m.viewSorts[m.focus] != sortBySize,
+		len(args) == 1 && strings.EqualFold(args[0], string(sortBySize)):
+		m.sortCurrentViewBySize()
+		return m, nil
+	case len(args) == 0,
+		len(args) == 1 && (strings.EqualFold(args[0], "off") || strings.EqualFold(args[0], string(sortByName))):
+		m.rememberSort(sortByName)
+		m.status = "Showing the list in loaded order"
+		m.tableSetCursor(0)
+		m.syncTable()
+		return m, nil
+	default:
+		m.status = "Usage: :sort [size|off]"
+		return m, nil
+	}
+}
+
+func (m *Model) toggleProjectSort() {
+	m.projectsByCount = !m.projectsByCount
+	m.applyProjectSort()
+}
+
+func (m *Model) applyProjectSort() {
+	if m.projectsByCount {
+		m.rememberSort(sortByCount)
+	} else {
+		m.rememberSort(sortByName)
+	}
+	if m.projectsByCount {
+		m.status = "Sorted projects by image count"
+	} else {
+		m.status = "Sorted projects by name"
+	}
+	m.tableSetCursor(0)
+	m.syncTable()
+}
+
+// visibleProjects returns the projects in display order. They load sorted by
+// name; projectsByCount puts the largest first, keeping names as tiebreak.
+func (m Model) visibleProjects() []projectInfo {
+	projects := m.projectsInNamespaces()
+	if !m.projectsByCount {
+		return projects
+	}
+	sorted := append([]projectInfo(nil), projects...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ImageCount > sorted[j].ImageCount
+	})
+	return sorted
+}
+
+// markSortedColumn appends the sort indicator to the column titled title,
+// widening it at the expense of the first (Name) column so the title is not
+// clipped.
+func markSortedColumn(columns []table.Column, title string) {
+	index := -1
+	for i, column := range columns {
+		if column.Title == title {
+			index = i
+			break
+		}
+	}
+	if index <= 0 {
+		return
+	}
+	extra := lipgloss.Width(sortIndicator)
+	columns[index].Title += sortIndicator
+	if columns[0].Width > extra+1 {
+		columns[0].Width -= extra
+		columns[index].Width += extra
+	}
+}
@@ -1,10 +1,13 @@
 package tui
 
 import (
+	"errors"
+	"fmt"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/scottbass3/beacon/internal/contextstore"
 	"github.com/scottbass3/beacon/internal/registry"
 )
 
@@ -46,13 +49,22 @@ func (m Model) handleConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "right", "l", "tab":
 		m.confirmFocus = 1
 	case "esc", "n":
-		m.clearConfirm()
-		return m, nil
+		return m.resolveConfirm(false)
 	case "y":
 		return m.resolveConfirm(true)
 	case "enter":
 		return m.resolveConfirm(m.confirmFocus == 1)
 	case "ctrl+c", "q":
+		if m.confirmAction == confirmActionRepairConfig {
+			return m.resolveConfirm(false)
+		}
+		if m.confirmAction != confirmActionQuit {
+			m.clearConfirm()
+			if msg.String() == "ctrl+c" {
+				return m.openQuitConfirm()
+			}
+			return m, nil
+		}
 		return m.resolveConfirm(true)
 	}
 	return m, nil
@@ -72,13 +84,24 @@ func (m Model) openQuitConfirm() (tea.Model, tea.Cmd) {
 
 func (m Model) resolveConfirm(accept bool) (tea.Model, tea.Cmd) {
 	action := m.confirmAction
+	image, from, to := m.retagImage, m.retagFrom, m.retagTo
+	deleteImage, deleteTags := m.deleteImage, m.deleteTags
 	m.clearConfirm()
 	if !accept {
+		if action == confirmActionRepairConfig {
+			return m, tea.Quit
+		}
 		return m, nil
 	}
 	switch action {
 	case confirmActionQuit:
 		return m, tea.Quit
+	case confirmActionRetag:
+		return m.startRetag(image, from, to)
+	case confirmActionDeleteTags:
+		return m.startDeleteTags(deleteImage, deleteTags)
+	case confirmActionRepairConfig:
+		return m.repairConfig()
 	default:
 		return m, nil
 	}
@@ -89,12 +112,17 @@ func (m *Model) clearConfirm() {
 	m.confirmTitle = ""
 	m.confirmMessage = ""
 	m.confirmFocus = 0
+	m.retagImage = ""
+	m.retagFrom = ""
+	m.retagTo = ""
+	m.deleteImage = ""
+	m.deleteTags = nil
 }
 
 func (m Model) submitAuth() (tea.Model, tea.Cmd) {
 	auth := m.auth
 	switch auth.Kind {
-	case "registry_v2":
+	case "registry_v2", "acr":
 		auth.RegistryV2.Username = strings.TrimSpace(m.usernameInput.Value())
 		auth.RegistryV2.Password = m.passwordInput.Value()
 		auth.RegistryV2.Remember = m.remember
@@ -117,7 +145,130 @@ func (m Model) submitAuth() (tea.Model, tea.Cmd) {
 	m.registryClient = client
 	m.authRequired = false
 	m.authError = ""
-	return m, m.initialLoadCmd()
+	if m.authRejected {
+		m.authRejected = false
+		m.rememberContextNeedsAuth()
+	}
+	return m, m.connectLoadCmd()
+}
+
+// promptAuthOnUnauthorized opens the login modal in place of an error when an
+// anonymous context turns out to require credentials.
+func (m *Model) promptAuthOnUnauthorized(err error) (tea.Cmd, bool) {
+	if !errors.Is(err, registry.ErrUnauthorized) || m.provider == nil {
+		return nil, false
+	}
+	auth := m.auth
+	switch auth.Kind {
+	case "registry_v2", "acr":
+		if !auth.RegistryV2.Anonymous {
+			return nil, false
+		}
+		auth.RegistryV2.Anonymous = false
+	case "harbor":
+		if !auth.Harbor.Anonymous {
+			return nil, false
+		}
+		auth.Harbor.Anonymous = false
+	default:
+		return nil, false
+	}
+	if !m.provider.NeedsAuthPrompt(auth) {
+		return nil, false
+	}
+	m.auth = auth
+	m.registryClient = nil
+	m.authRequired = true
+	m.authRejected = true
+	m.authError = "Registry requires authentication"
+	m.authFocus = 0
+	m.status = fmt.Sprintf("Log in to %s", m.registryHost)
+	m.syncAuthFocus()
+	return m.usernameInput.Focus(), true
+}
+
+// reopenAuthOnRejectedLogin brings the login modal back when the registry
+// rejected the configured credentials, either while the client was set up or
+// on the first listing, so they can be corrected in place. Pre-issued tokens
+// cannot be fixed there.
+func (m *Model) reopenAuthOnRejectedLogin(err error) (tea.Cmd, bool) {
+	if !errors.Is(err, registry.ErrUnauthorized) {
+		return nil, false
+	}
+	switch m.auth.Kind {
+	case "registry_v2", "acr":
+		if m.auth.RegistryV2.Token != "" {
+			return nil, false
+		}
+		// A rejected refresh token would be reused by the next attempt.
+		m.auth.RegistryV2.RefreshToken = ""
+	case "harbor":
+	default:
+		return nil, false
+	}
+	m.registryClient = nil
+	m.authRequired = true
+	m.authError = fmt.Sprintf("Login rejected: %v", err)
+	m.authFocus = 0
+	m.passwordInput.SetValue("")
+	m.setErrorStatus(fmt.Sprintf("Log in to %s again", m.registryHost))
+	m.syncAuthFocus()
+	return m.usernameInput.Focus(), true
+}
+
+// loginOnUnauthorized runs both login fallbacks for a load error: anonymous
+// contexts are asked for credentials, rejected ones are asked again.
+func (m *Model) loginOnUnauthorized(err error) (tea.Cmd, bool) {
+	if cmd, ok := m.promptAuthOnUnauthorized(err); ok {
+		return cmd, true
+	}
+	return m.reopenAuthOnRejectedLogin(err)
+}
+
+// loginOnListingUnauthorized is loginOnUnauthorized for catalog and project
+// listings. Rejected credentials are only assumed on the first listing: once
+// something was listed, a 401 is a scope the account lacks, not a bad login.
+func (m *Model) loginOnListingUnauthorized(err error) (tea.Cmd, bool) {
+	if cmd, ok := m.promptAuthOnUnauthorized(err); ok {
+		return cmd, true
+	}
+	if len(m.images) > 0 || len(m.projects) > 0 {
+		return nil, false
+	}
+	return m.reopenAuthOnRejectedLogin(err)
+}
+
+// rememberContextNeedsAuth clears the anonymous flag on the active context so
+// the next start asks for credentials up front.
+func (m *Model) rememberContextNeedsAuth() {
+	index := m.currentContextIndex()
+	if index < 0 {
+		return
+	}
+	if strings.TrimSpace(contextstore.New(m.configPath).Path()) == "" {
+		m.setErrorStatus("cannot save contexts: config path is not set")
+		return
+	}
+	name := m.contexts[index].Name
+	updated, err := contextstore.NewService(m.configPath).Update(func(current []contextstore.Context) ([]contextstore.Context, error) {
+		// Edit the file as it is now so contexts saved by another instance
+		// survive; fall back to the loaded list when the file has none.
+		if len(current) == 0 {
+			current = contextOptionsToStoredContexts(m.contexts)
+		}
+		i, ok := contextstore.ResolveByName(current, name)
+		if !ok {
+			return nil, fmt.Errorf("context %q not found", name)
+		}
+		current[i].Auth.RegistryV2.Anonymous = false
+		current[i].Auth.Harbor.Anonymous = false
+		return current, nil
+	})
+	if err != nil {
+		m.setErrorStatus(fmt.Sprintf("failed to save contexts: %v", err))
+		return
+	}
+	m.contexts = storedContextsToContextOptions(updated)
 }
 
 func (m Model) enterDockerHubMode() (tea.Model, tea.Cmd) {
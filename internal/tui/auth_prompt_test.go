@@ -0,0 +1,221 @@
+package tui
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/scottbass3/beacon/internal/contextstore"
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+func TestUnauthorizedAnonymousContextOpensLogin(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	contexts := []ContextOption{{Name: "prod", Host: "https://registry.example.com", Auth: auth}}
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, contexts, "prod", configPath)
+	m.registryClient = &fakeTagSearchClient{}
+
+	err := fmt.Errorf("%w: %w: 401 Unauthorized", registry.ErrCatalogForbidden, registry.ErrUnauthorized)
+	updated, _ := m.Update(imagesMsg{err: err})
+	m = updated.(Model)
+	if !m.isAuthModalActive() || m.repoPromptActive {
+		t.Fatalf("expected the login modal instead of the repository prompt, status %q", m.status)
+	}
+	if !m.authUI().ShowUsername || !m.usernameInput.Focused() {
+		t.Fatalf("expected the username input to be shown and focused")
+	}
+
+	m.usernameInput.SetValue("alice")
+	m.passwordInput.SetValue("secret")
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.isAuthModalActive() || cmd == nil {
+		t.Fatalf("expected enter to log in and reload, auth error %q", m.authError)
+	}
+	if m.contexts[0].Auth.RegistryV2.Anonymous {
+		t.Fatalf("expected the context to be remembered as needing auth")
+	}
+	stored, err := contextstore.New(configPath).Ensure()
+	if err != nil {
+		t.Fatalf("Ensure: %v", err)
+	}
+	if len(stored) != 1 || stored[0].Auth.RegistryV2.Anonymous {
+		t.Fatalf("expected the saved context to drop anonymous, got %+v", stored)
+	}
+}
+
+func TestRememberNeedsAuthKeepsContextsSavedElsewhere(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	contexts := []ContextOption{{Name: "prod", Host: "https://registry.example.com", Auth: auth}}
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, contexts, "prod", configPath)
+
+	// Another instance adds a context after this one loaded the file.
+	stored := append(contextOptionsToStoredContexts(contexts), contextstore.Context{Name: "staging", Host: "https://staging.example.com", Auth: auth})
+	if err := contextstore.New(configPath).Save(stored); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	m.rememberContextNeedsAuth()
+	if m.statusIsError() {
+		t.Fatalf("unexpected error status %q", m.status)
+	}
+	saved, err := contextstore.New(configPath).Ensure()
+	if err != nil {
+		t.Fatalf("Ensure: %v", err)
+	}
+	if len(saved) != 2 || saved[1].Name != "staging" {
+		t.Fatalf("expected the staging context to survive, got %+v", saved)
+	}
+	if saved[0].Auth.RegistryV2.Anonymous || !saved[1].Auth.RegistryV2.Anonymous {
+		t.Fatalf("expected only prod to drop anonymous, got %+v", saved)
+	}
+	if len(m.contexts) != 2 || m.contexts[0].Auth.RegistryV2.Anonymous {
+		t.Fatalf("expected the model to pick up the merged contexts, got %+v", m.contexts)
+	}
+}
+
+func TestUnauthorizedWithTokenKeepsError(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Token = "pre-issued"
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.registryClient = &fakeTagSearchClient{}
+
+	updated, _ := m.Update(projectsMsg{err: fmt.Errorf("harbor request failed: %w: 401 Unauthorized", registry.ErrUnauthorized)})
+	m = updated.(Model)
+	if m.isAuthModalActive() || !m.statusIsError() {
+		t.Fatalf("expected an error status for a context with a pre-issued token")
+	}
+}
+
+func TestHarborRobotLoginPrefillsName(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	auth := registry.Auth{Kind: "harbor"}
+	auth.Harbor.Robot = true
+	m := NewModel("https://harbor.example.com", auth, nil, false, nil, nil, "", "")
+	m.width = 120
+	m.height = 40
+
+	if !m.isAuthModalActive() || m.usernameInput.Value() != "robot$" {
+		t.Fatalf("expected the login modal with robot$ prefilled, got %q", m.usernameInput.Value())
+	}
+	if view := m.renderAuthModal(); !strings.Contains(view, "Robot account") {
+		t.Fatalf("expected the robot account hint in the login modal")
+	}
+
+	m.usernameInput.SetValue("team+ci")
+	m.passwordInput.SetValue("secret")
+	updated, _ := m.submitAuth()
+	m = updated.(Model)
+	if !m.isAuthModalActive() || !strings.Contains(m.authError, "robot$") {
+		t.Fatalf("expected a robot name error, got %q", m.authError)
+	}
+}
+
+func TestRejectedLoginAtInitReopensAuth(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	tests := []struct {
+		name   string
+		err    error
+		reopen bool
+	}{
+		{name: "unauthorized", err: fmt.Errorf("authentication failed: invalid_token: %w", registry.ErrUnauthorized), reopen: true},
+		{name: "network", err: fmt.Errorf("dial tcp: connection refused")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth := registry.Auth{Kind: "registry_v2"}
+			auth.RegistryV2.Username = "alice"
+			auth.RegistryV2.Remember = true
+			auth.RegistryV2.RefreshToken = "stale"
+			m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+			m.authRequired = false
+
+			updated, cmd := m.Update(initClientMsg{err: tt.err})
+			m = updated.(Model)
+			if m.isAuthModalActive() != tt.reopen {
+				t.Fatalf("expected login modal %v, status %q", tt.reopen, m.status)
+			}
+			if !tt.reopen {
+				return
+			}
+			if cmd == nil || !m.usernameInput.Focused() || m.usernameInput.Value() != "alice" {
+				t.Fatalf("expected the username input to be focused with alice, got %q", m.usernameInput.Value())
+			}
+			if m.auth.RegistryV2.RefreshToken != "" || !strings.Contains(m.authError, "invalid_token") {
+				t.Fatalf("expected the stale token dropped and the error shown, got %q", m.authError)
+			}
+		})
+	}
+}
+
+func TestRejectedCredentialsOnCatalogReopenAuth(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="registry"`)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Username = "alice"
+	auth.RegistryV2.Password = "wrong"
+	client, err := registry.NewClientWithLogger(server.URL, auth, nil)
+	if err != nil {
+		t.Fatalf("NewClientWithLogger: %v", err)
+	}
+	m := NewModel(server.URL, auth, nil, false, nil, nil, "", "")
+	m.authRequired = false
+	m.registryClient = client
+
+	updated, cmd := m.Update(loadImagesCmd(client)())
+	m = updated.(Model)
+	if !m.isAuthModalActive() || m.repoPromptActive || cmd == nil {
+		t.Fatalf("expected the login modal to reopen, status %q", m.status)
+	}
+	if m.usernameInput.Value() != "alice" || m.passwordInput.Value() != "" {
+		t.Fatalf("expected alice kept and the password cleared, got %q", m.usernameInput.Value())
+	}
+}
+
+func TestUnauthorizedTagsKeepCredentials(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Username = "alice"
+	auth.RegistryV2.Password = "secret"
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.authRequired = false
+	client := &fakeTagSearchClient{}
+	m.registryClient = client
+	m.images = []registry.Image{{Name: "team/private"}}
+	m.hasSelectedImage = true
+	m.selectedImage = registry.Image{Name: "team/private"}
+	m.passwordInput.SetValue("secret")
+
+	err := fmt.Errorf("%w: insufficient_scope", registry.ErrUnauthorized)
+	updated, _ := m.Update(tagsMsg{image: "team/private", err: err})
+	m = updated.(Model)
+	if m.isAuthModalActive() || m.registryClient != client || m.passwordInput.Value() != "secret" {
+		t.Fatalf("expected a tag scope error to keep the login, status %q", m.status)
+	}
+	if !m.statusIsError() || !strings.Contains(m.status, "team/private") {
+		t.Fatalf("expected the scope error in the status, got %q", m.status)
+	}
+
+	m.focus = FocusProjects
+	m.projects = []projectInfo{{Name: "team"}}
+	m.images = nil
+	updated, _ = m.Update(imagesMsg{err: err})
+	if next := updated.(Model); next.isAuthModalActive() || next.registryClient != client {
+		t.Fatalf("expected a project image 401 after the project list to keep the login")
+	}
+}
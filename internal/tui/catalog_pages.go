@@ -0,0 +1,154 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+// defaultCatalogLimit matches the single catalog page Beacon used to load.
+const defaultCatalogLimit = 1000
+
+func loadImagesPageCmd(client registry.Client, pager registry.ImagePager, last string, limit int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), registryLoadTimeout)
+		defer cancel()
+
+		images, next, err := pager.ListImagesPage(ctx, last, limit)
+		return imagesMsg{client: client, images: images, next: next, appendPage: last != "", err: withTimeout(err, registryLoadTimeout)}
+	}
+}
+
+// catalogPageSize is the number of repositories loaded per catalog batch, or
+// 0 to load the whole catalog at once.
+func (m Model) catalogPageSize() int {
+	switch {
+	case m.catalogLimit < 0:
+		return 0
+	case m.catalogLimit == 0:
+		return defaultCatalogLimit
+	default:
+		return m.catalogLimit
+	}
+}
+
+// loadCatalogCmd loads the first batch of repositories when the client can
+// page through its catalog, and the whole listing otherwise. A watch tick
+// reloads every batch already shown in one unstreamed request.
+func (m Model) loadCatalogCmd() tea.Cmd {
+	if pager, ok := m.registryClient.(registry.ImagePager); ok && m.catalogPageSize() > 0 {
+		limit := m.catalogPageSize()
+		if m.watchRefreshing {
+			limit = maxInt(limit, len(m.images))
+		}
+		return loadImagesPageCmd(m.registryClient, pager, "", limit)
+	}
+	if m.watchRefreshing {
+		return listImagesCmd(m.registryClient)
+	}
+	return loadImagesCmd(m.registryClient)
+}
+
+// imagesHaveMoreRows reports whether the catalog list can still grow, so
+// wrap-around navigation leaves the bottom to the page loader.
+func (m Model) imagesHaveMoreRows() bool {
+	if m.focus != FocusImages && m.focus != FocusProjects {
+		return false
+	}
+	return m.imagesNext != "" || m.imagesLoadingMore
+}
+
+func (m *Model) maybeLoadMoreImagesOnBottomKey(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case isShortcut(msg, shortcutMoveDown),
+		isShortcut(msg, shortcutMovePageDown),
+		isShortcut(msg, shortcutMoveHalfDown),
+		isShortcut(msg, shortcutMoveBottom):
+	default:
+		return nil
+	}
+	return m.maybeLoadMoreImagesOnBottom()
+}
+
+func (m *Model) maybeLoadMoreImagesOnBottom() tea.Cmd {
+	if m.focus != FocusImages && m.focus != FocusProjects {
+		return nil
+	}
+	rows := m.table.Rows()
+	if len(rows) == 0 || m.table.Cursor() < len(rows)-1 {
+		return nil
+	}
+	return m.loadMoreImages()
+}
+
+// maybeLoadImagesForFilter keeps fetching catalog batches while a filter
+// leaves fewer matches than fit on screen, so repositories past the loaded
+// pages can still be found.
+func (m *Model) maybeLoadImagesForFilter() tea.Cmd {
+	filter := strings.TrimSpace(m.filterInput.Value())
+	if filter == "" {
+		return nil
+	}
+	if m.focus != FocusImages && m.focus != FocusProjects {
+		return nil
+	}
+	if len(m.table.Rows()) >= maxInt(1, m.table.Height()) {
+		return nil
+	}
+	cmd := m.loadMoreImages()
+	if cmd != nil {
+		m.status = fmt.Sprintf("Loading more images matching %q after %s...", filter, m.imagesNext)
+	}
+	return cmd
+}
+
+func (m *Model) loadMoreImages() tea.Cmd {
+	if m.imagesNext == "" || m.imagesLoadingMore {
+		return nil
+	}
+	pager, ok := m.registryClient.(registry.ImagePager)
+	if !ok {
+		return nil
+	}
+	m.imagesLoadingMore = true
+	m.status = fmt.Sprintf("Loading more images after %s...", m.imagesNext)
+	m.startLoading()
+	return loadImagesPageCmd(m.registryClient, pager, m.imagesNext, m.catalogPageSize())
+}
+
+func (m Model) updateImagesPageMsg(msg imagesMsg) (tea.Model, tea.Cmd) {
+	if msg.client != m.registryClient {
+		return m, nil
+	}
+	m.stopLoading()
+	m.imagesLoadingMore = false
+	if msg.err != nil {
+		m.setErrorStatus(fmt.Sprintf("Error loading more images: %s", loadErrorText(msg.err)))
+		return m, nil
+	}
+	m.images = append(m.images, msg.images...)
+	m.imagesNext = msg.next
+	if m.tableSpec().SupportsProjects {
+		m.projects = deriveProjects(m.images)
+	}
+	m.status = m.imagesLoadedStatus()
+	counts := m.startTagCounts(false)
+	m.syncTable()
+	return m, tea.Batch(m.maybeLoadImagesForFilter(), counts)
+}
+
+func (m Model) imagesLoadedStatus() string {
+	images := len(m.imagesInNamespaces())
+	status := fmt.Sprintf("Loaded %d images", images)
+	if m.tableSpec().SupportsProjects {
+		status = fmt.Sprintf("Loaded %d images across %d projects", images, len(m.projectsInNamespaces()))
+	}
+	if m.imagesNext != "" {
+		status += " [more]"
+	}
+	return status
+}
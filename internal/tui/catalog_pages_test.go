@@ -0,0 +1,131 @@
+package tui
+
+import (
+	"context"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/scottbass3/beacon/internal/config"
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+type fakePagedClient struct {
+	registry.Client
+	repos []string
+}
+
+func (c *fakePagedClient) ListImagesPage(_ context.Context, last string, limit int) ([]registry.Image, string, error) {
+	start := 0
+	for i, repo := range c.repos {
+		if repo == last {
+			start = i + 1
+		}
+	}
+	end := minInt(len(c.repos), start+limit)
+	var images []registry.Image
+	for _, repo := range c.repos[start:end] {
+		images = append(images, registry.Image{Name: repo, TagCount: -1})
+	}
+	next := ""
+	if end < len(c.repos) {
+		next = c.repos[end-1]
+	}
+	return images, next, nil
+}
+
+func TestCatalogLimitLoadsMoreAtBottom(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "").WithSettings(config.Settings{CatalogLimit: 2})
+	m.registryClient = &fakePagedClient{repos: []string{"a", "b", "c"}}
+
+	updated, _ := m.Update(m.loadCatalogCmd()())
+	m = updated.(Model)
+	if len(m.images) != 2 || m.imagesNext != "b" {
+		t.Fatalf("expected the first 2 images with a cursor, got %d next=%q", len(m.images), m.imagesNext)
+	}
+
+	m.tableGotoBottom()
+	updated, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyDown})
+	m = updated.(Model)
+	if cmd == nil || !m.imagesLoadingMore {
+		t.Fatalf("expected moving past the last row to load more images")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if len(m.images) != 3 || m.imagesNext != "" || m.imagesLoadingMore {
+		t.Fatalf("expected all 3 images and no cursor, got %d next=%q", len(m.images), m.imagesNext)
+	}
+}
+
+type timeoutPagedClient struct {
+	registry.Client
+}
+
+func (c *timeoutPagedClient) ListImagesPage(context.Context, string, int) ([]registry.Image, string, error) {
+	return nil, "", context.DeadlineExceeded
+}
+
+func TestLoadMoreImagesDescribesTimeout(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	client := &timeoutPagedClient{}
+	m.registryClient = client
+	m.images = []registry.Image{{Name: "a"}}
+	m.imagesNext = "a"
+
+	cmd := m.loadMoreImages()
+	if cmd == nil {
+		t.Fatalf("expected a page load")
+	}
+	updated, _ := m.Update(cmd())
+	want := "Error loading more images: request timed out after 10s; the registry may be slow or unreachable"
+	if got := updated.(Model).status; got != want {
+		t.Fatalf("status = %q, want %q", got, want)
+	}
+}
+
+func TestCatalogFilterLoadsPagesUntilMatchesFill(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "").WithSettings(config.Settings{CatalogLimit: 2})
+	m.registryClient = &fakePagedClient{repos: []string{"a", "b", "c", "d", "team/zz"}}
+
+	updated, _ := m.Update(m.loadCatalogCmd()())
+	m = updated.(Model)
+	m.filterActive = true
+	m.filterInput.Focus()
+
+	updated, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("z")})
+	m = updated.(Model)
+	for i := 0; cmd != nil && i < 5; i++ {
+		var msg tea.Msg = cmd()
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			msg = nil
+			for _, c := range batch {
+				if c == nil {
+					continue
+				}
+				if page, ok := c().(imagesMsg); ok {
+					msg = page
+				}
+			}
+			if msg == nil {
+				break
+			}
+		}
+		updated, cmd = m.Update(msg)
+		m = updated.(Model)
+	}
+	if len(m.images) != 5 || m.imagesNext != "" {
+		t.Fatalf("expected the filter to load every batch, got %d images next=%q", len(m.images), m.imagesNext)
+	}
+	if rows := m.table.Rows(); len(rows) != 1 {
+		t.Fatalf("expected 1 matching row, got %d", len(rows))
+	}
+	if m.filterInput.Value() != "z" {
+		t.Fatalf("expected the filter to survive page loads, got %q", m.filterInput.Value())
+	}
+}
@@ -5,19 +5,49 @@ import (
 	"strings"
 
 	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/scottbass3/beacon/internal/registry"
 )
 
 var writeClipboard = clipboard.WriteAll
 var clipboardWriteAll = clipboard.WriteAll
 
 func (m *Model) copySelectedTagReference() bool {
+	if m.focus == FocusHistory {
+		return m.copyHistoryDigestReference()
+	}
 	ref, ok := m.selectedTagReferenceForCopy()
 	if !ok {
 		m.status = "No tag selected to copy"
 		return false
 	}
 	if err := writeClipboard(ref); err != nil {
-		m.status = fmt.Sprintf("Failed to copy %s: %v", ref, err)
+		m.setErrorStatus(fmt.Sprintf("Failed to copy %s: %v", ref, err))
+		return false
+	}
+	m.status = fmt.Sprintf("Copied %s", ref)
+	return true
+}
+
+// copyHistoryDigestReference copies image@digest for the tag whose history
+// is on screen, pinning exactly what was inspected.
+func (m *Model) copyHistoryDigestReference() bool {
+	image := m.selectedImage.Name
+	switch {
+	case m.dockerHubActive:
+		image = m.dockerHubImage
+	case m.githubActive:
+		image = m.githubImage
+	}
+	image = strings.TrimSpace(image)
+	if image == "" || m.historyDigest == "" {
+		m.status = "No digest resolved for this tag"
+		return false
+	}
+	ref := image + "@" + m.historyDigest
+	if err := writeClipboard(ref); err != nil {
+		m.setErrorStatus(fmt.Sprintf("Failed to copy %s: %v", ref, err))
 		return false
 	}
 	m.status = fmt.Sprintf("Copied %s", ref)
@@ -72,3 +102,122 @@ func formatTagReference(image, tag string) (string, bool) {
 	}
 	return image + ":" + tag, true
 }
+
+func runCopyCommand(m Model, args []string) (tea.Model, tea.Cmd) {
+	list := m.listView()
+	switch {
+	case len(args) == 0:
+		cursor := m.table.Cursor()
+		if cursor < 0 || cursor >= len(list.rows) {
+			m.status = "No row selected to copy"
+			return m, nil
+		}
+		m.copyText(strings.Join(list.rows[cursor], "\t"), "row")
+	case len(args) == 1 && strings.EqualFold(args[0], "host"):
+		m.copyRegistryHost()
+	case len(args) == 1 && strings.EqualFold(args[0], "url"):
+		m.copyLastRequestURL()
+	case len(args) == 1 && strings.EqualFold(args[0], "k8s"):
+		ref, ok := m.selectedTagKubernetesReference()
+		if !ok {
+			m.status = "No tag selected to copy"
+			return m, nil
+		}
+		m.copyText(ref, ref)
+	case len(args) == 1 && strings.EqualFold(args[0], "all"):
+		if len(list.rows) == 0 {
+			m.status = "No rows to copy"
+			return m, nil
+		}
+		lines := make([]string, 0, len(list.rows)+1)
+		lines = append(lines, strings.Join(list.headers, "\t"))
+		for _, row := range list.rows {
+			lines = append(lines, strings.Join(row, "\t"))
+		}
+		m.copyText(strings.Join(lines, "\n"), fmt.Sprintf("%d rows", len(list.rows)))
+	default:
+		m.status = "Usage: :copy [all|host|k8s|url]"
+	}
+	return m, nil
+}
+
+// runCopyTagsCommand copies the names of the listed tags, so the filter and
+// hidden artifacts apply and paged external lists give what is loaded.
+func runCopyTagsCommand(m Model, _ []string) (tea.Model, tea.Cmd) {
+	switch m.focus {
+	case FocusTags, FocusDockerHubTags, FocusGitHubTags:
+	default:
+		m.status = "Open an image's tags to copy them"
+		return m, nil
+	}
+	tags := m.currentTags()
+	list := m.listView()
+	names := make([]string, 0, len(list.indices))
+	for _, index := range list.indices {
+		if index >= 0 && index < len(tags) {
+			names = append(names, tags[index].Name)
+		}
+	}
+	if len(names) == 0 {
+		m.status = "No tags to copy"
+		return m, nil
+	}
+	m.copyText(strings.Join(names, "\n"), fmt.Sprintf("%d tags", len(names)))
+	return m, nil
+}
+
+// currentRegistryHostName is the host of the registry being browsed: the
+// active context's, or Docker Hub's / GHCR's in external modes.
+func (m Model) currentRegistryHostName() string {
+	switch {
+	case m.dockerHubActive:
+		return "hub.docker.com"
+	case m.githubActive:
+		return "ghcr.io"
+	default:
+		return registry.RegistryHostName(m.registryHost)
+	}
+}
+
+func (m *Model) copyRegistryHost() {
+	host := m.currentRegistryHostName()
+	if host == "" {
+		m.status = "No registry host to copy"
+		return
+	}
+	m.copyText(host, host)
+}
+
+// copyLastRequestURL copies the URL of the newest debug log entry, which
+// starts with "<METHOD> <URL>".
+func (m *Model) copyLastRequestURL() {
+	if !m.debug {
+		m.status = "Request log hidden; show it with :debug"
+		return
+	}
+	for i := len(m.logs) - 1; i >= 0; i-- {
+		fields := strings.Fields(m.logs[i])
+		if len(fields) >= 2 && strings.Contains(fields[1], "://") {
+			m.copyText(fields[1], "request URL")
+			return
+		}
+	}
+	m.status = "No request logged yet"
+}
+
+func (m *Model) copyText(text, what string) {
+	if err := writeClipboard(text); err != nil {
+		m.setErrorStatus(clipboardErrorStatus(what, err))
+		return
+	}
+	m.status = fmt.Sprintf("Copied %s", what)
+}
+
+// clipboardErrorStatus explains a failed copy, calling out headless sessions
+// where no clipboard tool is installed.
+func clipboardErrorStatus(what string, err error) string {
+	if clipboard.Unsupported {
+		return fmt.Sprintf("Clipboard unavailable, %s not copied (install xclip, xsel or wl-clipboard)", what)
+	}
+	return fmt.Sprintf("Failed to copy %s: %v", what, err)
+}
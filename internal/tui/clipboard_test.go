@@ -7,6 +7,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/scottbass3/beacon/internal/config"
 	"github.com/scottbass3/beacon/internal/registry"
 )
 
@@ -129,3 +130,328 @@ func TestCopySelectedTagReferenceWithoutSelection(t *testing.T) {
 		t.Fatalf("expected no selection status, got %q", next.status)
 	}
 }
+
+func TestCopySelectedPullCommand(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+
+	tests := []struct {
+		name     string
+		pullTool string
+		setup    func(*Model)
+		handle   func(Model) (tea.Model, tea.Cmd)
+		wantCopy string
+	}{
+		{
+			name: "registry tags include host",
+			setup: func(m *Model) {
+				m.focus = FocusTags
+				m.hasSelectedImage = true
+				m.selectedImage = registry.Image{Name: "team/service"}
+				m.tags = []registry.Tag{{Name: "v1.2.3"}}
+				m.syncTable()
+			},
+			handle: func(m Model) (tea.Model, tea.Cmd) {
+				return m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'P'}})
+			},
+			wantCopy: "docker pull registry.example.com/team/service:v1.2.3",
+		},
+		{
+			name:     "dockerhub official image with podman",
+			pullTool: "podman",
+			setup: func(m *Model) {
+				m.dockerHubActive = true
+				m.focus = FocusDockerHubTags
+				m.dockerHubImage = "library/nginx"
+				m.dockerHubTags = []registry.Tag{{Name: "alpine"}}
+				m.syncTable()
+			},
+			handle: func(m Model) (tea.Model, tea.Cmd) {
+				return m.handleDockerHubKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'P'}})
+			},
+			wantCopy: "podman pull nginx:alpine",
+		},
+		{
+			name: "github tags include ghcr.io",
+			setup: func(m *Model) {
+				m.githubActive = true
+				m.focus = FocusGitHubTags
+				m.githubImage = "org/service"
+				m.githubTags = []registry.Tag{{Name: "latest"}}
+				m.syncTable()
+			},
+			handle: func(m Model) (tea.Model, tea.Cmd) {
+				return m.handleGitHubKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'P'}})
+			},
+			wantCopy: "docker pull ghcr.io/org/service:latest",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "").
+				WithSettings(config.Settings{PullTool: tc.pullTool})
+			tc.setup(&m)
+
+			var copied string
+			writeClipboard = func(value string) error {
+				copied = value
+				return nil
+			}
+			t.Cleanup(func() {
+				writeClipboard = clipboardWriteAll
+			})
+
+			tc.handle(m)
+			if copied != tc.wantCopy {
+				t.Fatalf("expected copied value %q, got %q", tc.wantCopy, copied)
+			}
+		})
+	}
+}
+
+func TestCopyKubernetesReference(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+
+	tests := []struct {
+		name     string
+		setup    func(*Model)
+		wantCopy string
+	}{
+		{
+			name: "registry tags include host",
+			setup: func(m *Model) {
+				m.focus = FocusTags
+				m.hasSelectedImage = true
+				m.selectedImage = registry.Image{Name: "team/service"}
+				m.tags = []registry.Tag{{Name: "v1.2.3"}}
+			},
+			wantCopy: "registry.example.com/team/service:v1.2.3",
+		},
+		{
+			name: "dockerhub official image is fully qualified",
+			setup: func(m *Model) {
+				m.dockerHubActive = true
+				m.focus = FocusDockerHubTags
+				m.dockerHubImage = "library/nginx"
+				m.dockerHubTags = []registry.Tag{{Name: "alpine"}}
+			},
+			wantCopy: "docker.io/library/nginx:alpine",
+		},
+		{
+			name: "github tags include ghcr.io",
+			setup: func(m *Model) {
+				m.githubActive = true
+				m.focus = FocusGitHubTags
+				m.githubImage = "org/service"
+				m.githubTags = []registry.Tag{{Name: "latest"}}
+			},
+			wantCopy: "ghcr.io/org/service:latest",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+			tc.setup(&m)
+			m.syncTable()
+
+			var copied string
+			writeClipboard = func(value string) error {
+				copied = value
+				return nil
+			}
+			t.Cleanup(func() {
+				writeClipboard = clipboardWriteAll
+			})
+
+			runCopyCommand(m, []string{"k8s"})
+			if copied != tc.wantCopy {
+				t.Fatalf("expected copied value %q, got %q", tc.wantCopy, copied)
+			}
+		})
+	}
+}
+
+func TestCopyCommandRows(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.focus = FocusTags
+	m.hasSelectedImage = true
+	m.selectedImage = registry.Image{Name: "team/service"}
+	m.tags = []registry.Tag{{Name: "v1"}, {Name: "v2"}}
+	m.syncTable()
+
+	var copied string
+	writeClipboard = func(value string) error {
+		copied = value
+		return nil
+	}
+	t.Cleanup(func() {
+		writeClipboard = clipboardWriteAll
+	})
+
+	list := m.listView()
+	updated, _ := runCopyCommand(m, nil)
+	if want := strings.Join(list.rows[0], "\t"); copied != want {
+		t.Fatalf("expected selected row %q, got %q", want, copied)
+	}
+
+	updated, _ = runCopyCommand(updated.(Model), []string{"all"})
+	lines := strings.Split(copied, "\n")
+	if len(lines) != 3 || lines[0] != strings.Join(list.headers, "\t") {
+		t.Fatalf("expected header plus 2 rows, got %q", copied)
+	}
+	if status := updated.(Model).status; status != "Copied 2 rows" {
+		t.Fatalf("unexpected status %q", status)
+	}
+
+	writeClipboard = func(string) error {
+		return errors.New("no clipboard")
+	}
+	updated, _ = runCopyCommand(m, nil)
+	if status := updated.(Model).status; !strings.Contains(status, "not copied") && !strings.Contains(status, "Failed to copy") {
+		t.Fatalf("expected an informative clipboard error, got %q", status)
+	}
+}
+
+func TestCopyRegistryHost(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com:5000/", auth, nil, false, nil, nil, "", "")
+
+	var copied string
+	writeClipboard = func(value string) error {
+		copied = value
+		return nil
+	}
+	t.Cleanup(func() {
+		writeClipboard = clipboardWriteAll
+	})
+
+	tests := []struct {
+		name  string
+		setup func(*Model)
+		want  string
+	}{
+		{name: "registry", setup: func(*Model) {}, want: "registry.example.com:5000"},
+		{name: "docker hub", setup: func(m *Model) { m.dockerHubActive = true }, want: "hub.docker.com"},
+		{name: "github", setup: func(m *Model) { m.githubActive = true }, want: "ghcr.io"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := m
+			tt.setup(&next)
+			updated, _ := runCopyCommand(next, []string{"host"})
+			if copied != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, copied)
+			}
+			if status := updated.(Model).status; status != "Copied "+tt.want {
+				t.Fatalf("unexpected status %q", status)
+			}
+		})
+	}
+}
+
+func TestCopyHistoryDigestReference(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.focus = FocusTags
+	m.hasSelectedImage = true
+	m.selectedImage = registry.Image{Name: "team/service"}
+	m.hasSelectedTag = true
+	m.selectedTag = registry.Tag{Name: "v1"}
+
+	var copied string
+	writeClipboard = func(value string) error {
+		copied = value
+		return nil
+	}
+	t.Cleanup(func() {
+		writeClipboard = clipboardWriteAll
+	})
+
+	digest := "sha256:0123456789abcdef0123456789abcdef"
+	updated, _ := m.Update(historyMsg{history: []registry.HistoryEntry{{CreatedBy: "RUN true"}}, digest: digest})
+	m = updated.(Model)
+	if top := m.renderTopSection(); !strings.Contains(top, "sha256:0123456789ab") {
+		t.Fatalf("expected the short digest in the header, got %q", top)
+	}
+
+	if !m.copySelectedTagReference() {
+		t.Fatalf("expected the digest reference to be copied, status %q", m.status)
+	}
+	if want := "team/service@" + digest; copied != want {
+		t.Fatalf("expected %q, got %q", want, copied)
+	}
+}
+
+func TestCopyLastRequestURL(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, true, nil, nil, "", "")
+	m.appendLog("GET https://registry.example.com/v2/_catalog?n=1000 -> 200 (12ms)")
+	m.appendLog("HEAD https://registry.example.com/v2/team/app/manifests/v1?sig=abc -> 200 (8ms) | Accept: application/json")
+
+	var copied string
+	writeClipboard = func(value string) error {
+		copied = value
+		return nil
+	}
+	t.Cleanup(func() {
+		writeClipboard = clipboardWriteAll
+	})
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("L")})
+	if copied != "https://registry.example.com/v2/team/app/manifests/v1?sig=abc" {
+		t.Fatalf("expected the newest request URL, got %q", copied)
+	}
+	if status := updated.(Model).status; status != "Copied request URL" {
+		t.Fatalf("unexpected status %q", status)
+	}
+
+	m.debug = false
+	copied = ""
+	updated, _ = runCopyCommand(m, []string{"url"})
+	if copied != "" || !strings.Contains(updated.(Model).status, ":debug") {
+		t.Fatalf("expected no copy while the log is hidden, got %q / %q", copied, updated.(Model).status)
+	}
+}
+
+func TestCopyTagsCommandRespectsFilter(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.focus = FocusTags
+	m.hasSelectedImage = true
+	m.selectedImage = registry.Image{Name: "team/app"}
+	m.tags = []registry.Tag{{Name: "v1.0"}, {Name: "v1.1"}, {Name: "latest"}}
+	m.filterInput.SetValue("v1")
+	m.syncTable()
+
+	var copied string
+	writeClipboard = func(value string) error {
+		copied = value
+		return nil
+	}
+	t.Cleanup(func() {
+		writeClipboard = clipboardWriteAll
+	})
+
+	updated, _ := runCopyTagsCommand(m, nil)
+	if copied != "v1.0\nv1.1" {
+		t.Fatalf("expected filtered tag names, got %q", copied)
+	}
+	if status := updated.(Model).status; status != "Copied 2 tags" {
+		t.Fatalf("unexpected status %q", status)
+	}
+
+	m.focus = FocusImages
+	updated, _ = runCopyTagsCommand(m, nil)
+	if status := updated.(Model).status; status != "Open an image's tags to copy them" {
+		t.Fatalf("unexpected status %q", status)
+	}
+}
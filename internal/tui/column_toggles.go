@@ -0,0 +1,184 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+type optionalColumn struct {
+	key   string
+	title string
+	field func(spec *registry.TableSpec) *bool
+}
+
+var projectOptionalColumns = []optionalColumn{
+	{key: "project.artifacts", title: "Artifacts", field: func(s *registry.TableSpec) *bool { return &s.Project.ShowArtifactCount }},
+}
+
+var imageOptionalColumns = []optionalColumn{
+	{key: "image.tags", title: "Tags", field: func(s *registry.TableSpec) *bool { return &s.Image.ShowTagCount }},
+	{key: "image.pulls", title: "Pulls", field: func(s *registry.TableSpec) *bool { return &s.Image.ShowPulls }},
+	{key: "image.updated", title: "Updated", field: func(s *registry.TableSpec) *bool { return &s.Image.ShowUpdated }},
+}
+
+var tagOptionalColumns = []optionalColumn{
+	{key: "tag.size", title: "Size", field: func(s *registry.TableSpec) *bool { return &s.Tag.ShowSize }},
+	{key: "tag.pushed", title: "Pushed", field: func(s *registry.TableSpec) *bool { return &s.Tag.ShowPushed }},
+	{key: "tag.pushed_by", title: "Pushed By", field: func(s *registry.TableSpec) *bool { return &s.Tag.ShowPushedBy }},
+	{key: "tag.last_pull", title: "Last Pull", field: func(s *registry.TableSpec) *bool { return &s.Tag.ShowLastPulled }},
+	{key: "tag.platforms", title: "Platforms", field: func(s *registry.TableSpec) *bool { return &s.Tag.ShowPlatforms }},
+	{key: "tag.labels", title: "Labels", field: func(s *registry.TableSpec) *bool { return &s.Tag.ShowLabels }},
+	{key: "tag.digest", title: "Digest", field: func(s *registry.TableSpec) *bool { return &s.Tag.ShowDigest }},
+}
+
+var historyOptionalColumns = []optionalColumn{
+	{key: "history.size", title: "Size", field: func(s *registry.TableSpec) *bool { return &s.History.ShowSize }},
+	{key: "history.comment", title: "Comment", field: func(s *registry.TableSpec) *bool { return &s.History.ShowComment }},
+}
+
+func optionalColumnsForFocus(focus Focus) []optionalColumn {
+	switch focus {
+	case FocusProjects:
+		return projectOptionalColumns
+	case FocusImages:
+		return imageOptionalColumns
+	case FocusTags, FocusDockerHubTags, FocusGitHubTags:
+		return tagOptionalColumns
+	case FocusHistory:
+		return historyOptionalColumns
+	default:
+		return nil
+	}
+}
+
+// availableColumns lists the optional columns the current view can show at all;
+// hiding only ever removes columns the provider already supports.
+func (m Model) availableColumns() []optionalColumn {
+	spec := m.modeTableSpec()
+	out := []optionalColumn{}
+	for _, column := range optionalColumnsForFocus(m.focus) {
+		if *column.field(&spec) {
+			out = append(out, column)
+		}
+	}
+	return out
+}
+
+func (m Model) applyHiddenColumns(spec *registry.TableSpec) {
+	if len(m.hiddenColumns) == 0 {
+		return
+	}
+	for _, group := range [][]optionalColumn{projectOptionalColumns, imageOptionalColumns, tagOptionalColumns, historyOptionalColumns} {
+		for _, column := range group {
+			if m.hiddenColumns[column.key] {
+				*column.field(spec) = false
+			}
+		}
+	}
+}
+
+func runDigestsCommand(m Model, _ []string) (tea.Model, tea.Cmd) {
+	m.showDigest = !m.showDigest
+	delete(m.hiddenColumns, "tag.digest")
+	if m.showDigest {
+		m.status = "Showing tag digests"
+	} else {
+		m.status = "Tag digests hidden"
+	}
+	m.syncTable()
+	return m, nil
+}
+
+func (m Model) runColumnsCommand(args []string) (tea.Model, tea.Cmd) {
+	if len(args) == 1 && strings.EqualFold(args[0], "reset") {
+		m.hiddenColumns = nil
+		m.status = "All columns restored"
+		m.syncTable()
+		return m, nil
+	}
+	if len(args) > 0 {
+		m.status = "Usage: :columns [reset]"
+		return m, nil
+	}
+	if len(m.availableColumns()) == 0 {
+		m.status = "No optional columns in this view"
+		return m, nil
+	}
+	m.columnTogglesActive = true
+	m.columnTogglesIndex = 0
+	return m, nil
+}
+
+func (m *Model) toggleColumnAt(index int) {
+	columns := m.availableColumns()
+	if index < 0 || index >= len(columns) {
+		return
+	}
+	if m.hiddenColumns == nil {
+		m.hiddenColumns = map[string]bool{}
+	}
+	key := columns[index].key
+	if m.hiddenColumns[key] {
+		delete(m.hiddenColumns, key)
+	} else {
+		m.hiddenColumns[key] = true
+	}
+	m.syncTable()
+}
+
+func (m Model) handleColumnTogglesKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	columns := m.availableColumns()
+	switch msg.String() {
+	case "ctrl+c":
+		m.columnTogglesActive = false
+		return m.openQuitConfirm()
+	case "esc", "q":
+		m.columnTogglesActive = false
+		return m, nil
+	case "up", "k", "shift+tab":
+		m.columnTogglesIndex--
+		if m.columnTogglesIndex < 0 {
+			m.columnTogglesIndex = len(columns) - 1
+		}
+	case "down", "j", "tab":
+		if len(columns) > 0 {
+			m.columnTogglesIndex = (m.columnTogglesIndex + 1) % len(columns)
+		}
+	case " ", "enter", "x":
+		m.toggleColumnAt(m.columnTogglesIndex)
+	}
+	return m, nil
+}
+
+func (m Model) renderColumnTogglesModal() string {
+	lines := []string{
+		modalTitleStyle.Render("Columns"),
+		modalLabelStyle.Render("Hidden columns stay hidden until you restart or run :columns reset."),
+		modalDividerStyle.Render(strings.Repeat("─", 24)),
+	}
+	columns := m.availableColumns()
+	selected := clampInt(m.columnTogglesIndex, 0, len(columns)-1)
+	for i, column := range columns {
+		prefix := "  "
+		if i == selected {
+			prefix = "> "
+		}
+		mark := "[x] "
+		if m.hiddenColumns[column.key] {
+			mark = "[ ] "
+		}
+		style := modalOptionStyle
+		if i == selected {
+			style = modalOptionFocusStyle
+		}
+		lines = append(lines, style.Render(prefix+mark+column.title))
+	}
+	lines = append(lines,
+		"",
+		modalHelpStyle.Render("up/down move  space toggle  esc close"),
+	)
+	return m.renderModalCard(strings.Join(lines, "\n"), 64)
+}
@@ -41,8 +41,7 @@ func (m Model) handleCommandKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	m.commandInput, cmd = m.commandInput.Update(msg)
 	if m.commandInput.Value() != before {
-		m.commandIndex = 0
-		m.commandMatches = matchCommands(commandToken(m.commandInput.Value()))
+		m.refreshCommandMatches()
 	}
 	return m, cmd
 }
@@ -67,8 +66,7 @@ func (m Model) enterCommandMode() (tea.Model, tea.Cmd) {
 	m.commandInput.SetValue("")
 	cmd := m.commandInput.Focus()
 	m.commandInput.CursorEnd()
-	m.commandMatches = matchCommands("")
-	m.commandIndex = 0
+	m.refreshCommandMatches()
 	m.syncTable()
 	return m, cmd
 }
@@ -165,29 +163,39 @@ func (m Model) switchContextAt(index int) (tea.Model, tea.Cmd) {
 	m.contextSelectionError = ""
 
 	m.context = contextDisplayName(ctx, index)
+	m.rememberRecentContext(m.context)
 	m.registryHost = ctx.Host
+	m.defaultPath = ctx.DefaultPath
+	m.namespaces = ctx.Namespaces
+	m.pendingDefaultPath = ""
+	m.pendingTag = ""
 	m.auth = ctx.Auth
 	m.auth.Normalize()
 	registry.ApplyAuthCache(&m.auth, m.registryHost)
-	if m.auth.Kind == "registry_v2" && m.auth.RegistryV2.RefreshToken != "" {
+	if m.auth.UsesRegistryV2Auth() && m.auth.RegistryV2.RefreshToken != "" {
 		m.auth.RegistryV2.Remember = true
 	}
-	m.provider = registry.ProviderForAuth(m.auth)
+	m.provider = registry.ProviderForAuth(m.registryHost, m.auth)
 
 	m.registryClient = nil
+	m.conn = connUnknown
 	m.authRequired = m.provider.NeedsAuthPrompt(m.auth)
+	m.authRejected = false
 	m.authError = ""
 	m.authFocus = 0
 	m.usernameInput.SetValue("")
 	m.passwordInput.SetValue("")
 	m.remember = false
 	switch m.auth.Kind {
-	case "registry_v2":
+	case "registry_v2", "acr":
 		m.usernameInput.SetValue(m.auth.RegistryV2.Username)
 		m.remember = m.auth.RegistryV2.Remember
 	case "harbor":
 		m.usernameInput.SetValue(m.auth.Harbor.Username)
 	}
+	if m.usernameInput.Value() == "" {
+		m.usernameInput.SetValue(m.authUI().UsernamePrefix)
+	}
 
 	m.images = nil
 	m.projects = nil
@@ -197,6 +205,7 @@ func (m Model) switchContextAt(index int) (tea.Model, tea.Cmd) {
 	m.hasSelectedProject = false
 	m.selectedImage = registry.Image{}
 	m.hasSelectedImage = false
+	m.tagsQuery = ""
 	m.selectedTag = registry.Tag{}
 	m.hasSelectedTag = false
 	m.focus = m.defaultFocus()
@@ -210,6 +219,7 @@ func (m Model) switchContextAt(index int) (tea.Model, tea.Cmd) {
 	m.dockerHubNext = ""
 	m.dockerHubRateLimit = registry.DockerHubRateLimit{}
 	m.dockerHubRetryUntil = time.Time{}
+	m.dockerHubRetryPending = false
 	m.githubActive = false
 	m.githubInputFocus = false
 	m.githubInput.Blur()
@@ -217,6 +227,8 @@ func (m Model) switchContextAt(index int) (tea.Model, tea.Cmd) {
 	m.githubImage = ""
 	m.githubTags = nil
 	m.githubNext = ""
+	m.githubOwner = ""
+	m.githubPackages = nil
 	m.filterActive = false
 	m.filterInput.SetValue("")
 
@@ -1,8 +1,13 @@
 package tui
 
 import (
+	"strings"
 	"testing"
+	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/scottbass3/beacon/internal/config"
 	"github.com/scottbass3/beacon/internal/registry"
 )
 
@@ -26,6 +31,41 @@ func TestResolveCommandAlias(t *testing.T) {
 	}
 }
 
+func TestCommandPaletteFuzzyMatches(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	contexts := []ContextOption{
+		{Name: "prod", Host: "https://prod.example.com", Auth: auth},
+		{Name: "staging", Host: "https://staging.example.com", Auth: auth},
+	}
+	m := NewModel("https://prod.example.com", auth, nil, false, nil, contexts, "prod", "")
+
+	tests := []struct {
+		input string
+		first string
+	}{
+		{input: "prod", first: "context prod"},
+		{input: "stg", first: "context staging"},
+		{input: "dh ng", first: "dockerhub ng"},
+		{input: "dock", first: "dockerhub"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			m.commandInput.SetValue(tt.input)
+			m.refreshCommandMatches()
+			if len(m.commandMatches) == 0 || m.commandMatches[0] != tt.first {
+				t.Fatalf("expected %q ranked first, got %v", tt.first, m.commandMatches)
+			}
+		})
+	}
+
+	m.commandInput.SetValue("zzzz")
+	m.refreshCommandMatches()
+	if len(m.commandMatches) != 0 {
+		t.Fatalf("expected no matches, got %v", m.commandMatches)
+	}
+}
+
 func TestRunCommandHelpAndUnknown(t *testing.T) {
 	auth := registry.Auth{Kind: "registry_v2"}
 	auth.RegistryV2.Anonymous = true
@@ -45,3 +85,306 @@ func TestRunCommandHelpAndUnknown(t *testing.T) {
 		t.Fatalf("expected status message for unknown command")
 	}
 }
+
+func TestCommandPaletteCompletesImageNames(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.images = []registry.Image{{Name: "library/nginx"}, {Name: "team/api"}, {Name: "team/nginx-exporter"}}
+
+	m.commandInput.SetValue("img ngx")
+	m.refreshCommandMatches()
+	want := []string{"image library/nginx", "image team/nginx-exporter"}
+	if strings.Join(m.commandMatches, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected %v, got %v", want, m.commandMatches)
+	}
+
+	updated, _ := m.handleCommandKey(tea.KeyMsg{Type: tea.KeyDown})
+	m = updated.(Model)
+	updated, _ = m.handleCommandKey(tea.KeyMsg{Type: tea.KeyTab})
+	m = updated.(Model)
+	if got := m.commandInput.Value(); got != "image team/nginx-exporter" {
+		t.Fatalf("expected tab to fill the selected image, got %q", got)
+	}
+}
+
+func TestHelpFooterShowsVersion(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	if strings.Contains(m.renderHelpSectionBody(), "Beacon ") {
+		t.Fatalf("expected no version in the footer when none is set")
+	}
+	m = m.WithVersion("v1.4.0")
+	if !strings.Contains(m.renderHelpSectionBody(), "Beacon v1.4.0") {
+		t.Fatalf("expected the help footer to show the version")
+	}
+}
+
+func TestRunSizeCommand(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+
+	t.Run("sorts tags by size", func(t *testing.T) {
+		m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+		m.focus = FocusTags
+		m.tags = []registry.Tag{
+			{Name: "small", SizeBytes: 10},
+			{Name: "large", SizeBytes: 3000},
+			{Name: "medium", SizeBytes: 200},
+		}
+		m.commandInput.SetValue("size")
+		updated, _ := m.runCommand()
+		next := updated.(Model)
+		rows := next.listView().rows
+		if rows[0][0] != "large" || rows[2][0] != "small" {
+			t.Fatalf("expected tags sorted by size, got %v", rows)
+		}
+		if next.tags[0].Name != "small" {
+			t.Fatalf("expected the loaded tags to keep their order, got %v", next.tags)
+		}
+		if !strings.Contains(next.status, "total 3.1 KB") {
+			t.Fatalf("expected total size in status, got %q", next.status)
+		}
+	})
+
+	t.Run("reports missing size data", func(t *testing.T) {
+		m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+		m.focus = FocusTags
+		m.tags = []registry.Tag{{Name: "v1", SizeBytes: -1}, {Name: "v2", SizeBytes: -1}}
+		m.commandInput.SetValue("size")
+		updated, _ := m.runCommand()
+		next := updated.(Model)
+		if next.status != "Sort unavailable: no size data" {
+			t.Fatalf("unexpected status %q", next.status)
+		}
+		if next.tags[0].Name != "v1" {
+			t.Fatalf("expected tag order unchanged")
+		}
+	})
+}
+
+func TestRunReloadCommandResetsDrilldown(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.focus = FocusHistory
+	m.images = []registry.Image{{Name: "app"}}
+	m.tags = []registry.Tag{{Name: "v1"}}
+	m.selectedImage = registry.Image{Name: "app"}
+	m.hasSelectedImage = true
+	m.commandInput.SetValue("reload")
+
+	updated, _ := m.runCommand()
+	next := updated.(Model)
+	if next.focus != next.defaultFocus() {
+		t.Fatalf("expected focus to reset to %v, got %v", next.defaultFocus(), next.focus)
+	}
+	if next.hasSelectedImage || len(next.images) != 0 || len(next.tags) != 0 {
+		t.Fatalf("expected selection and loaded data to be cleared")
+	}
+}
+
+func TestColumnTogglesHideOptionalColumns(t *testing.T) {
+	auth := registry.Auth{Kind: "harbor"}
+	m := NewModel("https://harbor.example.com", auth, nil, false, nil, nil, "", "")
+	m.focus = FocusTags
+	if !m.effectiveTableSpec().Tag.ShowSize {
+		t.Fatalf("expected harbor tags to show size by default")
+	}
+
+	m.commandInput.SetValue("columns")
+	updated, _ := m.runCommand()
+	next := updated.(Model)
+	if !next.columnTogglesActive {
+		t.Fatalf("expected :columns to open the toggle list")
+	}
+
+	updated, _ = next.handleColumnTogglesKey(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	next = updated.(Model)
+	if next.effectiveTableSpec().Tag.ShowSize {
+		t.Fatalf("expected size column to be hidden after toggle")
+	}
+	if !next.modeTableSpec().Tag.ShowSize {
+		t.Fatalf("expected provider spec to be unchanged")
+	}
+
+	next.commandInput.SetValue("columns reset")
+	updated, _ = next.runCommand()
+	next = updated.(Model)
+	if !next.effectiveTableSpec().Tag.ShowSize {
+		t.Fatalf("expected :columns reset to restore the size column")
+	}
+}
+
+func TestRunHelpTopicCommand(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+
+	tests := []struct {
+		input       string
+		wantContain string
+		wantMissing string
+	}{
+		{input: "help filter", wantContain: "Topic: Filter", wantMissing: ":dockerhub"},
+		{input: "help ghcr", wantContain: ":github", wantMissing: ":context"},
+		{input: "help context", wantContain: ":context add", wantMissing: "Shortcuts"},
+		{input: "help nope", wantContain: `Unknown help topic "nope"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+			m.commandInput.SetValue(tt.input)
+			updated, _ := m.runCommand()
+			next := updated.(Model)
+			if !next.helpActive {
+				t.Fatalf("expected help to be open")
+			}
+			body := next.renderHelpSectionBody()
+			if !strings.Contains(body, tt.wantContain) {
+				t.Fatalf("expected help body to contain %q, got:\n%s", tt.wantContain, body)
+			}
+			if tt.wantMissing != "" && strings.Contains(body, tt.wantMissing) {
+				t.Fatalf("expected help body to omit %q, got:\n%s", tt.wantMissing, body)
+			}
+		})
+	}
+}
+
+func TestRunTimeCommandSwitchesTagTimestamps(t *testing.T) {
+	auth := registry.Auth{Kind: "harbor"}
+	m := NewModel("https://harbor.example.com", auth, nil, false, nil, nil, "", "")
+	m.focus = FocusTags
+	m.tags = []registry.Tag{{Name: "v1", PushedAt: time.Now().Add(-49 * time.Hour)}}
+	m.commandInput.SetValue("time relative")
+
+	updated, _ := m.runCommand()
+	next := updated.(Model)
+	if !next.relativeTime {
+		t.Fatalf("expected relative timestamps to be enabled")
+	}
+	list := next.listView()
+	found := false
+	for _, cell := range list.rows[0] {
+		if cell == "2d ago" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected a relative pushed time in %v", list.rows[0])
+	}
+}
+
+func TestRunCompactCommandReclaimsRows(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.width = 100
+	m.height = 40
+	before := m.tableHeight()
+	m.commandInput.SetValue("compact")
+
+	updated, _ := m.runCommand()
+	next := updated.(Model)
+	if !next.compact {
+		t.Fatalf("expected :compact to enable the compact layout")
+	}
+	// Top border (2), meta line (1) and main border (2).
+	if got := next.tableHeight(); got != before+5 {
+		t.Fatalf("expected compact layout to add 5 rows, got %d from %d", got, before)
+	}
+	if strings.Contains(next.renderTopSection(), "Context") {
+		t.Fatalf("expected compact layout to drop the meta line")
+	}
+	if got := lineCount(next.renderApp()); got > next.height {
+		t.Fatalf("expected compact layout to fit %d lines, got %d", next.height, got)
+	}
+}
+
+func TestRunDebugCommandTogglesRequestLog(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.height = 40
+	before := m.tableHeight()
+	m.commandInput.SetValue("debug")
+
+	updated, _ := m.runCommand()
+	next := updated.(Model)
+	if !next.debug {
+		t.Fatalf("expected :debug to show the request log")
+	}
+	if got := next.tableHeight(); got != before-maxVisibleLogs-4 {
+		t.Fatalf("expected table to shrink by the log panel, got %d from %d", got, before)
+	}
+	if !strings.Contains(next.renderApp(), "Requests") {
+		t.Fatalf("expected request log panel to render")
+	}
+
+	next.commandInput.SetValue("debug")
+	updated, _ = next.runCommand()
+	if updated.(Model).debug {
+		t.Fatalf("expected second :debug to hide the request log")
+	}
+}
+
+func TestLogEntriesResyncTableOnlyWithDebug(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.images = []registry.Image{{Name: "a"}}
+	m.syncTable()
+	m.images = append(m.images, registry.Image{Name: "b"})
+
+	updated, _ := m.Update(logMsg("GET /v2/_catalog 200"))
+	if rows := len(updated.(Model).table.Rows()); rows != 1 {
+		t.Fatalf("expected a log entry not to rebuild the table without :debug, got %d rows", rows)
+	}
+
+	m.debug = true
+	updated, _ = m.Update(logMsg("GET /v2/_catalog 200"))
+	if rows := len(updated.(Model).table.Rows()); rows != 2 {
+		t.Fatalf("expected the table resized with the log panel shown, got %d rows", rows)
+	}
+}
+
+func TestSizeSortSticksToTheView(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.focus = FocusTags
+	m.hasSelectedImage = true
+	m.selectedImage = registry.Image{Name: "team/app"}
+	m.tags = []registry.Tag{{Name: "small", SizeBytes: 10}, {Name: "large", SizeBytes: 3000}}
+	m.commandInput.SetValue("size")
+	updated, _ := m.runCommand()
+	m = updated.(Model)
+
+	m.selectedImage = registry.Image{Name: "team/web"}
+	updated, _ = m.Update(tagsMsg{image: "team/web", tags: []registry.Tag{{Name: "a", SizeBytes: 1}, {Name: "b", SizeBytes: 50}}})
+	next := updated.(Model)
+	if rows := next.listView().rows; rows[0][0] != "b" {
+		t.Fatalf("expected the next tag list sorted by size, got %v", rows)
+	}
+
+	next.commandInput.SetValue("sort off")
+	updated, cmd := next.runCommand()
+	next = updated.(Model)
+	if _, sorted := next.viewSorts[FocusTags]; sorted {
+		t.Fatalf("expected :sort off to forget the size sort")
+	}
+	if rows := next.listView().rows; rows[0][0] != "a" || cmd != nil {
+		t.Fatalf("expected the loaded order back without a reload, got %v", rows)
+	}
+}
+
+func TestSortSettingsSeedViews(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "").
+		WithSettings(config.Settings{Sort: &config.SortSettings{History: "size", Projects: "count"}})
+	if m.viewSorts[FocusHistory] != sortBySize || !m.projectsByCount {
+		t.Fatalf("expected history by size and projects by count, got %v / %v", m.viewSorts, m.projectsByCount)
+	}
+	if _, ok := m.viewSorts[FocusTags]; ok {
+		t.Fatalf("expected tags to keep load order")
+	}
+}
@@ -0,0 +1,146 @@
+package tui
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// commandCandidates lists what the command palette can suggest for query:
+// every command and alias, a switch to each context, and the canonical form
+// of the typed command when its name is an alias.
+func (m Model) commandCandidates(query string) []string {
+	var out []string
+	name, args := parseCommand(query)
+	if descriptor, ok := resolveCommand(name); ok && len(args) > 0 && descriptor.Name != name {
+		out = append(out, descriptor.Name+" "+strings.Join(args, " "))
+	}
+	out = append(out, commandSuggestions()...)
+	if query != "" {
+		for _, context := range contextNames(m.contexts) {
+			out = append(out, "context "+context)
+		}
+	}
+	return out
+}
+
+func (m *Model) refreshCommandMatches() {
+	m.commandIndex = 0
+	input := strings.TrimLeft(m.commandInput.Value(), " ")
+	if name, arg, ok := strings.Cut(input, " "); ok {
+		if descriptor, found := resolveCommand(name); found {
+			if values, completes := m.argumentCandidates(descriptor.Name); completes {
+				matches := rankCandidates(strings.TrimSpace(arg), values)
+				for i, value := range matches {
+					matches[i] = descriptor.Name + " " + value
+				}
+				m.commandMatches = matches
+				return
+			}
+		}
+	}
+	query := strings.TrimSpace(input)
+	m.commandMatches = rankCandidates(query, m.commandCandidates(query))
+}
+
+// argumentCandidates lists completions for the argument of commands that
+// take one, once the command name has been typed.
+func (m Model) argumentCandidates(command string) ([]string, bool) {
+	switch command {
+	case "image":
+		names := make([]string, 0, len(m.images))
+		for _, image := range m.images {
+			names = append(names, image.Name)
+		}
+		return names, true
+	default:
+		return nil, false
+	}
+}
+
+// rankCandidates keeps the candidates query fuzzy-matches, best first.
+func rankCandidates(query string, candidates []string) []string {
+	if query == "" {
+		return candidates
+	}
+	type scored struct {
+		value string
+		score int
+	}
+	matches := make([]scored, 0, len(candidates))
+	for _, candidate := range candidates {
+		if score, ok := fuzzyScore(query, candidate); ok {
+			matches = append(matches, scored{value: candidate, score: score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return len(matches[i].value) < len(matches[j].value)
+	})
+	out := make([]string, len(matches))
+	for i, match := range matches {
+		out[i] = match.value
+	}
+	return out
+}
+
+// fuzzyScore reports whether the runes of query appear in candidate in order,
+// scoring matches at the start, at word starts and in runs higher.
+func fuzzyScore(query, candidate string) (int, bool) {
+	q := []rune(strings.ToLower(query))
+	c := []rune(strings.ToLower(candidate))
+	score, qi, prev := 0, 0, -2
+	for ci := 0; ci < len(c) && qi < len(q); ci++ {
+		if c[ci] != q[qi] {
+			continue
+		}
+		score++
+		switch {
+		case ci == 0:
+			score += 8
+		case strings.ContainsRune(" -_/", c[ci-1]):
+			score += 4
+		}
+		if ci == prev+1 {
+			score += 2
+		}
+		prev = ci
+		qi++
+	}
+	return score, qi == len(q)
+}
+
+// renderCommandSuggestions lays the ranked matches out on one line, shifted
+// so the selected one stays visible.
+func (m Model) renderCommandSuggestions(width int) string {
+	if len(m.commandMatches) == 0 {
+		return shortcutHintStyle.Render("no matches")
+	}
+	const separator = "  "
+	index := clampInt(m.commandIndex, 0, len(m.commandMatches)-1)
+	start := 0
+	for start < index && lipgloss.Width(strings.Join(m.commandMatches[start:index+1], separator)) > width {
+		start++
+	}
+	var parts []string
+	used := 0
+	for i := start; i < len(m.commandMatches); i++ {
+		item := m.commandMatches[i]
+		if used > 0 && used+len(separator)+lipgloss.Width(item) > width {
+			break
+		}
+		if used > 0 {
+			used += len(separator)
+		}
+		used += lipgloss.Width(item)
+		if i == index {
+			parts = append(parts, suggestionActiveStyle.Render(item))
+		} else {
+			parts = append(parts, shortcutHintStyle.Render(item))
+		}
+	}
+	return strings.Join(parts, separator)
+}
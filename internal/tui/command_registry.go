@@ -20,6 +20,7 @@ func commandRegistry() []commandDescriptor {
 			Aliases: nil,
 			Help: []commandHelp{
 				{Command: "help", Usage: "Open the help page"},
+				{Command: "help <topic>", Usage: "Show help for one topic (filter, context, dockerhub, github, tags, history, ...)"},
 			},
 			Run: runHelpCommand,
 		},
@@ -31,6 +32,7 @@ func commandRegistry() []commandDescriptor {
 				{Command: "context add", Usage: "Create a new context"},
 				{Command: "context edit <name>", Usage: "Edit an existing context"},
 				{Command: "context remove <name>", Usage: "Remove a context"},
+				{Command: "context test <name>", Usage: "Check a context's registry and login without switching"},
 				{Command: "context <name>", Usage: "Switch to context by name"},
 			},
 			Run: runContextCommand,
@@ -55,6 +57,182 @@ func commandRegistry() []commandDescriptor {
 			},
 			Run: runGitHubCommand,
 		},
+		{
+			Name:    "columns",
+			Aliases: []string{"cols"},
+			Help: []commandHelp{
+				{Command: "columns", Usage: "Show or hide optional columns in the current view"},
+				{Command: "columns reset", Usage: "Show all columns again"},
+			},
+			Run: runColumnsCommand,
+		},
+		{
+			Name:    "reload",
+			Aliases: nil,
+			Help: []commandHelp{
+				{Command: "reload", Usage: "Reload the registry from the catalog root"},
+			},
+			Run: runReloadCommand,
+		},
+		{
+			Name:    "wrap",
+			Aliases: nil,
+			Help: []commandHelp{
+				{Command: "wrap", Usage: "Toggle wrap-around navigation at list edges"},
+			},
+			Run: runWrapCommand,
+		},
+		{
+			Name:    "stickyfilter",
+			Aliases: []string{"sticky"},
+			Help: []commandHelp{
+				{Command: "stickyfilter", Usage: "Toggle keeping the filter when moving between lists"},
+			},
+			Run: runStickyFilterCommand,
+		},
+		{
+			Name:    "watch",
+			Aliases: nil,
+			Help: []commandHelp{
+				{Command: "watch", Usage: "Toggle auto-refresh of the current view"},
+				{Command: "watch <seconds>", Usage: "Refresh the current view every N seconds"},
+				{Command: "watch off", Usage: "Stop auto-refresh"},
+			},
+			Run: runWatchCommand,
+		},
+		{
+			Name:    "debug",
+			Aliases: nil,
+			Help: []commandHelp{
+				{Command: "debug", Usage: "Show or hide the request log"},
+			},
+			Run: runDebugCommand,
+		},
+		{
+			Name:    "compact",
+			Aliases: nil,
+			Help: []commandHelp{
+				{Command: "compact", Usage: "Toggle the compact layout (no meta line or section borders)"},
+			},
+			Run: runCompactCommand,
+		},
+		{
+			Name:    "export",
+			Aliases: nil,
+			Help: []commandHelp{
+				{Command: "export <file.csv>", Usage: "Write the current (filtered) view to a CSV file"},
+			},
+			Run: runExportCommand,
+		},
+		{
+			Name:    "copy",
+			Aliases: nil,
+			Help: []commandHelp{
+				{Command: "copy", Usage: "Copy the selected row (tab-separated) to the clipboard"},
+				{Command: "copy all", Usage: "Copy the header and every visible row"},
+				{Command: "copy host", Usage: "Copy the registry host (hub.docker.com / ghcr.io in external modes)"},
+				{Command: "copy k8s", Usage: "Copy the selected tag as a fully qualified image reference for Kubernetes manifests"},
+				{Command: "copy url", Usage: "Copy the last request URL from the debug log"},
+			},
+			Run: runCopyCommand,
+		},
+		{
+			Name:    "copytags",
+			Aliases: nil,
+			Help: []commandHelp{
+				{Command: "copytags", Usage: "Copy the loaded tag names (filter applied), one per line"},
+			},
+			Run: runCopyTagsCommand,
+		},
+		{
+			Name:    "retag",
+			Aliases: nil,
+			Help: []commandHelp{
+				{Command: "retag <old> <new>", Usage: "Rename a tag of the open image (copies the manifest, then deletes the old tag)"},
+			},
+			Run: runRetagCommand,
+		},
+		{
+			Name:    "delete",
+			Aliases: nil,
+			Help: []commandHelp{
+				{Command: "delete", Usage: "Delete the tags selected with space, or the tag under the cursor"},
+			},
+			Run: runDeleteCommand,
+		},
+		{
+			Name:    "findtag",
+			Aliases: nil,
+			Help: []commandHelp{
+				{Command: "findtag <tag>", Usage: "Find repositories in this registry that have a tag"},
+				{Command: "findtag --all <tag>", Usage: "Search every context that needs no login prompt"},
+				{Command: "findtag", Usage: "Reopen the last search results"},
+			},
+			Run: runFindTagCommand,
+		},
+		{
+			Name:    "image",
+			Aliases: []string{"img", "tags"},
+			Help: []commandHelp{
+				{Command: "image <repo>", Usage: "Open the tags of a repository on the active registry, even when it is not in the catalog"},
+				{Command: "tags <repo>", Usage: "Alias for image <repo>"},
+				{Command: "image", Usage: "Type a repository name to open"},
+			},
+			Run: runImageCommand,
+		},
+		{
+			Name:    "time",
+			Aliases: nil,
+			Help: []commandHelp{
+				{Command: "time", Usage: "Toggle absolute/relative timestamps"},
+				{Command: "time absolute|relative", Usage: "Choose how timestamps are shown"},
+			},
+			Run: runTimeCommand,
+		},
+		{
+			Name:    "size",
+			Aliases: nil,
+			Help: []commandHelp{
+				{Command: "size", Usage: "Sort current tags/history by size and show the total"},
+			},
+			Run: runSizeCommand,
+		},
+		{
+			Name:    "digests",
+			Aliases: nil,
+			Help: []commandHelp{
+				{Command: "digests", Usage: "Show or hide a short Digest column in tag views"},
+			},
+			Run: runDigestsCommand,
+		},
+		{
+			Name:    "historylimit",
+			Aliases: nil,
+			Help: []commandHelp{
+				{Command: "historylimit <entries>", Usage: "Show only the most recent history entries, with a +N row for the rest"},
+				{Command: "historylimit off", Usage: "Show every history entry"},
+			},
+			Run: runHistoryLimitCommand,
+		},
+		{
+			Name:    "minsize",
+			Aliases: nil,
+			Help: []commandHelp{
+				{Command: "minsize <size>", Usage: "Hide tags/history entries smaller than a size like 500MB"},
+				{Command: "minsize off", Usage: "Show entries of any size again"},
+			},
+			Run: runMinSizeCommand,
+		},
+		{
+			Name:    "sort",
+			Aliases: nil,
+			Help: []commandHelp{
+				{Command: "sort", Usage: "Toggle sorting projects by image count or name"},
+				{Command: "sort name|count", Usage: "Choose how projects are sorted"},
+				{Command: "sort size|off", Usage: "On tags/history, sort by size or go back to load order; kept for later lists"},
+			},
+			Run: runSortCommand,
+		},
 	}
 }
 
@@ -95,23 +273,57 @@ func commandSuggestions() []string {
 	return out
 }
 
-func matchCommands(prefix string) []string {
-	candidates := commandSuggestions()
-	if prefix == "" {
-		return candidates
+func runColumnsCommand(m Model, args []string) (tea.Model, tea.Cmd) {
+	return m.runColumnsCommand(args)
+}
+
+func runExportCommand(m Model, args []string) (tea.Model, tea.Cmd) {
+	return m.runExportCommand(args)
+}
+
+func runReloadCommand(m Model, _ []string) (tea.Model, tea.Cmd) {
+	return m, m.reloadAll()
+}
+
+func runWrapCommand(m Model, _ []string) (tea.Model, tea.Cmd) {
+	m.toggleWrapNavigation()
+	return m, nil
+}
+
+func runDebugCommand(m Model, _ []string) (tea.Model, tea.Cmd) {
+	m.debug = !m.debug
+	if m.debug {
+		m.status = "Showing request log"
+	} else {
+		m.status = "Request log hidden"
 	}
-	prefix = strings.ToLower(prefix)
-	out := make([]string, 0, len(candidates))
-	for _, candidate := range candidates {
-		if strings.HasPrefix(candidate, prefix) {
-			out = append(out, candidate)
-		}
+	m.syncTable()
+	return m, nil
+}
+
+func runTimeCommand(m Model, args []string) (tea.Model, tea.Cmd) {
+	switch {
+	case len(args) == 0:
+		m.relativeTime = !m.relativeTime
+	case len(args) == 1 && strings.EqualFold(args[0], "relative"):
+		m.relativeTime = true
+	case len(args) == 1 && strings.EqualFold(args[0], "absolute"):
+		m.relativeTime = false
+	default:
+		m.status = "Usage: :time [absolute|relative]"
+		return m, nil
 	}
-	return out
+	if m.relativeTime {
+		m.status = "Showing relative timestamps"
+	} else {
+		m.status = "Showing absolute timestamps"
+	}
+	m.syncTable()
+	return m, nil
 }
 
-func runHelpCommand(m Model, _ []string) (tea.Model, tea.Cmd) {
-	return m.openHelp()
+func runHelpCommand(m Model, args []string) (tea.Model, tea.Cmd) {
+	return m.openHelpTopic(strings.Join(args, " "))
 }
 
 func runContextCommand(m Model, args []string) (tea.Model, tea.Cmd) {
@@ -0,0 +1,54 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// WithCompact starts in the compact layout, which drops the meta line and the
+// section borders so more table rows fit.
+func (m Model) WithCompact(compact bool) Model {
+	m.compact = compact
+	return m
+}
+
+func runCompactCommand(m Model, _ []string) (tea.Model, tea.Cmd) {
+	m.compact = !m.compact
+	if m.compact {
+		m.status = "Compact layout on"
+	} else {
+		m.status = "Compact layout off"
+	}
+	m.syncTable()
+	return m, nil
+}
+
+func (m Model) topSectionStyle() lipgloss.Style {
+	if m.compact {
+		return compactSectionStyle
+	}
+	return topSectionStyle
+}
+
+func (m Model) mainSectionStyle() lipgloss.Style {
+	if m.compact {
+		return compactSectionStyle
+	}
+	return mainSectionStyle
+}
+
+// mainSectionBorderLines and mainSectionHChrome are the rows and columns the
+// main section's border and padding take up.
+func (m Model) mainSectionBorderLines() int {
+	if m.compact {
+		return 0
+	}
+	return mainSectionBorderLines
+}
+
+func (m Model) mainSectionHChrome() int {
+	if m.compact {
+		return 0
+	}
+	return mainSectionHChromeChars
+}
@@ -0,0 +1,32 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/scottbass3/beacon/internal/contextstore"
+)
+
+// WithConfigProblem opens the repair prompt for a config file that could not
+// be parsed at startup. Declining quits so the file can be fixed by hand.
+func (m Model) WithConfigProblem(err error) Model {
+	m.confirmAction = confirmActionRepairConfig
+	m.confirmTitle = "Config file can't be read"
+	m.confirmMessage = fmt.Sprintf("%v\n\nBack it up as %s.bak and start with an empty config, or quit to fix it by hand?", err, filepath.Base(m.configPath))
+	m.confirmFocus = 0
+	// Dismissing the welcome screen would write to the unreadable file.
+	m.welcomeActive = false
+	return m
+}
+
+func (m Model) repairConfig() (tea.Model, tea.Cmd) {
+	backup, err := contextstore.New(m.configPath).Reset()
+	if err != nil {
+		m.setErrorStatus(fmt.Sprintf("Error repairing config: %v", err))
+		return m, nil
+	}
+	m.status = fmt.Sprintf("Moved the unreadable config to %s; starting with an empty one", backup)
+	return m, nil
+}
@@ -0,0 +1,102 @@
+package tui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/scottbass3/beacon/internal/contextstore"
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+func TestConfigRepairPrompt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o600); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	newModel := func() Model {
+		return NewModel("", registry.Auth{}, nil, false, nil, nil, "", path).
+			WithConfigProblem(errors.New("invalid config JSON"))
+	}
+
+	updated, cmd := newModel().handleConfirmKey(tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd == nil {
+		t.Fatalf("expected declining to quit")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok || updated.(Model).isConfirmModalActive() {
+		t.Fatalf("expected declining to close the prompt and quit")
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected the broken config left in place: %v", err)
+	}
+
+	updated, _ = newModel().handleConfirmKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if _, err := os.Stat(path + ".bak"); err != nil {
+		t.Fatalf("expected a backup of the broken config: %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) == "{not json" {
+		t.Fatalf("expected a fresh config in place of the broken one")
+	}
+	if m := updated.(Model); m.isConfirmModalActive() || !m.isContextFormActive() {
+		t.Fatalf("expected to continue to the context form after repairing")
+	}
+}
+
+func TestWelcomeShownOnceOnFirstRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	store := contextstore.New(path)
+	m := NewModel("", registry.Auth{}, nil, false, nil, nil, "", path).WithSettings(store.Settings())
+	if !m.welcomeActive {
+		t.Fatalf("expected the welcome screen on a first run")
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	next := updated.(Model)
+	if next.welcomeActive || !next.isContextFormActive() {
+		t.Fatalf("expected any key to dismiss welcome into the context form")
+	}
+	if !store.Settings().SeenWelcome {
+		t.Fatalf("expected seen_welcome to be saved")
+	}
+
+	again := NewModel("", registry.Auth{}, nil, false, nil, nil, "", path).WithSettings(store.Settings())
+	if again.welcomeActive {
+		t.Fatalf("expected no welcome screen once it was seen")
+	}
+}
+
+func TestConfigProblemHidesWelcome(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o600); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	m := NewModel("", registry.Auth{}, nil, false, nil, nil, "", path).
+		WithSettings(contextstore.New(path).Settings()).
+		WithConfigProblem(errors.New("invalid config JSON"))
+	if m.welcomeActive {
+		t.Fatalf("expected no welcome screen over the repair prompt")
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	next := updated.(Model)
+	if next.statusIsError() {
+		t.Fatalf("expected the first key to answer the repair prompt, got %q", next.status)
+	}
+	if _, err := os.Stat(path + ".bak"); err != nil {
+		t.Fatalf("expected the first key to repair the config: %v", err)
+	}
+}
+
+func TestWelcomeKeysFollowShortcuts(t *testing.T) {
+	m := NewModel("", registry.Auth{}, nil, false, nil, nil, "", "")
+	view := m.renderWelcomeModal()
+	for _, action := range []shortcutAction{shortcutOpenHelp, shortcutQuit, shortcutMoveDown} {
+		if keys := shortcutDefinitions[action].HelpKeys; !strings.Contains(view, keys) {
+			t.Fatalf("expected %q in the welcome screen:\n%s", keys, view)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"context"
+	"errors"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type connHealth int
+
+const (
+	connUnknown connHealth = iota
+	connHealthy
+	connFailing
+)
+
+// recordConnHealth tracks whether the last response from the connected
+// registry was an error. Docker Hub and GHCR lookups are left out.
+func (m *Model) recordConnHealth(msg tea.Msg) {
+	var err error
+	switch msg := msg.(type) {
+	case initClientMsg:
+		err = msg.err
+	case imagesMsg:
+		err = msg.err
+	case projectsMsg:
+		err = msg.err
+	case projectImagesMsg:
+		err = msg.err
+	case tagsMsg:
+		err = msg.err
+	case historyMsg:
+		if m.dockerHubActive || m.githubActive {
+			return
+		}
+		err = msg.err
+	default:
+		return
+	}
+	switch {
+	case err == nil:
+		m.conn = connHealthy
+	case errors.Is(err, context.Canceled):
+	default:
+		m.conn = connFailing
+	}
+}
+
+func (m Model) renderConnDot() string {
+	switch m.conn {
+	case connHealthy:
+		return connHealthyStyle.Render("●")
+	case connFailing:
+		return connFailingStyle.Render("●")
+	default:
+		return ""
+	}
+}
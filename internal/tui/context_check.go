@@ -0,0 +1,94 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+const contextTestTimeout = 10 * time.Second
+
+// testContextCmd asks for one catalog entry, or one project on registries
+// that list projects, with the context's own credentials. A catalog the
+// registry refuses or disables still proves the host answered and accepted
+// the login, so only auth and transport errors fail the test.
+func testContextCmd(name string, client registry.Client) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), contextTestTimeout)
+		defer cancel()
+		start := time.Now()
+		err := probeContext(ctx, client)
+		msg := contextTestMsg{name: name, latency: time.Since(start)}
+		// A 401 on the catalog also matches ErrCatalogForbidden.
+		if err != nil && !errors.Is(err, registry.ErrUnauthorized) &&
+			(errors.Is(err, registry.ErrCatalogForbidden) || errors.Is(err, registry.ErrCatalogDisabled)) {
+			msg.noCatalog = true
+			err = nil
+		}
+		msg.err = err
+		return msg
+	}
+}
+
+// probeContext sends the single request the latency is measured on,
+// falling back to the full listing for clients that can't page.
+func probeContext(ctx context.Context, client registry.Client) error {
+	switch c := client.(type) {
+	case registry.ProjectPager:
+		_, err := c.ListProjectsPage(ctx, 1, 1)
+		return err
+	case registry.ImagePager:
+		_, _, err := c.ListImagesPage(ctx, "", 1)
+		return err
+	default:
+		_, err := client.ListImages(ctx)
+		return err
+	}
+}
+
+func (m Model) testContext(name string) (tea.Model, tea.Cmd) {
+	index, ok := m.resolveContextIndex(name)
+	if !ok {
+		m.status = fmt.Sprintf("Unknown context: %s", name)
+		return m, nil
+	}
+	option := m.contexts[index]
+	name = contextDisplayName(option, index)
+	if strings.TrimSpace(option.Host) == "" {
+		m.setErrorStatus(fmt.Sprintf("Context %s has no registry configured", name))
+		return m, nil
+	}
+	auth := option.Auth
+	auth.Normalize()
+	registry.ApplyAuthCache(&auth, option.Host)
+	if registry.ProviderForAuth(option.Host, auth).NeedsAuthPrompt(auth) {
+		m.setErrorStatus(fmt.Sprintf("Context %s needs login; switch to it to sign in", name))
+		return m, nil
+	}
+	client, err := registry.NewClientWithLogger(option.Host, auth, m.logger)
+	if err != nil {
+		m.setErrorStatus(fmt.Sprintf("Context %s: %v", name, err))
+		return m, nil
+	}
+	m.status = fmt.Sprintf("Testing context %s...", name)
+	return m, testContextCmd(name, client)
+}
+
+func (m Model) updateContextTestMsg(msg contextTestMsg) (tea.Model, tea.Cmd) {
+	latency := msg.latency.Round(time.Millisecond)
+	switch {
+	case msg.err != nil:
+		m.setErrorStatus(fmt.Sprintf("Context %s failed after %s: %v", msg.name, latency, msg.err))
+	case msg.noCatalog:
+		m.status = fmt.Sprintf("Context %s OK (%s, catalog unavailable)", msg.name, latency)
+	default:
+		m.status = fmt.Sprintf("Context %s OK (%s)", msg.name, latency)
+	}
+	return m, nil
+}
@@ -23,6 +23,7 @@ func (m Model) openContextFormAdd(returnSelection, allowSkip bool) (tea.Model, t
 	m.contextFormRegistryInput.SetValue("")
 	m.contextFormKindInput.SetValue("registry_v2")
 	m.contextFormServiceInput.SetValue("")
+	m.contextFormTokenInput.SetValue("")
 	if returnSelection {
 		m.contextSelectionActive = false
 		m.contextSelectionRequired = false
@@ -53,6 +54,7 @@ func (m Model) openContextFormEdit(index int, returnSelection bool) (tea.Model,
 	}
 	anonymous := true
 	service := ""
+	token := ""
 	switch kind {
 	case "harbor":
 		anonymous = ctx.Auth.Harbor.Anonymous
@@ -60,6 +62,7 @@ func (m Model) openContextFormEdit(index int, returnSelection bool) (tea.Model,
 	default:
 		anonymous = ctx.Auth.RegistryV2.Anonymous
 		service = ctx.Auth.RegistryV2.Service
+		token = ctx.Auth.RegistryV2.Token
 	}
 
 	m.contextFormActive = true
@@ -74,6 +77,7 @@ func (m Model) openContextFormEdit(index int, returnSelection bool) (tea.Model,
 	m.contextFormRegistryInput.SetValue(strings.TrimSpace(ctx.Host))
 	m.contextFormKindInput.SetValue(kind)
 	m.contextFormServiceInput.SetValue(strings.TrimSpace(service))
+	m.contextFormTokenInput.SetValue(strings.TrimSpace(token))
 	if returnSelection {
 		m.contextSelectionActive = false
 		m.contextSelectionRequired = false
@@ -88,6 +92,7 @@ func (m *Model) syncContextFormFocus() tea.Cmd {
 	m.contextFormRegistryInput.Blur()
 	m.contextFormKindInput.Blur()
 	m.contextFormServiceInput.Blur()
+	m.contextFormTokenInput.Blur()
 
 	switch m.contextFormFocus {
 	case contextFormFocusName:
@@ -98,6 +103,8 @@ func (m *Model) syncContextFormFocus() tea.Cmd {
 		return m.contextFormKindInput.Focus()
 	case contextFormFocusService:
 		return m.contextFormServiceInput.Focus()
+	case contextFormFocusToken:
+		return m.contextFormTokenInput.Focus()
 	default:
 		return nil
 	}
@@ -130,6 +137,7 @@ func (m *Model) deactivateContextForm() {
 	m.contextFormRegistryInput.Blur()
 	m.contextFormKindInput.Blur()
 	m.contextFormServiceInput.Blur()
+	m.contextFormTokenInput.Blur()
 }
 
 func (m Model) submitContextForm() (tea.Model, tea.Cmd) {
@@ -137,6 +145,7 @@ func (m Model) submitContextForm() (tea.Model, tea.Cmd) {
 	registryHost := strings.TrimSpace(m.contextFormRegistryInput.Value())
 	kindInput := strings.TrimSpace(m.contextFormKindInput.Value())
 	service := strings.TrimSpace(m.contextFormServiceInput.Value())
+	token := strings.TrimSpace(m.contextFormTokenInput.Value())
 
 	if name == "" {
 		m.contextFormError = "Context name is required"
@@ -146,50 +155,81 @@ func (m Model) submitContextForm() (tea.Model, tea.Cmd) {
 		m.contextFormError = "Registry is required"
 		return m, nil
 	}
+	registryHost, err := contextstore.NormalizeRegistryURL(registryHost)
+	if err != nil {
+		m.contextFormError = "Invalid registry: " + err.Error()
+		return m, nil
+	}
 	kind, ok := contextstore.NormalizeKindInput(kindInput)
 	if !ok {
-		m.contextFormError = "Kind must be registry_v2 or harbor"
+		m.contextFormError = "Kind must be registry_v2, harbor, acr or gcr"
+		return m, nil
+	}
+	if token != "" && kind != "registry_v2" && kind != "acr" {
+		m.contextFormError = "Token is only supported for registry_v2 and acr"
 		return m, nil
 	}
 
 	auth := registry.Auth{Kind: kind}
+	defaultPath := ""
+	var namespaces []string
+	if m.contextFormMode == contextFormModeEdit && m.contextFormIndex >= 0 && m.contextFormIndex < len(m.contexts) {
+		auth.Proxy = m.contexts[m.contextFormIndex].Auth.Proxy
+		auth.ManifestAccept = m.contexts[m.contextFormIndex].Auth.ManifestAccept
+		auth.Mirrors = m.contexts[m.contextFormIndex].Auth.Mirrors
+		defaultPath = m.contexts[m.contextFormIndex].DefaultPath
+		namespaces = m.contexts[m.contextFormIndex].Namespaces
+	}
 	switch kind {
 	case "harbor":
 		auth.Harbor.Anonymous = m.contextFormAnonymous
 		auth.Harbor.Service = service
+		if m.contextFormMode == contextFormModeEdit && m.contextFormIndex >= 0 && m.contextFormIndex < len(m.contexts) {
+			auth.Harbor.Robot = m.contexts[m.contextFormIndex].Auth.Harbor.Robot
+		}
 	default:
 		auth.RegistryV2.Anonymous = m.contextFormAnonymous
 		auth.RegistryV2.Service = service
+		auth.RegistryV2.Token = token
 	}
 	auth.Normalize()
 
 	candidate := contextstore.Context{
-		Name: name,
-		Host: registryHost,
-		Auth: auth,
+		Name:        name,
+		Host:        registryHost,
+		Auth:        auth,
+		DefaultPath: defaultPath,
+		Namespaces:  namespaces,
 	}
 
 	serviceManager := contextstore.NewService(m.configPath)
 	existing := contextOptionsToStoredContexts(m.contexts)
 	var (
-		updatedStored []contextstore.Context
-		targetIndex   int
-		err           error
+		targetIndex int
+		merged      bool
 	)
-	if m.contextFormMode == contextFormModeEdit {
+	updatedStored, err := serviceManager.Update(func(current []contextstore.Context) ([]contextstore.Context, error) {
+		merged = contextstore.Changed(current, existing)
+		if m.contextFormMode != contextFormModeEdit {
+			updated, index, err := serviceManager.Add(current, candidate)
+			targetIndex = index
+			return updated, err
+		}
 		targetIndex = m.contextFormIndex
-		updatedStored, err = serviceManager.Edit(existing, targetIndex, candidate)
-	} else {
-		updatedStored, targetIndex, err = serviceManager.Add(existing, candidate)
-	}
+		if merged {
+			// Another instance changed the file; find the edited context by name.
+			index, ok := contextstore.ResolveByName(current, existing[m.contextFormIndex].Name)
+			if !ok {
+				return nil, fmt.Errorf("context %s was removed by another Beacon instance", existing[m.contextFormIndex].Name)
+			}
+			targetIndex = index
+		}
+		return serviceManager.Edit(current, targetIndex, candidate)
+	})
 	if err != nil {
 		m.contextFormError = err.Error()
 		return m, nil
 	}
-	if err := serviceManager.Save(updatedStored); err != nil {
-		m.contextFormError = fmt.Sprintf("failed to save contexts: %v", err)
-		return m, nil
-	}
 	updated := storedContextsToContextOptions(updatedStored)
 
 	oldCount := len(m.contexts)
@@ -210,22 +250,28 @@ func (m Model) submitContextForm() (tea.Model, tea.Cmd) {
 
 	switch mode {
 	case contextFormModeAdd:
-		m.status = fmt.Sprintf("Added context %s", name)
+		m.status = fmt.Sprintf("Added context %s", name) + mergedContextsNote(merged)
 		if oldCount == 0 || strings.TrimSpace(m.registryHost) == "" {
 			m.contextSelectionActive = false
 			m.contextSelectionRequired = false
 			return m.switchContextAt(targetIndex)
 		}
 		m.syncTable()
+		if m.contextSelectionActive {
+			return m, m.probeContextsCmd()
+		}
 		return m, nil
 	case contextFormModeEdit:
-		m.status = fmt.Sprintf("Updated context %s", name)
+		m.status = fmt.Sprintf("Updated context %s", name) + mergedContextsNote(merged)
 		if activeIndex == targetIndex {
 			m.contextSelectionActive = false
 			m.contextSelectionRequired = false
 			return m.switchContextAt(targetIndex)
 		}
 		m.syncTable()
+		if m.contextSelectionActive {
+			return m, m.probeContextsCmd()
+		}
 		return m, nil
 	default:
 		m.syncTable()
@@ -70,6 +70,8 @@ func (m Model) handleContextFormKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.contextFormKindInput, cmd = m.contextFormKindInput.Update(msg)
 	case contextFormFocusService:
 		m.contextFormServiceInput, cmd = m.contextFormServiceInput.Update(msg)
+	case contextFormFocusToken:
+		m.contextFormTokenInput, cmd = m.contextFormTokenInput.Update(msg)
 	}
 	return m, cmd
 }
@@ -90,6 +92,8 @@ func (m Model) nextContextFormFocus(current int) int {
 	case contextFormFocusKind:
 		return contextFormFocusService
 	case contextFormFocusService:
+		return contextFormFocusToken
+	case contextFormFocusToken:
 		return contextFormFocusAnonymous
 	case contextFormFocusAnonymous:
 		return contextFormFocusPrimaryButton
@@ -119,8 +123,10 @@ func (m Model) prevContextFormFocus(current int) int {
 		return contextFormFocusRegistry
 	case contextFormFocusService:
 		return contextFormFocusKind
-	case contextFormFocusAnonymous:
+	case contextFormFocusToken:
 		return contextFormFocusService
+	case contextFormFocusAnonymous:
+		return contextFormFocusToken
 	case contextFormFocusPrimaryButton:
 		return contextFormFocusAnonymous
 	case contextFormFocusSecondaryButton:
@@ -20,6 +20,7 @@ func (m Model) renderContextFormModal() string {
 	registryHost := m.contextFormRegistryInput.View()
 	kind := m.contextFormKindInput.View()
 	service := m.contextFormServiceInput.View()
+	token := m.contextFormTokenInput.View()
 
 	if m.contextFormFocus == contextFormFocusName {
 		name = modalInputFocusStyle.Render(name)
@@ -41,6 +42,11 @@ func (m Model) renderContextFormModal() string {
 	} else {
 		service = modalInputStyle.Render(service)
 	}
+	if m.contextFormFocus == contextFormFocusToken {
+		token = modalInputFocusStyle.Render(token)
+	} else {
+		token = modalInputStyle.Render(token)
+	}
 
 	anonymous := "[ ] Anonymous"
 	if m.contextFormAnonymous {
@@ -99,6 +105,8 @@ func (m Model) renderContextFormModal() string {
 		kind,
 		modalLabelStyle.Render("Service"),
 		service,
+		modalLabelStyle.Render("Token (registry_v2/acr, skips login)"),
+		token,
 		anonymous,
 		"",
 		buttonRow,
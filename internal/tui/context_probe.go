@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+const contextProbeTimeout = 3 * time.Second
+
+type contextProbe struct {
+	done bool
+	err  error
+}
+
+func contextProbeKey(host string) string {
+	return strings.ToLower(strings.TrimSpace(host))
+}
+
+func probeContextCmd(host, proxy string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), contextProbeTimeout)
+		defer cancel()
+		return contextProbeMsg{host: contextProbeKey(host), err: registry.Probe(ctx, host, proxy)}
+	}
+}
+
+// probeContextsCmd checks every context host that has no result or
+// in-flight probe yet. Probes run concurrently and never block selection.
+func (m *Model) probeContextsCmd() tea.Cmd {
+	if m.contextProbes == nil {
+		m.contextProbes = make(map[string]contextProbe)
+	}
+	var cmds []tea.Cmd
+	for _, ctx := range m.contexts {
+		key := contextProbeKey(ctx.Host)
+		if key == "" {
+			continue
+		}
+		if _, ok := m.contextProbes[key]; ok {
+			continue
+		}
+		m.contextProbes[key] = contextProbe{}
+		cmds = append(cmds, probeContextCmd(ctx.Host, ctx.Auth.Proxy))
+	}
+	return tea.Batch(cmds...)
+}
+
+// startupContextProbesCmd probes every context when the selection modal is
+// shown at launch, before Update has had a chance to record pending probes.
+func (m Model) startupContextProbesCmd() tea.Cmd {
+	var cmds []tea.Cmd
+	for _, ctx := range m.contexts {
+		if contextProbeKey(ctx.Host) != "" {
+			cmds = append(cmds, probeContextCmd(ctx.Host, ctx.Auth.Proxy))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m Model) updateContextProbeMsg(msg contextProbeMsg) (tea.Model, tea.Cmd) {
+	probes := make(map[string]contextProbe, len(m.contextProbes)+1)
+	for key, probe := range m.contextProbes {
+		probes[key] = probe
+	}
+	probes[msg.host] = contextProbe{done: true, err: msg.err}
+	m.contextProbes = probes
+	return m, nil
+}
+
+func (m Model) renderContextProbeBadge(host string) string {
+	probe, ok := m.contextProbes[contextProbeKey(host)]
+	switch {
+	case !ok || contextProbeKey(host) == "":
+		return " "
+	case !probe.done:
+		return modalOptionMutedStyle.Render("…")
+	case probe.err != nil:
+		return modalErrorStyle.Render("●")
+	default:
+		return modalSuccessStyle.Render("●")
+	}
+}
@@ -27,8 +27,9 @@ func (m Model) openContextSelection(required bool) (tea.Model, tea.Cmd) {
 	if current := m.currentContextIndex(); current >= 0 {
 		m.contextSelectionIndex = current
 	}
+	m.contextProbes = nil
 	m.syncTable()
-	return m, nil
+	return m, m.probeContextsCmd()
 }
 
 func (m Model) closeContextSelection() (tea.Model, tea.Cmd) {
@@ -64,6 +65,12 @@ func (m Model) runContextCommand(args []string) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		return m.openContextFormEditByName(strings.Join(args[1:], " "))
+	case "test":
+		if len(args) < 2 {
+			m.status = "Usage: :context test <name>"
+			return m, nil
+		}
+		return m.testContext(strings.Join(args[1:], " "))
 	default:
 		return m.switchContext(strings.Join(args, " "))
 	}
@@ -156,10 +163,14 @@ func (m Model) renderContextSelectionModal() string {
 		hostLabel := modalOptionMutedStyle.Render(host)
 		if host == "" {
 			hostLabel = modalOptionErrorStyle.Render("(no registry configured)")
+		} else if probe := m.contextProbes[contextProbeKey(host)]; probe.err != nil {
+			hostLabel = modalOptionErrorStyle.Render(host + " (unreachable)")
 		}
 
 		row := prefix + lipglossv2.JoinHorizontal(
 			lipglossv2.Top,
+			m.renderContextProbeBadge(host),
+			" ",
 			name,
 			"  ",
 			hostLabel,
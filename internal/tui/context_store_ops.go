@@ -72,14 +72,35 @@ func (m Model) currentContextIndex() int {
 
 func (m Model) removeContextByName(name string) (tea.Model, tea.Cmd) {
 	serviceManager := contextstore.NewService(m.configPath)
-	updatedStored, removedContext, index, err := serviceManager.RemoveByName(contextOptionsToStoredContexts(m.contexts), name)
-	if err != nil {
-		m.status = err.Error()
-		return m, nil
-	}
+	existing := contextOptionsToStoredContexts(m.contexts)
 	currentIndex := m.currentContextIndex()
-	if err := serviceManager.Save(updatedStored); err != nil {
-		m.status = fmt.Sprintf("failed to save contexts: %v", err)
+	currentName := ""
+	if currentIndex >= 0 {
+		currentName = existing[currentIndex].Name
+	}
+	var (
+		removedContext contextstore.Context
+		index          int
+		merged         bool
+	)
+	updatedStored, err := serviceManager.Update(func(current []contextstore.Context) ([]contextstore.Context, error) {
+		merged = contextstore.Changed(current, existing)
+		var (
+			updated []contextstore.Context
+			err     error
+		)
+		updated, removedContext, index, err = serviceManager.RemoveByName(current, name)
+		if err == nil && merged {
+			// Indices from the merged file may differ from the loaded list.
+			currentIndex = -1
+			if i, ok := contextstore.ResolveByName(current, currentName); ok && currentName != "" {
+				currentIndex = i
+			}
+		}
+		return updated, err
+	})
+	if err != nil {
+		m.setErrorStatus(err.Error())
 		return m, nil
 	}
 	updated := storedContextsToContextOptions(updatedStored)
@@ -119,19 +140,34 @@ func (m Model) removeContextByName(name string) (tea.Model, tea.Cmd) {
 	} else if m.contextSelectionIndex >= len(m.contexts) {
 		m.contextSelectionIndex = len(m.contexts) - 1
 	}
-	m.status = fmt.Sprintf("Removed context %s", removed)
+	m.status = fmt.Sprintf("Removed context %s", removed) + mergedContextsNote(merged)
 	m.syncTable()
 	return m, nil
 }
 
+// mergedContextsNote tells the user a save picked up context edits another
+// Beacon instance wrote to the config since it was loaded.
+func mergedContextsNote(merged bool) string {
+	if !merged {
+		return ""
+	}
+	return " (kept changes made by another Beacon instance)"
+}
+
 func (m *Model) clearRegistryContext() {
 	m.context = ""
 	m.registryHost = ""
+	m.defaultPath = ""
+	m.pendingDefaultPath = ""
+	m.namespaces = nil
+	m.cancelTagStream()
 	m.registryClient = nil
+	m.conn = connUnknown
 	m.auth = registry.Auth{}
 	m.auth.Normalize()
-	m.provider = registry.ProviderForAuth(m.auth)
+	m.provider = registry.ProviderForAuth("", m.auth)
 	m.authRequired = false
+	m.authRejected = false
 	m.authError = ""
 	m.authFocus = 0
 	m.usernameInput.SetValue("")
@@ -143,11 +179,13 @@ func (m *Model) clearRegistryContext() {
 	m.images = nil
 	m.projects = nil
 	m.tags = nil
+	m.tagsLoadErr = nil
 	m.history = nil
 	m.selectedProject = ""
 	m.hasSelectedProject = false
 	m.selectedImage = registry.Image{}
 	m.hasSelectedImage = false
+	m.tagsQuery = ""
 	m.selectedTag = registry.Tag{}
 	m.hasSelectedTag = false
 	m.focus = m.defaultFocus()
@@ -168,6 +206,7 @@ func (m *Model) clearRegistryContext() {
 	m.dockerHubNext = ""
 	m.dockerHubRateLimit = registry.DockerHubRateLimit{}
 	m.dockerHubRetryUntil = time.Time{}
+	m.dockerHubRetryPending = false
 	m.githubActive = false
 	m.githubInputFocus = false
 	m.githubInput.Blur()
@@ -203,9 +242,11 @@ func storedContextToContextOption(ctx contextstore.Context) ContextOption {
 	auth := ctx.Auth
 	auth.Normalize()
 	return ContextOption{
-		Name: strings.TrimSpace(ctx.Name),
-		Host: strings.TrimSpace(ctx.Host),
-		Auth: auth,
+		Name:        strings.TrimSpace(ctx.Name),
+		Host:        strings.TrimSpace(ctx.Host),
+		Auth:        auth,
+		DefaultPath: ctx.DefaultPath,
+		Namespaces:  ctx.Namespaces,
 	}
 }
 
@@ -225,19 +266,23 @@ func contextOptionToStoredContext(ctx ContextOption) contextstore.Context {
 	if !ok {
 		kind = "registry_v2"
 	}
-	auth := registry.Auth{Kind: kind}
+	auth := registry.Auth{Kind: kind, Proxy: ctx.Auth.Proxy, ManifestAccept: ctx.Auth.ManifestAccept, Mirrors: ctx.Auth.Mirrors}
 	switch kind {
 	case "harbor":
 		auth.Harbor.Anonymous = ctx.Auth.Harbor.Anonymous
 		auth.Harbor.Service = strings.TrimSpace(ctx.Auth.Harbor.Service)
+		auth.Harbor.Robot = ctx.Auth.Harbor.Robot
 	default:
 		auth.RegistryV2.Anonymous = ctx.Auth.RegistryV2.Anonymous
 		auth.RegistryV2.Service = strings.TrimSpace(ctx.Auth.RegistryV2.Service)
+		auth.RegistryV2.Token = ctx.Auth.RegistryV2.Token
 	}
 	auth.Normalize()
 	return contextstore.Context{
-		Name: strings.TrimSpace(ctx.Name),
-		Host: strings.TrimSpace(ctx.Host),
-		Auth: auth,
+		Name:        strings.TrimSpace(ctx.Name),
+		Host:        strings.TrimSpace(ctx.Host),
+		Auth:        auth,
+		DefaultPath: ctx.DefaultPath,
+		Namespaces:  ctx.Namespaces,
 	}
 }
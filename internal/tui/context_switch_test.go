@@ -1,12 +1,19 @@
 package tui
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/scottbass3/beacon/internal/contextstore"
 	"github.com/scottbass3/beacon/internal/registry"
 )
 
 func TestSwitchContextAt(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
 	authA := registry.Auth{Kind: "registry_v2"}
 	authA.RegistryV2.Anonymous = true
 	authB := registry.Auth{Kind: "harbor"}
@@ -31,3 +38,146 @@ func TestSwitchContextAt(t *testing.T) {
 		t.Fatalf("expected init command after context switch")
 	}
 }
+
+func TestOpenContextSelectionProbesHosts(t *testing.T) {
+	reachable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer reachable.Close()
+	down := httptest.NewServer(http.NotFoundHandler())
+	downURL := down.URL
+	down.Close()
+
+	contexts := []ContextOption{
+		{Name: "up", Host: reachable.URL},
+		{Name: "down", Host: downURL},
+		{Name: "empty"},
+	}
+	m := NewModel("", registry.Auth{}, nil, false, nil, contexts, "", "/tmp/beacon-config.json")
+	updated, cmd := m.openContextSelection(false)
+	next := updated.(Model)
+	if cmd == nil {
+		t.Fatalf("expected probe command when opening context selection")
+	}
+	if len(next.contextProbes) != 2 {
+		t.Fatalf("expected 2 pending probes, got %d", len(next.contextProbes))
+	}
+
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok {
+		t.Fatalf("expected batched probes, got %T", cmd())
+	}
+	for _, probeCmd := range batch {
+		updated, _ = next.Update(probeCmd())
+		next = updated.(Model)
+	}
+
+	if probe := next.contextProbes[contextProbeKey(reachable.URL)]; !probe.done || probe.err != nil {
+		t.Fatalf("expected reachable registry, got %+v", probe)
+	}
+	if probe := next.contextProbes[contextProbeKey(downURL)]; !probe.done || probe.err == nil {
+		t.Fatalf("expected unreachable registry, got %+v", probe)
+	}
+	if view := next.renderContextSelectionModal(); !strings.Contains(view, "(unreachable)") {
+		t.Fatalf("expected unreachable marker in modal:\n%s", view)
+	}
+}
+
+func TestRecentContextsToggleBetweenLastTwo(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	contexts := []ContextOption{
+		{Name: "prod", Host: "https://prod.example.com", Auth: auth},
+		{Name: "staging", Host: "https://staging.example.com", Auth: auth},
+		{Name: "dev", Host: "https://dev.example.com", Auth: auth},
+	}
+	m := NewModel("https://prod.example.com", auth, nil, false, nil, contexts, "prod", "").WithRecentContexts([]string{"prod"})
+	updated, _ := m.switchContextAt(2)
+	m = updated.(Model)
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlO})
+	m = updated.(Model)
+	if !m.recentContextsActive {
+		t.Fatalf("expected ctrl+o to open the quick switcher")
+	}
+	if view := m.renderRecentContextsModal(); !strings.Contains(view, "dev (current)") || strings.Contains(view, "staging") {
+		t.Fatalf("expected only used contexts in MRU order:\n%s", view)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.recentContextsActive || m.context != "prod" {
+		t.Fatalf("expected enter to switch back to prod, got %q", m.context)
+	}
+	if got := contextstore.LoadRecent(); len(got) != 2 || got[0] != "prod" || got[1] != "dev" {
+		t.Fatalf("expected persisted MRU [prod dev], got %v", got)
+	}
+}
+
+func TestContextTestReportsWithoutSwitching(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RequestURI())
+		if r.URL.Path == "/v2/_catalog" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	down := httptest.NewServer(http.NotFoundHandler())
+	downURL := down.URL
+	down.Close()
+
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	contexts := []ContextOption{
+		{Name: "prod", Host: "https://registry.example.com", Auth: auth},
+		{Name: "staging", Host: server.URL, Auth: auth},
+		{Name: "down", Host: downURL, Auth: auth},
+	}
+	m := NewModel("", registry.Auth{}, nil, false, nil, contexts, "prod", "/tmp/beacon-config.json")
+
+	tests := []struct {
+		name   string
+		status string
+		err    bool
+	}{
+		{name: "staging", status: "Context staging OK ("},
+		{name: "down", status: "Context down failed after", err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated, cmd := m.runContextCommand([]string{"test", tt.name})
+			if cmd == nil {
+				t.Fatalf("expected a test command, status %q", updated.(Model).status)
+			}
+			msg, ok := cmd().(contextTestMsg)
+			if !ok {
+				t.Fatalf("expected contextTestMsg")
+			}
+			updated, _ = updated.(Model).Update(msg)
+			next := updated.(Model)
+			if !strings.HasPrefix(next.status, tt.status) {
+				t.Fatalf("unexpected status %q", next.status)
+			}
+			if next.statusIsError() != tt.err {
+				t.Fatalf("unexpected status kind for %q", next.status)
+			}
+			if next.context != m.context || next.registryHost != m.registryHost {
+				t.Fatalf("expected the active registry to stay, got %q at %q", next.context, next.registryHost)
+			}
+		})
+	}
+
+	if len(requests) != 1 || requests[0] != "/v2/_catalog?n=1" {
+		t.Fatalf("expected a single one-entry catalog request, got %v", requests)
+	}
+
+	updated, cmd := m.runContextCommand([]string{"test", "missing"})
+	if cmd != nil || updated.(Model).status != "Unknown context: missing" {
+		t.Fatalf("unexpected result for unknown context: %q", updated.(Model).status)
+	}
+}
@@ -14,6 +14,7 @@ const (
 	contextFormFocusRegistry
 	contextFormFocusKind
 	contextFormFocusService
+	contextFormFocusToken
 	contextFormFocusAnonymous
 	contextFormFocusSecondaryButton
 	contextFormFocusPrimaryButton
@@ -0,0 +1,78 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// connectLoadCmd runs the initial load after connecting and arms the
+// context's default path so the loaded lists drill straight into it. A path
+// set before connecting, such as a search result, takes precedence.
+func (m *Model) connectLoadCmd() tea.Cmd {
+	if m.pendingDefaultPath == "" {
+		m.pendingDefaultPath = strings.Trim(m.defaultPath, "/")
+	}
+	return m.initialLoadCmd()
+}
+
+// followDefaultPath opens the next level of pendingDefaultPath once the
+// current list has loaded. Unknown names stop the walk with a status note.
+func (m *Model) followDefaultPath() tea.Cmd {
+	path := m.pendingDefaultPath
+	if path == "" {
+		return nil
+	}
+
+	switch m.focus {
+	case FocusProjects:
+		project, rest, _ := strings.Cut(path, "/")
+		projects := m.visibleProjects()
+		if m.selectListRow(func(index int) bool { return projects[index].Name == project }) {
+			if rest == "" {
+				m.pendingDefaultPath = ""
+			}
+			return m.handleEnter()
+		}
+		m.pendingDefaultPath = ""
+		m.status = fmt.Sprintf("Default path: project %q not found", project)
+		return nil
+	case FocusImages:
+		m.pendingDefaultPath = ""
+		short := path
+		if m.hasSelectedProject {
+			short = strings.TrimPrefix(path, m.selectedProject+"/")
+		}
+		images := m.visibleImages()
+		if m.selectListRow(func(index int) bool { return images[index].Name == path || images[index].Name == short }) {
+			return m.handleEnter()
+		}
+		if m.hasSelectedProject {
+			m.status = fmt.Sprintf("Default path: image %q not found", path)
+			return nil
+		}
+		// Registries without projects: treat the path as a namespace filter.
+		m.filterInput.SetValue(path + "/")
+		m.tableSetCursor(0)
+		m.syncTable()
+		m.status = fmt.Sprintf("Showing images under %s/", path)
+		return nil
+	default:
+		m.pendingDefaultPath = ""
+		return nil
+	}
+}
+
+// selectListRow moves the cursor to the first listed row whose source index
+// matches. Rows can be sorted or hidden, so the row is looked up through
+// listView like handleEnter resolves it.
+func (m *Model) selectListRow(match func(index int) bool) bool {
+	for row, index := range m.listView().indices {
+		if index >= 0 && match(index) {
+			m.tableSetCursor(row)
+			return true
+		}
+	}
+	return false
+}
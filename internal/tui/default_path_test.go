@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+func TestFollowDefaultPathAfterConnect(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		wantFocus  Focus
+		wantImage  string
+		wantFilter string
+	}{
+		{name: "image", path: "team/app", wantFocus: FocusTags, wantImage: "team/app"},
+		{name: "namespace", path: "team/", wantFocus: FocusImages, wantFilter: "team/"},
+		{name: "none", path: "", wantFocus: FocusImages},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth := registry.Auth{Kind: "registry_v2"}
+			auth.RegistryV2.Anonymous = true
+			contexts := []ContextOption{{Name: "prod", Host: "https://registry.example.com", Auth: auth, DefaultPath: tt.path}}
+			m := NewModel("https://registry.example.com", auth, nil, false, nil, contexts, "prod", "")
+			m.pendingDefaultPath = ""
+			m.connectLoadCmd()
+
+			updated, cmd := m.Update(imagesMsg{images: []registry.Image{{Name: "other/tool"}, {Name: "team/app"}, {Name: "team/web"}}})
+			next := updated.(Model)
+			if next.focus != tt.wantFocus {
+				t.Fatalf("expected focus %v, got %v", tt.wantFocus, next.focus)
+			}
+			if tt.wantImage != "" && (cmd == nil || next.selectedImage.Name != tt.wantImage) {
+				t.Fatalf("expected tags to load for %s, got %q", tt.wantImage, next.selectedImage.Name)
+			}
+			if got := next.filterInput.Value(); got != tt.wantFilter {
+				t.Fatalf("expected filter %q, got %q", tt.wantFilter, got)
+			}
+			if next.pendingDefaultPath != "" {
+				t.Fatalf("expected default path to be consumed, got %q", next.pendingDefaultPath)
+			}
+		})
+	}
+}
+
+func TestFollowDefaultPathUsesListedRows(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	contexts := []ContextOption{{Name: "prod", Host: "https://registry.example.com", Auth: auth, DefaultPath: "team/web"}}
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, contexts, "prod", "")
+	m.stickyFilter = true
+	m.filterInput.SetValue("web")
+	m.pendingDefaultPath = ""
+	m.connectLoadCmd()
+
+	// The sticky filter hides other/tool, so team/web is the first row.
+	updated, _ := m.Update(imagesMsg{images: []registry.Image{{Name: "other/tool"}, {Name: "team/web"}, {Name: "tools/web"}}})
+	if next := updated.(Model); next.selectedImage.Name != "team/web" {
+		t.Fatalf("expected team/web to open, got %q", next.selectedImage.Name)
+	}
+}
+
+func TestNamespacesLimitListedRepositories(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	contexts := []ContextOption{{Name: "prod", Host: "https://registry.example.com", Auth: auth, Namespaces: []string{"team", "infra/base"}}}
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, contexts, "prod", "")
+	m.images = []registry.Image{{Name: "team/app"}, {Name: "teamwork/app"}, {Name: "infra/base/go"}, {Name: "infra/tools"}, {Name: "other/app"}}
+
+	var got []string
+	for _, image := range m.visibleImages() {
+		got = append(got, image.Name)
+	}
+	if strings.Join(got, ",") != "team/app,infra/base/go" {
+		t.Fatalf("expected only repositories under the namespaces, got %v", got)
+	}
+
+	m.projects = []projectInfo{{Name: "team"}, {Name: "infra"}, {Name: "other"}}
+	var projects []string
+	for _, project := range m.projectsInNamespaces() {
+		projects = append(projects, project.Name)
+	}
+	if strings.Join(projects, ",") != "team,infra" {
+		t.Fatalf("expected projects holding a namespace, got %v", projects)
+	}
+}
@@ -2,6 +2,8 @@ package tui
 
 import (
 	"context"
+	"sort"
+	"sync"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -51,83 +53,239 @@ func (m *Model) syncAuthFocus() {
 }
 
 func loadImagesCmd(client registry.Client) tea.Cmd {
+	if streamer, ok := client.(registry.ImageStreamer); ok {
+		return streamImagesCmd(client, streamer)
+	}
+	return listImagesCmd(client)
+}
+
+func listImagesCmd(client registry.Client) tea.Cmd {
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), registryLoadTimeout)
 		defer cancel()
 
 		images, err := client.ListImages(ctx)
-		return imagesMsg{images: images, err: err}
+		return imagesMsg{images: images, err: withTimeout(err, registryLoadTimeout)}
+	}
+}
+
+// streamImagesCmd delivers each batch as an imagesPartialMsg and finishes
+// with a sorted imagesMsg, like ListImages would return.
+func streamImagesCmd(client registry.Client, streamer registry.ImageStreamer) tea.Cmd {
+	return func() tea.Msg {
+		ch := make(chan tea.Msg, 16)
+		go func() {
+			defer close(ch)
+			ctx, cancel := context.WithTimeout(context.Background(), imageStreamTimeout)
+			defer cancel()
+
+			var images []registry.Image
+			emit := func(batch []registry.Image, total int) {
+				images = append(images, batch...)
+				ch <- imagesPartialMsg{client: client, images: batch, total: total}
+			}
+			var err error
+			if totaler, ok := streamer.(registry.ImageTotalStreamer); ok {
+				err = totaler.StreamImagesWithTotal(ctx, emit)
+			} else {
+				err = streamer.StreamImages(ctx, func(batch []registry.Image) { emit(batch, 0) })
+			}
+			if err != nil {
+				ch <- imagesMsg{err: withTimeout(err, imageStreamTimeout)}
+				return
+			}
+			sort.Slice(images, func(i, j int) bool {
+				return images[i].Name < images[j].Name
+			})
+			ch <- imagesMsg{images: images}
+		}()
+		return listenImagesStream(ch)()
+	}
+}
+
+func listenImagesStream(ch <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-ch
+		if !ok {
+			return nil
+		}
+		if partial, ok := msg.(imagesPartialMsg); ok {
+			partial.next = listenImagesStream(ch)
+			return partial
+		}
+		return msg
 	}
 }
 
 func loadProjectsCmd(client registry.ProjectClient) tea.Cmd {
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), registryLoadTimeout)
 		defer cancel()
 
 		projects, err := client.ListProjects(ctx)
-		return projectsMsg{projects: projects, err: err}
+		return projectsMsg{projects: projects, err: withTimeout(err, registryLoadTimeout)}
+	}
+}
+
+func loadProjectArtifactCountsCmd(client registry.Client, counter registry.ProjectArtifactCounter) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), registryLoadTimeout)
+		defer cancel()
+
+		counts, err := counter.ProjectArtifactCounts(ctx)
+		return projectArtifactCountsMsg{client: client, counts: counts, err: err}
 	}
 }
 
 func loadProjectImagesCmd(client registry.ProjectClient, project string) tea.Cmd {
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), registryLoadTimeout)
 		defer cancel()
 
 		images, err := client.ListProjectImages(ctx, project)
-		return projectImagesMsg{project: project, images: images, err: err}
+		return projectImagesMsg{project: project, images: images, err: withTimeout(err, registryLoadTimeout)}
 	}
 }
 
 func loadTagsCmd(client registry.Client, image string) tea.Cmd {
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), registryLoadTimeout)
 		defer cancel()
 
 		tags, err := client.ListTags(ctx, image)
-		return tagsMsg{tags: tags, err: err}
+		return tagsMsg{image: image, tags: tags, err: withTimeout(err, registryLoadTimeout)}
+	}
+}
+
+// streamTagsCmd is the tag-list counterpart of streamImagesCmd. A non-empty
+// query is filtered by the registry.
+func streamTagsCmd(ctx context.Context, cancel context.CancelFunc, stream int, streamer registry.TagStreamer, image, query string) tea.Cmd {
+	return func() tea.Msg {
+		ch := make(chan tea.Msg, 16)
+		go func() {
+			defer close(ch)
+			defer cancel()
+
+			var tags []registry.Tag
+			err := streamer.StreamTags(ctx, image, query, func(batch []registry.Tag) {
+				tags = append(tags, batch...)
+				ch <- tagsPartialMsg{image: image, query: query, tags: batch, stream: stream}
+			})
+			ch <- tagsMsg{image: image, query: query, tags: tags, err: withTimeout(err, tagStreamTimeout), stream: stream}
+		}()
+		return listenTagsStream(ch)()
+	}
+}
+
+func listenTagsStream(ch <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-ch
+		if !ok {
+			return nil
+		}
+		if partial, ok := msg.(tagsPartialMsg); ok {
+			partial.next = listenTagsStream(ch)
+			return partial
+		}
+		return msg
 	}
 }
 
 func loadHistoryCmd(client registry.Client, image, tag string) tea.Cmd {
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), registryLoadTimeout)
+		defer cancel()
+
+		details, err := inspectTag(ctx, client, image, tag)
+		return historyMsg{history: details.History, digest: details.Digest, labels: details.Labels, annotations: details.Annotations, err: withTimeout(err, registryLoadTimeout)}
+	}
+}
+
+func inspectTag(ctx context.Context, client registry.Client, image, tag string) (registry.TagDetails, error) {
+	if inspector, ok := client.(registry.TagInspector); ok {
+		return inspector.InspectTag(ctx, image, tag)
+	}
+	if resolver, ok := client.(registry.TagHistoryResolver); ok {
+		history, digest, err := resolver.ListTagHistoryWithDigest(ctx, image, tag)
+		return registry.TagDetails{History: history, Digest: digest}, err
+	}
+	history, err := client.ListTagHistory(ctx, image, tag)
+	return registry.TagDetails{History: history}, err
+}
+
+// loadTagPlatformsCmd counts manifest platforms for the given tags in the
+// background so the Tags view can show single/multi-arch markers.
+func loadTagPlatformsCmd(counter registry.PlatformCounter, focus Focus, image string, tags []string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), tagPlatformsTimeout)
 		defer cancel()
 
-		history, err := client.ListTagHistory(ctx, image, tag)
-		return historyMsg{history: history, err: err}
+		var (
+			mu            sync.Mutex
+			wg            sync.WaitGroup
+			platforms     = make(map[string]int, len(tags))
+			artifactTypes = make(map[string]string, len(tags))
+			sem           = make(chan struct{}, platformLookupWorkers)
+		)
+		for _, tag := range tags {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(tag string) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				summary, err := counter.CountPlatforms(ctx, image, tag)
+				if err != nil {
+					summary.Platforms = platformLookupFailed
+				}
+				mu.Lock()
+				platforms[tag] = summary.Platforms
+				if summary.ArtifactType != "" {
+					artifactTypes[tag] = summary.ArtifactType
+				}
+				mu.Unlock()
+			}(tag)
+		}
+		wg.Wait()
+		return tagPlatformsMsg{focus: focus, image: image, platforms: platforms, artifactTypes: artifactTypes}
 	}
 }
 
-func loadDockerHubTagsFirstPageCmd(query string, logger registry.RequestLogger) tea.Cmd {
+func retryPendingTick(image, next string) tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return retryPendingMsg{image: image, next: next}
+	})
+}
+
+func loadDockerHubTagsFirstPageCmd(query string, logger registry.RequestLogger, proxy string, skipResolve bool) tea.Cmd {
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), externalLoadTimeout)
 		defer cancel()
 
-		client := registry.NewDockerHubClient(logger)
+		client := registry.NewDockerHubClient(logger, proxy)
+		client.SkipResolve = skipResolve
 		page, err := client.SearchTagsPage(ctx, query)
 		if err != nil {
-			return dockerHubErrorMsg(err)
+			return dockerHubErrorMsg(withTimeout(err, externalLoadTimeout))
 		}
 		return dockerHubTagsMsg{
 			tags:      page.Tags,
 			image:     page.Image,
 			next:      page.Next,
 			rateLimit: page.RateLimit,
+			digest:    page.Digest,
 		}
 	}
 }
 
-func loadDockerHubTagsNextPageCmd(image, next string, logger registry.RequestLogger) tea.Cmd {
+func loadDockerHubTagsNextPageCmd(image, next string, logger registry.RequestLogger, proxy string) tea.Cmd {
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), externalLoadTimeout)
 		defer cancel()
 
-		client := registry.NewDockerHubClient(logger)
+		client := registry.NewDockerHubClient(logger, proxy)
 		page, err := client.NextTagsPage(ctx, image, next)
 		if err != nil {
-			msg := dockerHubErrorMsg(err)
+			msg := dockerHubErrorMsg(withTimeout(err, externalLoadTimeout))
 			msg.appendPage = true
 			return msg
 		}
@@ -141,33 +299,45 @@ func loadDockerHubTagsNextPageCmd(image, next string, logger registry.RequestLog
 	}
 }
 
-func loadGitHubTagsFirstPageCmd(query string, logger registry.RequestLogger) tea.Cmd {
+func loadGitHubTagsFirstPageCmd(query string, logger registry.RequestLogger, proxy string) tea.Cmd {
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), externalLoadTimeout)
 		defer cancel()
 
-		client := registry.NewGitHubContainerClient(logger)
+		client := registry.NewGitHubContainerClient(logger, proxy)
 		page, err := client.SearchTagsPage(ctx, query)
 		if err != nil {
-			return githubTagsMsg{err: err}
+			return githubTagsMsg{err: withTimeout(err, externalLoadTimeout)}
 		}
 		return githubTagsMsg{
-			tags:  page.Tags,
-			image: page.Image,
-			next:  page.Next,
+			tags:   page.Tags,
+			image:  page.Image,
+			next:   page.Next,
+			digest: page.Digest,
 		}
 	}
 }
 
-func loadGitHubTagsNextPageCmd(image, next string, logger registry.RequestLogger) tea.Cmd {
+func loadGitHubPackagesCmd(owner string, logger registry.RequestLogger, proxy, token string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), externalLoadTimeout)
+		defer cancel()
+
+		client := registry.NewGitHubContainerClient(logger, proxy).WithAPIToken(registry.GitHubAPIToken(token))
+		packages, err := client.ListPackages(ctx, owner)
+		return githubPackagesMsg{owner: owner, packages: packages, err: withTimeout(err, externalLoadTimeout)}
+	}
+}
+
+func loadGitHubTagsNextPageCmd(image, next string, logger registry.RequestLogger, proxy string) tea.Cmd {
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), externalLoadTimeout)
 		defer cancel()
 
-		client := registry.NewGitHubContainerClient(logger)
+		client := registry.NewGitHubContainerClient(logger, proxy)
 		page, err := client.NextTagsPage(ctx, image, next)
 		if err != nil {
-			return githubTagsMsg{err: err, appendPage: true}
+			return githubTagsMsg{err: withTimeout(err, externalLoadTimeout), appendPage: true}
 		}
 		return githubTagsMsg{
 			tags:       page.Tags,
@@ -178,24 +348,24 @@ func loadGitHubTagsNextPageCmd(image, next string, logger registry.RequestLogger
 	}
 }
 
-func loadDockerHubHistoryCmd(image, tag string, logger registry.RequestLogger) tea.Cmd {
+func loadDockerHubHistoryCmd(image, tag string, logger registry.RequestLogger, proxy string) tea.Cmd {
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), externalLoadTimeout)
 		defer cancel()
 
-		client := registry.NewDockerHubClient(logger)
-		history, err := client.ListTagHistory(ctx, image, tag)
-		return historyMsg{history: history, err: err}
+		client := registry.NewDockerHubClient(logger, proxy)
+		details, err := client.InspectTag(ctx, image, tag)
+		return historyMsg{history: details.History, digest: details.Digest, labels: details.Labels, annotations: details.Annotations, rateLimit: client.RateLimit(), err: withTimeout(err, externalLoadTimeout)}
 	}
 }
 
-func loadGitHubHistoryCmd(image, tag string, logger registry.RequestLogger) tea.Cmd {
+func loadGitHubHistoryCmd(image, tag string, logger registry.RequestLogger, proxy string) tea.Cmd {
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), externalLoadTimeout)
 		defer cancel()
 
-		client := registry.NewGitHubContainerClient(logger)
-		history, err := client.ListTagHistory(ctx, image, tag)
-		return historyMsg{history: history, err: err}
+		client := registry.NewGitHubContainerClient(logger, proxy)
+		details, err := client.InspectTag(ctx, image, tag)
+		return historyMsg{history: details.History, digest: details.Digest, labels: details.Labels, annotations: details.Annotations, err: withTimeout(err, externalLoadTimeout)}
 	}
 }
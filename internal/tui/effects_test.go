@@ -0,0 +1,130 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+type fakeStreamingClient struct {
+	registry.Client
+	batches [][]registry.Image
+}
+
+func (c *fakeStreamingClient) StreamImages(_ context.Context, emit func([]registry.Image)) error {
+	for _, batch := range c.batches {
+		emit(batch)
+	}
+	return nil
+}
+
+type fakeTotalStreamingClient struct {
+	fakeStreamingClient
+	total int
+}
+
+func (c *fakeTotalStreamingClient) StreamImagesWithTotal(_ context.Context, emit func([]registry.Image, int)) error {
+	for _, batch := range c.batches {
+		emit(batch, c.total)
+	}
+	return nil
+}
+
+func TestLoadImagesShowsProgressAgainstTotal(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	client := &fakeTotalStreamingClient{
+		fakeStreamingClient: fakeStreamingClient{batches: [][]registry.Image{{{Name: "team/web"}}, {{Name: "team/app"}}}},
+		total:               2,
+	}
+	m.registryClient = client
+
+	updated, _ := m.Update(loadImagesCmd(client)())
+	if status := updated.(Model).status; status != "Loading images... 1/2" {
+		t.Fatalf("expected progress against the total, got %q", status)
+	}
+}
+
+func TestLoadImagesStreamsPartialBatches(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	client := &fakeStreamingClient{batches: [][]registry.Image{
+		{{Name: "team/web"}},
+		{{Name: "other/tool"}, {Name: "team/app"}},
+	}}
+	m.registryClient = client
+
+	cmd := loadImagesCmd(client)
+	var partials []int
+	for cmd != nil {
+		msg := cmd()
+		if msg == nil {
+			break
+		}
+		var updated tea.Model
+		updated, cmd = m.Update(msg)
+		m = updated.(Model)
+		if _, ok := msg.(imagesPartialMsg); ok {
+			partials = append(partials, len(m.images))
+			if want := fmt.Sprintf("%d so far", len(m.images)); !strings.Contains(m.status, want) {
+				t.Fatalf("expected a running count in the status, got %q", m.status)
+			}
+			if !m.imagesStreaming {
+				t.Fatalf("expected streaming state during partial batches")
+			}
+		}
+	}
+
+	if len(partials) != 2 || partials[0] != 1 || partials[1] != 3 {
+		t.Fatalf("expected the list to grow per batch, got %v", partials)
+	}
+	if m.imagesStreaming {
+		t.Fatalf("expected streaming to end with the final message")
+	}
+	if len(m.images) != 3 || m.images[0].Name != "other/tool" || m.images[2].Name != "team/web" {
+		t.Fatalf("expected the final list sorted, got %+v", m.images)
+	}
+}
+
+func TestLoadErrorsDescribeTimeouts(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "timeout",
+			err:  withTimeout(fmt.Errorf("list catalog: %w", context.DeadlineExceeded), registryLoadTimeout),
+			want: "Error loading images: request timed out after 10s; the registry may be slow or unreachable",
+		},
+		{
+			name: "canceled",
+			err:  fmt.Errorf("list catalog: %w", context.Canceled),
+			want: "Error loading images: request canceled",
+		},
+		{
+			name: "other",
+			err:  fmt.Errorf("list catalog: boom"),
+			want: "Error loading images: list catalog: boom",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+			updated, _ := m.Update(imagesMsg{err: tt.err})
+			if got := updated.(Model).status; got != tt.want {
+				t.Fatalf("status = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package tui
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func (m Model) runExportCommand(args []string) (tea.Model, tea.Cmd) {
+	path := strings.TrimSpace(strings.Join(args, " "))
+	if path == "" {
+		m.status = "Usage: :export <file.csv>"
+		return m, nil
+	}
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, rest)
+		}
+	}
+
+	list := m.listView()
+	m.status = fmt.Sprintf("Exporting %d rows to %s...", len(list.rows), path)
+	return m, exportCSVCmd(path, list.headers, list.rows)
+}
+
+func exportCSVCmd(path string, headers []string, rows [][]string) tea.Cmd {
+	return func() tea.Msg {
+		return exportMsg{path: path, rows: len(rows), err: writeCSVFile(path, headers, rows)}
+	}
+}
+
+func writeCSVFile(path string, headers []string, rows [][]string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	writer := csv.NewWriter(file)
+	if err := writer.Write(headers); err != nil {
+		file.Close()
+		return err
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
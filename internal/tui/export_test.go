@@ -0,0 +1,44 @@
+package tui
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+func TestExportCommandWritesFilteredRows(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.focus = FocusImages
+	m.images = []registry.Image{{Name: "team/app,v2"}, {Name: "team/web"}, {Name: "other/tool"}}
+	m.filterInput.SetValue("team")
+	m.syncTable()
+
+	path := filepath.Join(t.TempDir(), "images.csv")
+	m.commandInput.SetValue("export " + path)
+	updated, cmd := m.runCommand()
+	if cmd == nil {
+		t.Fatalf("expected export command")
+	}
+	updated, _ = updated.(Model).Update(cmd())
+	if status := updated.(Model).status; status != "Exported 2 rows to "+path {
+		t.Fatalf("unexpected status %q", status)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open export: %v", err)
+	}
+	defer file.Close()
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+	if len(records) != 3 || records[0][0] != "Name" || records[1][0] != "team/app,v2" || records[2][0] != "team/web" {
+		t.Fatalf("unexpected csv records: %v", records)
+	}
+}
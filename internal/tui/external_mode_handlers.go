@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
@@ -48,25 +49,64 @@ func (m Model) handleExternalKey(kind externalModeKind, msg tea.KeyMsg) (tea.Mod
 			}
 			return m, m.searchExternal(kind, query)
 		}
+		if text, ok := pastedText(msg); ok && strings.TrimSpace(m.externalInputValue(kind)) == "" {
+			return m, m.pasteExternalReference(kind, text)
+		}
 		return m, m.updateExternalInput(kind, msg)
 	}
 
+	if text, ok := pastedText(msg); ok {
+		return m, m.pasteExternalReference(kind, text)
+	}
+
 	switch {
 	case isShortcut(msg, shortcutQuit):
 		return m.openQuitConfirm()
 	case isShortcut(msg, shortcutBack):
+		if m.dismissErrorStatus() {
+			return m, nil
+		}
 		if m.focus == FocusHistory {
 			return m, m.handleEscape()
 		}
+		if kind == externalModeGitHub && m.focus == FocusGitHubTags && len(m.githubPackages) > 0 {
+			m.focus = FocusGitHubPackages
+			m.status = fmt.Sprintf("GHCR: %s (%d packages)", m.githubOwner, len(m.githubPackages))
+			m.clearFilter()
+			m.syncTable()
+			return m, nil
+		}
 		return m.exitExternalMode(kind)
 	case isShortcut(msg, shortcutCopyImageTag):
 		m.copySelectedTagReference()
 		return m, nil
+	case isShortcut(msg, shortcutCopyPullCommand):
+		m.copySelectedPullCommand()
+		return m, nil
 	case isShortcut(msg, shortcutPullImageTag):
 		return m, m.pullSelectedTagWithDocker()
+	case isShortcut(msg, shortcutMarkTag) && m.focus != FocusHistory:
+		m.toggleMarkedTag()
+		return m, nil
+	case isShortcut(msg, shortcutCompareTags) && m.focus != FocusHistory:
+		return m, m.compareWithMarkedTag()
+	case isShortcut(msg, shortcutToggleHistoryClean) && m.focus == FocusHistory:
+		m.toggleCleanHistory()
+		return m, nil
+	case isShortcut(msg, shortcutToggleHistoryCollapse) && m.focus == FocusHistory:
+		m.toggleHistoryCollapse()
+		return m, nil
+	case isShortcut(msg, shortcutToggleArtifacts) && m.focus != FocusHistory:
+		m.toggleHideArtifacts()
+		return m, nil
+	case isShortcut(msg, shortcutOpenSource) && m.focus != FocusGitHubPackages:
+		return m, m.openTagSource()
 	case isShortcut(msg, shortcutOpenCommand):
 		return m.enterCommandMode()
 	case isShortcut(msg, shortcutOpenExternalTagHistory):
+		if m.printSelection && m.focus != FocusHistory && m.focus != FocusGitHubPackages {
+			return m.pickSelectedTag()
+		}
 		return m, m.openExternalTagHistory(kind)
 	case isShortcut(msg, shortcutFocusExternalSearch):
 		m.setExternalInputValue(kind, "")
@@ -82,6 +122,16 @@ func (m Model) handleExternalKey(kind externalModeKind, msg tea.KeyMsg) (tea.Mod
 		return m, nil
 	case isShortcut(msg, shortcutRefresh):
 		return m, m.refreshExternal(kind)
+	case isShortcut(msg, shortcutToggleWatch):
+		return m.toggleWatch()
+	case isShortcut(msg, shortcutCopyHost):
+		m.copyRegistryHost()
+		return m, nil
+	case isShortcut(msg, shortcutCopyRequestURL):
+		m.copyLastRequestURL()
+		return m, nil
+	case isShortcut(msg, shortcutExpandRow):
+		return m.openRowPreview()
 	}
 	if m.handleTableNavKey(msg) {
 		return m, m.maybeLoadExternalOnBottomKey(kind, msg)
@@ -89,10 +139,11 @@ func (m Model) handleExternalKey(kind externalModeKind, msg tea.KeyMsg) (tea.Mod
 
 	if len(msg.Runes) > 0 || msg.String() == "backspace" || msg.String() == "delete" {
 		m.setExternalInputFocus(kind, true)
+		var focus tea.Cmd
 		if !m.isExternalInputFocused(kind) {
-			return m, m.focusExternalInput(kind)
+			focus = m.focusExternalInput(kind)
 		}
-		return m, m.updateExternalInput(kind, msg)
+		return m, tea.Batch(focus, m.updateExternalInput(kind, msg))
 	}
 
 	return m, nil
@@ -181,27 +232,89 @@ func (m *Model) searchExternal(kind externalModeKind, query string) tea.Cmd {
 	m.setExternalInputFocus(kind, false)
 	m.blurExternalInput(kind)
 	m.table.Focus()
+	m.externalPendingTag = ""
+	if kind == externalModeGitHub {
+		if owner, ok := githubOwnerQuery(query); ok {
+			return m.searchGitHubPackages(owner)
+		}
+		if !strings.HasPrefix(strings.ToLower(query), m.githubOwner+"/") {
+			m.githubOwner = ""
+			m.githubPackages = nil
+		}
+	}
 	m.status = kind.searchingStatus(query)
 	m.setExternalTags(kind, nil)
 	m.setExternalImage(kind, "")
 	m.setExternalNext(kind, "")
 	m.setExternalLoading(kind, true)
+	m.externalFilterPages = 0
 	if kind == externalModeDockerHub {
 		m.dockerHubRateLimit = registry.DockerHubRateLimit{}
 		m.dockerHubRetryUntil = time.Time{}
+		m.dockerHubRetryPending = false
 	}
 	m.startLoading()
 	m.syncTable()
 
 	switch kind {
 	case externalModeGitHub:
-		return loadGitHubTagsFirstPageCmd(query, m.logger)
+		return loadGitHubTagsFirstPageCmd(query, m.logger, m.auth.Proxy)
 	default:
-		return loadDockerHubTagsFirstPageCmd(query, m.logger)
+		return loadDockerHubTagsFirstPageCmd(query, m.logger, m.auth.Proxy, m.dockerHubSkipResolve)
+	}
+}
+
+func (m *Model) searchGitHubPackages(owner string) tea.Cmd {
+	m.status = fmt.Sprintf("Listing GHCR packages for %s...", owner)
+	m.githubTags = nil
+	m.githubImage = ""
+	m.githubNext = ""
+	m.githubPackages = nil
+	m.githubOwner = ""
+	m.githubLoading = true
+	m.externalFilterPages = 0
+	m.startLoading()
+	m.syncTable()
+	return loadGitHubPackagesCmd(owner, m.logger, m.auth.Proxy, m.githubToken)
+}
+
+func (m *Model) openGitHubPackage() tea.Cmd {
+	list := m.listView()
+	cursor := m.table.Cursor()
+	if cursor < 0 || cursor >= len(list.indices) {
+		return nil
+	}
+	index := list.indices[cursor]
+	if index < 0 || index >= len(m.githubPackages) {
+		return nil
 	}
+	image := m.githubPackages[index].Image()
+	m.githubInput.SetValue(image)
+	m.githubInput.CursorEnd()
+	m.clearFilter()
+	return m.searchExternal(externalModeGitHub, image)
+}
+
+// githubOwnerQuery reports whether query names only a GHCR owner.
+func githubOwnerQuery(query string) (string, bool) {
+	trimmed := strings.TrimSpace(query)
+	trimmed = strings.TrimPrefix(trimmed, "https://")
+	trimmed = strings.TrimPrefix(trimmed, "ghcr.io/")
+	trimmed = strings.Trim(trimmed, "/")
+	if trimmed == "" || strings.ContainsAny(trimmed, "/:@ ") {
+		return "", false
+	}
+	return strings.ToLower(trimmed), true
 }
 
 func (m *Model) openExternalTagHistory(kind externalModeKind) tea.Cmd {
+	if kind == externalModeGitHub && m.focus == FocusGitHubPackages {
+		return m.openGitHubPackage()
+	}
+	if m.focus == FocusHistory {
+		m.openHistoryDetail()
+		return nil
+	}
 	if m.focus != kind.focus() {
 		return nil
 	}
@@ -221,7 +334,25 @@ func (m *Model) openExternalTagHistory(kind externalModeKind) tea.Cmd {
 		return nil
 	}
 
-	selected := m.externalTags(kind)[index]
+	return m.openExternalHistoryFor(kind, image, m.externalTags(kind)[index])
+}
+
+// openExternalDigest opens history for a digest pasted as image@sha256:...,
+// selecting the loaded tag that points at it when there is one.
+func (m *Model) openExternalDigest(kind externalModeKind, digest string) tea.Cmd {
+	image := strings.TrimSpace(m.externalImage(kind))
+	list := m.listView()
+	for cursor, index := range list.indices {
+		tags := m.externalTags(kind)
+		if index >= 0 && index < len(tags) && strings.EqualFold(tags[index].Digest, digest) {
+			m.tableSetCursor(cursor)
+			return m.openExternalHistoryFor(kind, image, tags[index])
+		}
+	}
+	return m.openExternalHistoryFor(kind, image, registry.Tag{Name: digest, Digest: digest})
+}
+
+func (m *Model) openExternalHistoryFor(kind externalModeKind, image string, selected registry.Tag) tea.Cmd {
 	m.selectedImage = registry.Image{Name: image}
 	m.hasSelectedImage = true
 	m.selectedTag = selected
@@ -235,9 +366,9 @@ func (m *Model) openExternalTagHistory(kind externalModeKind) tea.Cmd {
 
 	switch kind {
 	case externalModeGitHub:
-		return loadGitHubHistoryCmd(image, selected.Name, m.logger)
+		return loadGitHubHistoryCmd(image, selected.Name, m.logger, m.auth.Proxy)
 	default:
-		return loadDockerHubHistoryCmd(image, selected.Name, m.logger)
+		return loadDockerHubHistoryCmd(image, selected.Name, m.logger, m.auth.Proxy)
 	}
 }
 
@@ -267,7 +398,26 @@ func (m *Model) maybeLoadExternalOnBottom(kind externalModeKind) tea.Cmd {
 	return m.requestNextExternalPage(kind, false)
 }
 
+// externalHasMoreRows reports whether the focused external tag list can still
+// grow, so wrap-around navigation leaves the bottom to the page loader.
+func (m Model) externalHasMoreRows() bool {
+	for _, kind := range []externalModeKind{externalModeDockerHub, externalModeGitHub} {
+		if m.focus == kind.focus() {
+			return m.externalNext(kind) != "" || m.externalLoading(kind)
+		}
+	}
+	return false
+}
+
 func (m *Model) maybeLoadExternalForFilter(kind externalModeKind) tea.Cmd {
+	cmd := m.nextExternalPageForFilter(kind)
+	if cmd == nil && !m.externalLoading(kind) {
+		m.externalFilterPages = 0
+	}
+	return cmd
+}
+
+func (m *Model) nextExternalPageForFilter(kind externalModeKind) tea.Cmd {
 	filter := strings.TrimSpace(m.filterInput.Value())
 	if filter == "" {
 		return nil
@@ -289,25 +439,28 @@ func (m *Model) requestNextExternalPage(kind externalModeKind, forFilter bool) t
 	if kind == externalModeDockerHub {
 		now := time.Now()
 		if !m.dockerHubRetryUntil.IsZero() && now.Before(m.dockerHubRetryUntil) {
-			m.status = m.dockerHubRateLimitStatus("Docker Hub rate limit reached")
-			return nil
+			return m.deferDockerHubPage(forFilter)
 		}
 		if m.dockerHubRateLimit.Remaining == 0 && !m.dockerHubRateLimit.ResetAt.IsZero() && now.Before(m.dockerHubRateLimit.ResetAt) {
 			m.dockerHubRetryUntil = m.dockerHubRateLimit.ResetAt
-			m.status = m.dockerHubRateLimitStatus("Docker Hub rate limit reached")
-			return nil
+			return m.deferDockerHubPage(forFilter)
 		}
 	}
 
+	if forFilter {
+		m.externalFilterPages++
+	} else {
+		m.externalFilterPages = 0
+	}
 	m.status = kind.loadingMoreStatus(m.externalImage(kind), forFilter)
 	m.setExternalLoading(kind, true)
 	m.startLoading()
 
 	switch kind {
 	case externalModeGitHub:
-		return loadGitHubTagsNextPageCmd(m.githubImage, m.githubNext, m.logger)
+		return loadGitHubTagsNextPageCmd(m.githubImage, m.githubNext, m.logger, m.auth.Proxy)
 	default:
-		return loadDockerHubTagsNextPageCmd(m.dockerHubImage, m.dockerHubNext, m.logger)
+		return loadDockerHubTagsNextPageCmd(m.dockerHubImage, m.dockerHubNext, m.logger, m.auth.Proxy)
 	}
 }
 
@@ -3,6 +3,7 @@ package tui
 import (
 	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
@@ -109,6 +110,25 @@ func TestExternalSearchInputConsumesShortcutKeys(t *testing.T) {
 	}
 }
 
+func TestShortcutsEnterExternalModes(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.focus = FocusImages
+
+	updated, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'D'}})
+	next := updated.(Model)
+	if !next.dockerHubActive || next.focus != FocusDockerHubTags || !next.dockerHubInputFocus {
+		t.Fatalf("expected D to open Docker Hub search, got focus %v", next.focus)
+	}
+
+	updated, _ = m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'H'}})
+	next = updated.(Model)
+	if !next.githubActive || next.focus != FocusGitHubTags || !next.githubInputFocus {
+		t.Fatalf("expected H to open GHCR search, got focus %v", next.focus)
+	}
+}
+
 func TestHelpShortcutIgnoredWhileExternalInputFocused(t *testing.T) {
 	auth := registry.Auth{Kind: "registry_v2"}
 	auth.RegistryV2.Anonymous = true
@@ -146,3 +166,239 @@ func TestCommandShortcutIgnoredWhileExternalInputFocused(t *testing.T) {
 		t.Fatalf("expected ':' to be typed into search input, got %q", next.dockerHubInput.Value())
 	}
 }
+
+func TestOpenExternalDigestSelectsMatchingTag(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.githubActive = true
+	m.focus = FocusGitHubTags
+	m.githubImage = "owner/app"
+	m.githubTags = []registry.Tag{
+		{Name: "v1", Digest: "sha256:111"},
+		{Name: "v2", Digest: "sha256:222"},
+	}
+	m.syncTable()
+
+	if cmd := m.openExternalDigest(externalModeGitHub, "sha256:222"); cmd == nil {
+		t.Fatalf("expected history load command")
+	}
+	if m.focus != FocusHistory || m.selectedTag.Name != "v2" {
+		t.Fatalf("expected history for v2, got focus=%v tag=%q", m.focus, m.selectedTag.Name)
+	}
+
+	m.focus = FocusGitHubTags
+	m.openExternalDigest(externalModeGitHub, "sha256:999")
+	if m.selectedTag.Name != "sha256:999" {
+		t.Fatalf("expected unmatched digest to be used as the reference, got %q", m.selectedTag.Name)
+	}
+}
+
+func TestDockerHubRateLimitedPageResumesAutomatically(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.dockerHubActive = true
+	m.focus = FocusDockerHubTags
+	m.dockerHubImage = "library/nginx"
+	m.dockerHubNext = "https://hub.docker.com/v2/next"
+	m.dockerHubRetryUntil = time.Now().Add(time.Minute)
+
+	if cmd := m.requestNextDockerHubPage(false); cmd == nil {
+		t.Fatalf("expected a retry tick while rate limited")
+	}
+	if !m.dockerHubRetryPending || m.dockerHubLoading {
+		t.Fatalf("expected the page load to be deferred")
+	}
+
+	updated, cmd := m.updateRetryPendingMsg(retryPendingMsg{image: m.dockerHubImage, next: m.dockerHubNext})
+	next := updated.(Model)
+	if cmd == nil || !next.dockerHubRetryPending || !strings.Contains(next.status, "Retry in") {
+		t.Fatalf("expected countdown to continue, got status %q", next.status)
+	}
+
+	next.dockerHubRetryUntil = time.Now().Add(-time.Second)
+	updated, cmd = next.updateRetryPendingMsg(retryPendingMsg{image: next.dockerHubImage, next: next.dockerHubNext})
+	next = updated.(Model)
+	if cmd == nil || next.dockerHubRetryPending || !next.dockerHubLoading {
+		t.Fatalf("expected deferred page load to fire once the window ends")
+	}
+}
+
+func TestDockerHubRetryDroppedAfterLeavingView(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.dockerHubActive = true
+	m.focus = FocusHistory
+	m.dockerHubImage = "library/nginx"
+	m.dockerHubNext = "https://hub.docker.com/v2/next"
+	m.dockerHubRetryPending = true
+
+	updated, cmd := m.updateRetryPendingMsg(retryPendingMsg{image: m.dockerHubImage, next: m.dockerHubNext})
+	if cmd != nil || updated.(Model).dockerHubRetryPending {
+		t.Fatalf("expected pending retry to be dropped outside the tags view")
+	}
+}
+
+func TestDockerHubRateChipInTopSection(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.width = 160
+	m.dockerHubActive = true
+	m.focus = FocusDockerHubTags
+	m.dockerHubImage = "library/nginx"
+	reset := time.Date(2024, 1, 2, 12, 30, 5, 0, time.Local)
+
+	updated, _ := m.updateDockerHubTagsMsg(dockerHubTagsMsg{
+		image:     "library/nginx",
+		tags:      []registry.Tag{{Name: "alpine"}},
+		rateLimit: registry.DockerHubRateLimit{Limit: 200, Remaining: 180, ResetAt: reset},
+	})
+	next := updated.(Model)
+	if top := next.renderTopSection(); !strings.Contains(top, "DH rate: 180/200, reset 12:30:05") {
+		t.Fatalf("expected rate chip in top section:\n%s", top)
+	}
+
+	next.dockerHubActive = false
+	if strings.Contains(next.renderTopSection(), "DH rate") {
+		t.Fatalf("expected no rate chip outside Docker Hub mode")
+	}
+}
+
+func TestDockerHubRateChipFollowsHistoryResponses(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.width = 160
+	m.dockerHubActive = true
+	m.focus = FocusDockerHubTags
+	m.dockerHubRateLimit = registry.DockerHubRateLimit{Limit: 200, Remaining: 180}
+
+	updated, _ := m.updateHistoryMsg(historyMsg{
+		history:   []registry.HistoryEntry{{CreatedBy: "ADD rootfs"}},
+		rateLimit: registry.DockerHubRateLimit{Limit: 100, Remaining: 76},
+	})
+	next := updated.(Model)
+	if top := next.renderTopSection(); !strings.Contains(top, "DH rate: 76/100") {
+		t.Fatalf("expected rate chip from the manifest response:\n%s", top)
+	}
+
+	updated, _ = next.updateHistoryMsg(historyMsg{rateLimit: registry.DockerHubRateLimit{Limit: -1, Remaining: -1}})
+	if limit := updated.(Model).dockerHubRateLimit; limit.Remaining != 76 {
+		t.Fatalf("expected a response without headers to keep the last quota, got %+v", limit)
+	}
+}
+
+func TestPasteIntoExternalSearch(t *testing.T) {
+	tests := []struct {
+		name        string
+		paste       string
+		wantTag     string
+		wantLoading bool
+	}{
+		{name: "image", paste: "library/nginx"},
+		{name: "tagged", paste: "library/nginx:alpine", wantTag: "alpine", wantLoading: true},
+		{name: "digest", paste: "library/nginx@sha256:abc", wantLoading: true},
+		{name: "registry port", paste: "localhost:5000/nginx"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth := registry.Auth{Kind: "registry_v2"}
+			auth.RegistryV2.Anonymous = true
+			m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+			m.dockerHubActive = true
+			m.focus = FocusDockerHubTags
+			m.status = "unchanged"
+
+			updated, _ := m.handleDockerHubKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(tt.paste), Paste: true})
+			next := updated.(Model)
+			if next.dockerHubInput.Value() != tt.paste {
+				t.Fatalf("expected input %q, got %q", tt.paste, next.dockerHubInput.Value())
+			}
+			if next.commandActive || next.filterActive {
+				t.Fatalf("paste should not trigger shortcuts")
+			}
+			if next.dockerHubLoading != tt.wantLoading {
+				t.Fatalf("expected loading=%v, got %v", tt.wantLoading, next.dockerHubLoading)
+			}
+			if next.externalPendingTag != tt.wantTag {
+				t.Fatalf("expected pending tag %q, got %q", tt.wantTag, next.externalPendingTag)
+			}
+		})
+	}
+}
+
+func TestPastedTagOpensHistoryWhenTagsLoad(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.dockerHubActive = true
+	m.focus = FocusDockerHubTags
+	m.externalPendingTag = "alpine"
+
+	updated, cmd := m.updateDockerHubTagsMsg(dockerHubTagsMsg{
+		image: "library/nginx",
+		tags:  []registry.Tag{{Name: "latest"}, {Name: "alpine"}},
+	})
+	next := updated.(Model)
+	if next.focus != FocusHistory || cmd == nil {
+		t.Fatalf("expected history to open, focus=%v", next.focus)
+	}
+	if next.selectedTag.Name != "alpine" || next.externalPendingTag != "" {
+		t.Fatalf("expected alpine selected and pending tag cleared, got %q/%q", next.selectedTag.Name, next.externalPendingTag)
+	}
+}
+
+func TestPasteOpensRegistryFilter(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+
+	updated, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("team/api"), Paste: true})
+	next := updated.(Model)
+	if !next.filterActive || next.filterInput.Value() != "team/api" {
+		t.Fatalf("expected filter with pasted text, got active=%v value=%q", next.filterActive, next.filterInput.Value())
+	}
+
+	// Runes typed quickly enough to share a message are not a paste.
+	updated, _ = m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("team/api")})
+	if next := updated.(Model); next.filterInput.Value() == "team/api" {
+		t.Fatalf("expected unbracketed runes not to be read as a paste")
+	}
+}
+
+func TestFilterDrivenPagesShowProgress(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.width = 160
+	m.height = 40
+	m.githubActive = true
+	m.focus = FocusGitHubTags
+	m.githubImage = "org/service"
+	m.githubNext = "page-2"
+	m.githubTags = []registry.Tag{{Name: "v1"}, {Name: "v2"}}
+	m.filterInput.SetValue("rc")
+	m.syncTable()
+
+	if cmd := m.maybeLoadGitHubForFilter(); cmd == nil {
+		t.Fatalf("expected the filter to request the next page")
+	}
+	if top := m.renderTopSection(); !strings.Contains(top, "Loaded 2 tags, fetching page 2") {
+		t.Fatalf("expected page progress in the top section, got %q", top)
+	}
+
+	updated, _ := m.updateGitHubTagsMsg(githubTagsMsg{image: "org/service", tags: []registry.Tag{{Name: "v3"}}, next: "page-3", appendPage: true})
+	next := updated.(Model)
+	if top := next.renderTopSection(); !strings.Contains(top, "Loaded 3 tags, fetching page 3") {
+		t.Fatalf("expected progress to advance with each appended page, got %q", top)
+	}
+
+	updated, _ = next.updateGitHubTagsMsg(githubTagsMsg{image: "org/service", tags: []registry.Tag{{Name: "v4-rc"}}, appendPage: true})
+	next = updated.(Model)
+	if next.externalFilterPages != 0 || strings.Contains(next.renderTopSection(), "fetching page") {
+		t.Fatalf("expected the progress line to go away once the last page loads")
+	}
+}
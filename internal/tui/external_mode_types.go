@@ -1,6 +1,9 @@
 package tui
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 type externalModeKind int
 
@@ -21,7 +24,7 @@ func (k externalModeKind) focus() Focus {
 func (k externalModeKind) searchPlaceholder() string {
 	switch k {
 	case externalModeGitHub:
-		return "Enter an image name to search GHCR (owner/image)"
+		return "Enter an owner or image to search GHCR (owner or owner/image)"
 	default:
 		return "Enter an image name to search Docker Hub"
 	}
@@ -70,5 +73,8 @@ func (k externalModeKind) loadingMoreStatus(image string, forFilter bool) string
 }
 
 func (k externalModeKind) loadingHistoryStatus(image, tag string) string {
+	if strings.HasPrefix(tag, "sha256:") {
+		return fmt.Sprintf("Loading history for %s@%s...", image, tag)
+	}
 	return fmt.Sprintf("Loading history for %s:%s...", image, tag)
 }
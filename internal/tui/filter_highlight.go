@@ -0,0 +1,49 @@
+package tui
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ansiPrefix matches the SGR sequences bubbles/table opens the cursor row with.
+var ansiPrefix = regexp.MustCompile(`^(\x1b\[[0-9;]*m)+`)
+
+// tableHeaderLines are the header row and its bottom border.
+const tableHeaderLines = 2
+
+// highlightFilterMatches styles the filter match in the first column of each
+// rendered row. bubbles/table truncates cells without skipping escape codes,
+// so the highlight goes onto the rendered view instead of into the rows.
+func (m Model) highlightFilterMatches(view string) string {
+	needle := strings.ToLower(m.filterInput.Value())
+	if needle == "" || len(m.tableColumns) == 0 {
+		return view
+	}
+	// The cell padding puts one space before the column.
+	nameWidth := 1 + m.tableColumns[0].Width
+	lines := strings.Split(view, "\n")
+	for i := tableHeaderLines; i < len(lines); i++ {
+		lines[i] = highlightLineMatch(lines[i], needle, nameWidth)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func highlightLineMatch(line, needle string, nameWidth int) string {
+	prefix := ansiPrefix.FindString(line)
+	rest := line[len(prefix):]
+	region := rest
+	if runes := []rune(rest); len(runes) > nameWidth {
+		region = string(runes[:nameWidth])
+	}
+	lower := strings.ToLower(region)
+	start := strings.Index(lower, needle)
+	if start < 0 || len(lower) != len(region) {
+		return line
+	}
+	end := start + len(needle)
+	if prefix == "" {
+		return rest[:start] + filterMatchStyle.Render(rest[start:end]) + rest[end:]
+	}
+	// The cursor row: the highlight's reset ends the row style, so reopen it.
+	return prefix + rest[:start] + filterMatchSelectedStyle.Render(rest[start:end]) + prefix + rest[end:]
+}
@@ -2,19 +2,90 @@ package tui
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+type helpTopic struct {
+	title    string
+	page     shortcutPage
+	hasPage  bool
+	commands []string
+}
+
+// helpTopics maps :help arguments to a shortcut page and the commands that
+// belong with it. Keys are matched case-insensitively.
+var helpTopics = map[string]helpTopic{
+	"filter":    {title: "Filter", page: shortcutPageFilterInput, hasPage: true},
+	"command":   {title: "Commands", page: shortcutPageCommandInput, hasPage: true},
+	"context":   {title: "Contexts", commands: []string{"context"}},
+	"dockerhub": {title: "Docker Hub", page: shortcutPageDockerHubTags, hasPage: true, commands: []string{"dockerhub"}},
+	"github":    {title: "GHCR", page: shortcutPageGitHubTags, hasPage: true, commands: []string{"github"}},
+	"packages":  {title: "GHCR Packages", page: shortcutPageGitHubPackages, hasPage: true, commands: []string{"github"}},
+	"projects":  {title: "Projects", page: shortcutPageProjects, hasPage: true, commands: []string{"columns", "reload"}},
+	"images":    {title: "Images", page: shortcutPageImages, hasPage: true, commands: []string{"columns", "reload"}},
+	"tags":      {title: "Tags", page: shortcutPageTags, hasPage: true, commands: []string{"size", "columns", "reload"}},
+	"history":   {title: "History", page: shortcutPageHistory, hasPage: true, commands: []string{"size", "columns"}},
+}
+
+var helpTopicAliases = map[string]string{
+	"commands": "command",
+	"contexts": "context",
+	"ctx":      "context",
+	"dh":       "dockerhub",
+	"hub":      "dockerhub",
+	"ghcr":     "github",
+	"tag":      "tags",
+	"image":    "images",
+	"project":  "projects",
+}
+
+func lookupHelpTopic(name string) (helpTopic, bool) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if alias, ok := helpTopicAliases[key]; ok {
+		key = alias
+	}
+	topic, ok := helpTopics[key]
+	return topic, ok
+}
+
+func helpTopicNames() []string {
+	names := make([]string, 0, len(helpTopics))
+	for name := range helpTopics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func commandHelpFor(names []string) []commandHelp {
+	entries := []commandHelp{}
+	for _, name := range names {
+		if descriptor, ok := resolveCommand(name); ok {
+			entries = append(entries, descriptor.Help...)
+		}
+	}
+	return entries
+}
+
 func (m Model) renderHelpSectionBody() string {
+	if topic, ok := lookupHelpTopic(m.helpTopic); ok {
+		return m.renderHelpTopicBody(topic)
+	}
 	pageTitle := m.helpPageTitle()
 	shortcuts := m.currentPageHelpEntries()
 	lines := []string{
 		helpFooterStyle.Render(fmt.Sprintf("Current page: %s", pageTitle)),
+	}
+	if topic := strings.TrimSpace(m.helpTopic); topic != "" {
+		lines = append(lines, helpFooterStyle.Render(fmt.Sprintf("Unknown help topic %q. Topics: %s", topic, strings.Join(helpTopicNames(), ", "))))
+	}
+	lines = append(lines,
 		"",
 		helpHeadingStyle.Render("Shortcuts"),
-	}
+	)
 	lines = append(lines, m.renderHelpEntries(shortcuts)...)
 	lines = append(lines,
 		"",
@@ -23,7 +94,32 @@ func (m Model) renderHelpSectionBody() string {
 	lines = append(lines, m.renderCommandHelpEntries(availableCommands())...)
 	lines = append(lines,
 		"",
-		helpFooterStyle.Render("Press esc, ?, f1, or enter to close help."),
+		helpFooterStyle.Render("Press esc, ?, f1, or enter to close help."+m.versionSuffix()),
+	)
+	return strings.Join(lines, "\n")
+}
+
+func (m Model) renderHelpTopicBody(topic helpTopic) string {
+	lines := []string{
+		helpFooterStyle.Render(fmt.Sprintf("Topic: %s", topic.title)),
+	}
+	if topic.hasPage {
+		lines = append(lines,
+			"",
+			helpHeadingStyle.Render("Shortcuts"),
+		)
+		lines = append(lines, m.renderHelpEntries(helpEntriesForActions(m.helpActionsForPage(topic.page)))...)
+	}
+	if len(topic.commands) > 0 {
+		lines = append(lines,
+			"",
+			helpHeadingStyle.Render("Commands"),
+		)
+		lines = append(lines, m.renderCommandHelpEntries(commandHelpFor(topic.commands))...)
+	}
+	lines = append(lines,
+		"",
+		helpFooterStyle.Render("Run :help for everything. Press esc, ?, f1, or enter to close help."),
 	)
 	return strings.Join(lines, "\n")
 }
@@ -75,7 +171,12 @@ func (m Model) helpPageTitle() string {
 }
 
 func (m Model) openHelp() (tea.Model, tea.Cmd) {
+	return m.openHelpTopic("")
+}
+
+func (m Model) openHelpTopic(topic string) (tea.Model, tea.Cmd) {
 	m.helpActive = true
+	m.helpTopic = strings.TrimSpace(topic)
 	return m, nil
 }
 
@@ -95,3 +196,10 @@ func (m Model) handleHelpKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 func isHelpShortcut(msg tea.KeyMsg) bool {
 	return isShortcut(msg, shortcutOpenHelp)
 }
+
+func (m Model) versionSuffix() string {
+	if m.version == "" {
+		return ""
+	}
+	return " • Beacon " + m.version
+}
@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+// minCollapsedRun is the shortest run of empty layers folded into one row.
+const minCollapsedRun = 2
+
+// emptyLayerRun returns how many consecutive empty-layer entries start at
+// index, or 0 when index does not begin a run.
+func emptyLayerRun(entries []registry.HistoryEntry, index int) int {
+	if index < 0 || index >= len(entries) || !entries[index].EmptyLayer {
+		return 0
+	}
+	if index > 0 && entries[index-1].EmptyLayer {
+		return 0
+	}
+	n := 0
+	for index+n < len(entries) && entries[index+n].EmptyLayer {
+		n++
+	}
+	return n
+}
+
+// collapseEmptyLayerRows folds each run of empty-layer entries that is not in
+// expanded into a single row pointing at the run's first entry.
+func collapseEmptyLayerRows(headers []string, rows [][]string, entries []registry.HistoryEntry, expanded map[int]bool) listView {
+	out := listView{headers: headers}
+	for i := 0; i < len(rows); i++ {
+		run := emptyLayerRun(entries, i)
+		if run < minCollapsedRun || expanded[i] {
+			out.rows = append(out.rows, rows[i])
+			out.indices = append(out.indices, i)
+			continue
+		}
+		row := make([]string, len(rows[i]))
+		for col := range row {
+			row[col] = "-"
+		}
+		row[0] = fmt.Sprintf("+%d metadata steps", run)
+		if len(row) > 1 {
+			row[1] = rows[i][1]
+		}
+		out.rows = append(out.rows, row)
+		out.indices = append(out.indices, i)
+		i += run - 1
+	}
+	return out
+}
+
+func (m Model) historyCollapsedAt(index int) bool {
+	if !m.historyCollapse || m.filterInput.Value() != "" || m.historyExpanded[index] {
+		return false
+	}
+	return emptyLayerRun(m.history, index) >= minCollapsedRun
+}
+
+// expandHistoryRun unfolds the collapsed run under the cursor and reports
+// whether there was one.
+func (m *Model) expandHistoryRun(index int) bool {
+	if !m.historyCollapsedAt(index) {
+		return false
+	}
+	if m.historyExpanded == nil {
+		m.historyExpanded = make(map[int]bool)
+	}
+	m.historyExpanded[index] = true
+	m.status = fmt.Sprintf("Expanded %d metadata steps", emptyLayerRun(m.history, index))
+	m.syncTable()
+	return true
+}
+
+func (m *Model) toggleHistoryCollapse() {
+	m.historyCollapse = !m.historyCollapse
+	m.historyExpanded = nil
+	if m.historyCollapse {
+		m.status = "Collapsing runs of empty-layer metadata steps; enter expands one"
+	} else {
+		m.status = "Showing every history step"
+	}
+	m.tableSetCursor(0)
+	m.syncTable()
+}
@@ -0,0 +1,161 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func (m *Model) openHistoryDetail() {
+	list := m.listView()
+	cursor := m.table.Cursor()
+	if cursor < 0 || cursor >= len(list.indices) {
+		return
+	}
+	index := list.indices[cursor]
+	if m.expandHistoryLimit(index) {
+		return
+	}
+	if index < 0 || index >= len(m.history) {
+		return
+	}
+	if m.expandHistoryRun(index) {
+		return
+	}
+	m.historyDetailActive = true
+	m.historyDetailIndex = index
+	m.historyDetailOffset = 0
+}
+
+func (m Model) handleHistoryDetailKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	visible := m.historyDetailVisibleLines()
+	maxOffset := maxInt(0, len(m.historyDetailBody())-visible)
+	switch {
+	case msg.String() == "ctrl+c":
+		m.historyDetailActive = false
+		return m.openQuitConfirm()
+	case msg.String() == "esc", msg.String() == "enter", msg.String() == "q":
+		m.historyDetailActive = false
+	case isShortcut(msg, shortcutMoveUp):
+		m.historyDetailOffset = clampInt(m.historyDetailOffset-1, 0, maxOffset)
+	case isShortcut(msg, shortcutMoveDown):
+		m.historyDetailOffset = clampInt(m.historyDetailOffset+1, 0, maxOffset)
+	case isShortcut(msg, shortcutMovePageUp):
+		m.historyDetailOffset = clampInt(m.historyDetailOffset-visible, 0, maxOffset)
+	case isShortcut(msg, shortcutMovePageDown):
+		m.historyDetailOffset = clampInt(m.historyDetailOffset+visible, 0, maxOffset)
+	case isShortcut(msg, shortcutMoveTop):
+		m.historyDetailOffset = 0
+	case isShortcut(msg, shortcutMoveBottom):
+		m.historyDetailOffset = maxOffset
+	}
+	return m, nil
+}
+
+// historyDetailVisibleLines is how much of the command and label sections
+// fits under the layer header, leaving room for the card frame and footer.
+func (m Model) historyDetailVisibleLines() int {
+	height := m.height
+	if height <= 0 {
+		height = 24
+	}
+	return maxInt(3, height-14)
+}
+
+// historyDetailBody is the scrollable part of the modal, wrapped to the card
+// width so each entry is one screen line.
+func (m Model) historyDetailBody() []string {
+	if m.historyDetailIndex < 0 || m.historyDetailIndex >= len(m.history) {
+		return nil
+	}
+	command := m.history[m.historyDetailIndex].CreatedBy
+	if m.cleanHistory {
+		command = cleanHistoryCommand(command)
+	}
+	sections := []string{formatHistoryCommand(strings.ReplaceAll(command, "\t", "  "))}
+	sections = append(sections, m.renderKeyValueSection("Image labels", m.historyLabels)...)
+	sections = append(sections, m.renderKeyValueSection("Manifest annotations", m.historyAnnotations)...)
+
+	wrap := lipgloss.NewStyle().Width(m.modalWidth(100) - 8)
+	var lines []string
+	for _, section := range sections {
+		for _, line := range strings.Split(wrap.Render(section), "\n") {
+			lines = append(lines, strings.TrimRight(line, " "))
+		}
+	}
+	return lines
+}
+
+func (m Model) renderHistoryDetailModal() string {
+	if m.historyDetailIndex < 0 || m.historyDetailIndex >= len(m.history) {
+		return m.renderModalCard(modalErrorStyle.Render("History entry no longer available."), 64)
+	}
+	entry := m.history[m.historyDetailIndex]
+	created := formatTime(entry.CreatedAt, m.displayLocation())
+	if m.relativeTime && !entry.CreatedAt.IsZero() {
+		created += " (" + formatRelativeTime(entry.CreatedAt) + ")"
+	}
+	lines := []string{
+		modalTitleStyle.Render(fmt.Sprintf("Layer %d of %d", m.historyDetailIndex+1, len(m.history))),
+		modalLabelStyle.Render(fmt.Sprintf("Created  %s", created)),
+	}
+	if entry.SizeBytes >= 0 {
+		lines = append(lines, modalLabelStyle.Render(fmt.Sprintf("Size     %s", formatSize(entry.SizeBytes))))
+	}
+	if comment := strings.TrimSpace(entry.Comment); comment != "" {
+		lines = append(lines, modalLabelStyle.Render(fmt.Sprintf("Comment  %s", comment)))
+	}
+	lines = append(lines, modalDividerStyle.Render(strings.Repeat("─", 24)))
+
+	body := m.historyDetailBody()
+	help := "esc/enter close"
+	start := clampInt(m.historyDetailOffset, 0, len(body))
+	end := minInt(len(body), start+m.historyDetailVisibleLines())
+	lines = append(lines, body[start:end]...)
+	if end < len(body) || start > 0 {
+		lines = append(lines, modalLabelStyle.Render(fmt.Sprintf("lines %d-%d of %d", start+1, end, len(body))))
+		help = "up/down, pgup/pgdn scroll • " + help
+	}
+	lines = append(lines, "", modalHelpStyle.Render(help))
+	return m.renderModalCard(strings.Join(lines, "\n"), 100)
+}
+
+func (m Model) renderKeyValueSection(title string, values map[string]string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	lines := []string{"", modalTitleStyle.Render(title)}
+	width := m.modalWidth(100) - 8
+	for _, key := range sortedLabelKeys(values) {
+		lines = append(lines, modalLabelStyle.Render(truncateLogLine(key+" = "+values[key], width)))
+	}
+	return lines
+}
+
+// primaryLabels lead the label list since they point back to the source.
+var primaryLabels = []string{
+	"org.opencontainers.image.source",
+	"org.opencontainers.image.revision",
+	"org.opencontainers.image.created",
+}
+
+func sortedLabelKeys(labels map[string]string) []string {
+	keys := make([]string, 0, len(labels))
+	for _, key := range primaryLabels {
+		if _, ok := labels[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+	rest := make([]string, 0, len(labels))
+	for key := range labels {
+		if !slices.Contains(primaryLabels, key) {
+			rest = append(rest, key)
+		}
+	}
+	sort.Strings(rest)
+	return append(keys, rest...)
+}
@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func runHistoryLimitCommand(m Model, args []string) (tea.Model, tea.Cmd) {
+	if len(args) != 1 {
+		m.status = "Usage: :historylimit <entries>|off"
+		return m, nil
+	}
+	if strings.EqualFold(args[0], "off") {
+		m.historyLimit = 0
+		m.status = "Showing every history entry"
+	} else {
+		limit, err := strconv.Atoi(args[0])
+		if err != nil || limit <= 0 {
+			m.status = "Usage: :historylimit <entries>|off"
+			return m, nil
+		}
+		m.historyLimit = limit
+		m.historyShowAll = false
+		m.status = fmt.Sprintf("Showing the %d most recent history entries", limit)
+	}
+	m.tableSetCursor(0)
+	m.syncTable()
+	return m, nil
+}
+
+// historyLimitRow is the index of the "+N older layers" row. It is negative
+// so index-based actions such as copy or preview skip it.
+const historyLimitRow = -1
+
+func (m Model) historyCapped() bool {
+	return m.historyLimit > 0 && !m.historyShowAll && m.filterInput.Value() == "" && len(m.history) > m.historyLimit
+}
+
+// capHistoryRows keeps the rows of the historyLimit most recent entries and
+// ends the list with a "+N older layers" row; enter on it shows the rest. The cap goes by the loaded order, so a
+// size sort reorders the recent entries rather than picking the largest.
+func (m Model) capHistoryRows(list listView) listView {
+	if !m.historyCapped() {
+		return list
+	}
+	out := listView{headers: list.headers}
+	hidden := 0
+	for i, index := range list.indices {
+		if index >= m.historyLimit {
+			hidden++
+			continue
+		}
+		out.rows = append(out.rows, list.rows[i])
+		out.indices = append(out.indices, index)
+	}
+	if hidden == 0 {
+		return list
+	}
+	row := make([]string, len(list.headers))
+	for col := range row {
+		row[col] = "-"
+	}
+	row[0] = fmt.Sprintf("+%d older layers", hidden)
+	out.rows = append(out.rows, row)
+	out.indices = append(out.indices, historyLimitRow)
+	return out
+}
+
+// expandHistoryLimit shows every entry when index is the "+N older layers"
+// row and reports whether it was.
+func (m *Model) expandHistoryLimit(index int) bool {
+	if !m.historyCapped() || index != historyLimitRow {
+		return false
+	}
+	m.historyShowAll = true
+	m.status = fmt.Sprintf("Showing all %d history entries", len(m.history))
+	m.syncTable()
+	return true
+}
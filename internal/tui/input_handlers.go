@@ -10,13 +10,13 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		case isShortcut(msg, shortcutClearFilter):
 			m.clearFilter()
 			m.syncTable()
-			return m, nil
+			return m, m.queryTagsForFilter()
 		case isShortcut(msg, shortcutOpenCommand):
 			return m.enterCommandMode()
 		case isShortcut(msg, shortcutApplyFilter):
 			m.stopFilterEditing()
 			m.syncTable()
-			return m, nil
+			return m, m.queryTagsForFilter()
 		}
 		before := m.filterInput.Value()
 		var cmd tea.Cmd
@@ -24,20 +24,56 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.filterInput.Value() != before {
 			m.tableSetCursor(0)
 			m.syncTable()
+			return m, tea.Batch(cmd, m.maybeLoadImagesForFilter())
 		}
 		return m, cmd
 	}
 
+	if text, ok := pastedText(msg); ok {
+		m.openFilterWith(text)
+		return m, m.maybeLoadImagesForFilter()
+	}
+
 	switch {
 	case isShortcut(msg, shortcutQuit):
 		return m.openQuitConfirm()
 	case isShortcut(msg, shortcutBack):
+		if m.dismissErrorStatus() {
+			return m, nil
+		}
+		if m.focus == FocusTags && m.clearTagSelection() {
+			return m, nil
+		}
 		return m, m.handleEscape()
 	case isShortcut(msg, shortcutCopyImageTag):
 		m.copySelectedTagReference()
 		return m, nil
+	case isShortcut(msg, shortcutCopyPullCommand):
+		m.copySelectedPullCommand()
+		return m, nil
 	case isShortcut(msg, shortcutPullImageTag):
 		return m, m.pullSelectedTagWithDocker()
+	case isShortcut(msg, shortcutMarkTag) && m.focus != FocusHistory:
+		m.toggleMarkedTag()
+		return m, nil
+	case isShortcut(msg, shortcutCompareTags) && m.focus != FocusHistory:
+		return m, m.compareWithMarkedTag()
+	case isShortcut(msg, shortcutToggleHistoryClean) && m.focus == FocusHistory:
+		m.toggleCleanHistory()
+		return m, nil
+	case isShortcut(msg, shortcutToggleHistoryCollapse) && m.focus == FocusHistory:
+		m.toggleHistoryCollapse()
+		return m, nil
+	case isShortcut(msg, shortcutSelectTag) && m.focus == FocusTags:
+		m.toggleTagSelection()
+		return m, nil
+	case isShortcut(msg, shortcutDeleteTags) && m.focus == FocusTags:
+		return m.confirmDeleteTags()
+	case isShortcut(msg, shortcutToggleArtifacts) && m.focus == FocusTags:
+		m.toggleHideArtifacts()
+		return m, nil
+	case isShortcut(msg, shortcutOpenSource) && (m.focus == FocusTags || m.focus == FocusHistory):
+		return m, m.openTagSource()
 	case isShortcut(msg, shortcutOpenFilter):
 		m.filterActive = true
 		m.filterInput.Focus()
@@ -48,11 +84,35 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.enterCommandMode()
 	case isShortcut(msg, shortcutRefresh):
 		return m, m.refreshCurrent()
+	case isShortcut(msg, shortcutToggleWatch):
+		return m.toggleWatch()
+	case isShortcut(msg, shortcutCopyHost):
+		m.copyRegistryHost()
+		return m, nil
+	case isShortcut(msg, shortcutCopyRequestURL):
+		m.copyLastRequestURL()
+		return m, nil
+	case isShortcut(msg, shortcutExpandRow):
+		return m.openRowPreview()
+	case m.focus == FocusProjects && isShortcut(msg, shortcutToggleProjectSort):
+		m.toggleProjectSort()
+		return m, nil
+	case isShortcut(msg, shortcutReload):
+		return m, m.reloadAll()
+	case isShortcut(msg, shortcutRecentContexts):
+		return m.openRecentContexts()
+	case isShortcut(msg, shortcutOpenDockerHub):
+		return m.enterExternalMode(externalModeDockerHub)
+	case isShortcut(msg, shortcutOpenGitHub):
+		return m.enterExternalMode(externalModeGitHub)
 	case isShortcut(msg, shortcutOpenTagHistory):
+		if m.printSelection && m.focus == FocusTags {
+			return m.pickSelectedTag()
+		}
 		return m, m.handleEnter()
 	}
 	if m.handleTableNavKey(msg) {
-		return m, nil
+		return m, m.maybeLoadMoreImagesOnBottomKey(msg)
 	}
 
 	var cmd tea.Cmd
@@ -62,6 +122,9 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 	if m.handleTableMouse(msg) {
+		if msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonWheelDown {
+			return m, m.maybeLoadMoreImagesOnBottom()
+		}
 		return m, nil
 	}
 	return m, nil
@@ -110,9 +173,17 @@ func (m *Model) handleTableNavKey(msg tea.KeyMsg) bool {
 
 	switch {
 	case isShortcut(msg, shortcutMoveUp):
+		if m.wrapNavigation && m.table.Cursor() == 0 {
+			m.tableGotoBottom()
+			return true
+		}
 		m.tableMoveUp(1)
 		return true
 	case isShortcut(msg, shortcutMoveDown):
+		if m.wrapNavigation && m.table.Cursor() >= rowCount-1 && !m.externalHasMoreRows() && !m.imagesHaveMoreRows() {
+			m.tableGotoTop()
+			return true
+		}
 		m.tableMoveDown(1)
 		return true
 	case isShortcut(msg, shortcutMovePageUp):
@@ -133,6 +204,12 @@ func (m *Model) handleTableNavKey(msg tea.KeyMsg) bool {
 	case isShortcut(msg, shortcutMoveBottom):
 		m.tableGotoBottom()
 		return true
+	case isShortcut(msg, shortcutMoveNextGroup):
+		m.tableSetCursor(nextGroupRow(m.table.Rows(), m.table.Cursor()))
+		return true
+	case isShortcut(msg, shortcutMovePrevGroup):
+		m.tableSetCursor(prevGroupRow(m.table.Rows(), m.table.Cursor()))
+		return true
 	default:
 		return false
 	}
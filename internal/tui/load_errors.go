@@ -0,0 +1,52 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	registryLoadTimeout = 10 * time.Second
+	externalLoadTimeout = 15 * time.Second
+	tagStreamTimeout    = 30 * time.Second
+	tagPlatformsTimeout = 15 * time.Second
+	// imageStreamTimeout bounds a whole streamed catalog, which shows its
+	// progress as it goes and can take many requests on large registries.
+	imageStreamTimeout = 60 * time.Second
+)
+
+// timeoutError remembers how long a load waited before its deadline passed,
+// so the status can say more than "context deadline exceeded".
+type timeoutError struct {
+	after time.Duration
+	err   error
+}
+
+func (e timeoutError) Error() string { return e.err.Error() }
+
+func (e timeoutError) Unwrap() error { return e.err }
+
+func withTimeout(err error, after time.Duration) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return timeoutError{after: after, err: err}
+	}
+	return err
+}
+
+// loadErrorText describes a load error for the status line, replacing the
+// raw context errors with something a user can act on.
+func loadErrorText(err error) string {
+	var timeout timeoutError
+	switch {
+	case errors.As(err, &timeout):
+		return fmt.Sprintf("request timed out after %s; the registry may be slow or unreachable", timeout.after)
+	case errors.Is(err, context.DeadlineExceeded):
+		return "request timed out; the registry may be slow or unreachable"
+	case errors.Is(err, context.Canceled):
+		return "request canceled"
+	default:
+		return err.Error()
+	}
+}
@@ -0,0 +1,101 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sizeUnits matches formatSize, which counts in powers of 1024.
+var sizeUnits = map[string]int64{
+	"":   1,
+	"b":  1,
+	"k":  1 << 10,
+	"kb": 1 << 10,
+	"m":  1 << 20,
+	"mb": 1 << 20,
+	"g":  1 << 30,
+	"gb": 1 << 30,
+	"t":  1 << 40,
+	"tb": 1 << 40,
+}
+
+// parseSize reads a human size such as "500MB", "1.5 GB" or "2048".
+func parseSize(value string) (int64, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	split := strings.IndexFunc(value, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	number, unit := value, ""
+	if split >= 0 {
+		number, unit = value[:split], strings.TrimSpace(value[split:])
+	}
+	multiplier, ok := sizeUnits[strings.Replace(unit, "ib", "b", 1)]
+	if !ok {
+		return 0, fmt.Errorf("unknown size unit %q", unit)
+	}
+	amount, err := strconv.ParseFloat(number, 64)
+	if err != nil || amount < 0 {
+		return 0, fmt.Errorf("invalid size %q", value)
+	}
+	return int64(amount * float64(multiplier)), nil
+}
+
+func runMinSizeCommand(m Model, args []string) (tea.Model, tea.Cmd) {
+	value := strings.Join(args, "")
+	if value == "" || strings.EqualFold(value, "off") {
+		if m.minSizeBytes == 0 {
+			m.status = "Usage: :minsize <size> (e.g. 500MB) or :minsize off"
+			return m, nil
+		}
+		m.minSizeBytes = 0
+		m.status = "Showing tags of any size"
+		m.tableSetCursor(0)
+		m.syncTable()
+		return m, nil
+	}
+	size, err := parseSize(value)
+	if err != nil {
+		m.status = fmt.Sprintf("Usage: :minsize <size> (e.g. 500MB): %v", err)
+		return m, nil
+	}
+	if !m.currentViewHasSizeData() {
+		m.status = "Size filter unavailable: no size data in this view"
+		return m, nil
+	}
+	m.minSizeBytes = size
+	m.status = fmt.Sprintf("Hiding entries smaller than %s", formatSize(size))
+	m.tableSetCursor(0)
+	m.syncTable()
+	return m, nil
+}
+
+func (m Model) currentViewHasSizeData() bool {
+	switch m.focus {
+	case FocusHistory:
+		return historyHasSizeData(m.history)
+	case FocusTags, FocusDockerHubTags, FocusGitHubTags:
+		return tagsHaveSizeData(m.currentTags())
+	default:
+		return false
+	}
+}
+
+// dropSmallRows hides rows whose size is below minSizeBytes, keeping indices
+// into the underlying slice. Rows of unknown size are hidden too.
+func (m Model) dropSmallRows(list listView, sizeAt func(index int) int64) listView {
+	if m.minSizeBytes <= 0 {
+		return list
+	}
+	out := listView{headers: list.headers}
+	for i, index := range list.indices {
+		if index >= 0 && sizeAt(index) < m.minSizeBytes {
+			continue
+		}
+		out.rows = append(out.rows, list.rows[i])
+		out.indices = append(out.indices, index)
+	}
+	return out
+}
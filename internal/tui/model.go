@@ -3,11 +3,13 @@ package tui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/scottbass3/beacon/internal/config"
 	"github.com/scottbass3/beacon/internal/registry"
 )
 
@@ -39,57 +41,71 @@ func NewModel(registryHost string, auth registry.Auth, logger registry.RequestLo
 
 	githubInput := textinput.New()
 	githubInput.Prompt = "Search: "
-	githubInput.Placeholder = "owner/image"
+	githubInput.Placeholder = "owner or owner/image"
 	githubInput.CharLimit = 128
 	githubInput.Blur()
 
+	repoInput := textinput.New()
+	repoInput.Prompt = "Repository: "
+	repoInput.Placeholder = "team/app"
+	repoInput.CharLimit = 256
+	repoInput.Blur()
+
 	commandInput := textinput.New()
 	commandInput.Prompt = ":"
 	commandInput.Placeholder = "help | context add | dockerhub | github"
-	commandInput.CharLimit = 64
+	commandInput.CharLimit = 256
 	commandInput.Blur()
 
 	contextNameInput := newContextInput("name")
 	contextRegistryInput := newContextInput("https://registry.example.com")
-	contextKindInput := newContextInput("registry_v2 | harbor")
+	contextKindInput := newContextInput("registry_v2 | harbor | acr | gcr")
 	contextServiceInput := newContextInput("optional service")
+	contextTokenInput := newContextInput("optional bearer token")
+	contextTokenInput.EchoMode = textinput.EchoPassword
+	contextTokenInput.EchoCharacter = '*'
 	contextKindInput.SetValue("registry_v2")
 	contextNameInput.Blur()
 	contextRegistryInput.Blur()
 	contextKindInput.Blur()
 	contextServiceInput.Blur()
+	contextTokenInput.Blur()
 
 	auth.Normalize()
 	if registryHost != "" {
 		registry.ApplyAuthCache(&auth, registryHost)
-		if auth.Kind == "registry_v2" && auth.RegistryV2.RefreshToken != "" {
+		if auth.UsesRegistryV2Auth() && auth.RegistryV2.RefreshToken != "" {
 			auth.RegistryV2.Remember = true
 		}
 	}
-	provider := registry.ProviderForAuth(auth)
+	provider := registry.ProviderForAuth(registryHost, auth)
 
 	username := textinput.New()
 	username.Prompt = ""
 	username.Placeholder = "username"
-	username.CharLimit = 128
+	username.CharLimit = 256
 	username.Blur()
 
 	password := textinput.New()
 	password.Prompt = ""
 	password.Placeholder = "password"
-	password.CharLimit = 128
+	// Robot and service account tokens can be long JWTs.
+	password.CharLimit = 4096
 	password.EchoMode = textinput.EchoPassword
 	password.EchoCharacter = '*'
 	password.Blur()
 
 	remember := false
 	switch auth.Kind {
-	case "registry_v2":
+	case "registry_v2", "acr":
 		username.SetValue(auth.RegistryV2.Username)
 		remember = auth.RegistryV2.Remember
 	case "harbor":
 		username.SetValue(auth.Harbor.Username)
 	}
+	if username.Value() == "" {
+		username.SetValue(provider.AuthUI(auth).UsernamePrefix)
+	}
 	authRequired := provider.NeedsAuthPrompt(auth)
 
 	contextIndex := make(map[string]int, len(contexts))
@@ -100,8 +116,14 @@ func NewModel(registryHost string, auth registry.Auth, logger registry.RequestLo
 	contextSelectionRequired := contextSelectionActive
 	contextFormStartup := registryHost == "" && len(contexts) == 0
 	contextSelectionIndex := 0
+	defaultPath := ""
+	var namespaces []string
 	if i, ok := contextIndex[strings.ToLower(strings.TrimSpace(currentContext))]; ok {
 		contextSelectionIndex = i
+		if registryHost != "" && strings.EqualFold(contexts[i].Host, registryHost) {
+			defaultPath = contexts[i].DefaultPath
+			namespaces = contexts[i].Namespaces
+		}
 	}
 	if contextSelectionActive {
 		status = "Select context to continue"
@@ -140,10 +162,13 @@ func NewModel(registryHost string, auth registry.Auth, logger registry.RequestLo
 			contextFormRegistryInput: contextRegistryInput,
 			contextFormKindInput:     contextKindInput,
 			contextFormServiceInput:  contextServiceInput,
+			contextFormTokenInput:    contextTokenInput,
 			contextFormAnonymous:     true,
 		},
 		configPath:     configPath,
 		registryHost:   registryHost,
+		defaultPath:    defaultPath,
+		namespaces:     namespaces,
 		auth:           auth,
 		provider:       provider,
 		authRequired:   authRequired,
@@ -155,6 +180,7 @@ func NewModel(registryHost string, auth registry.Auth, logger registry.RequestLo
 		table:          tbl,
 		dockerHubInput: dockerHubInput,
 		githubInput:    githubInput,
+		repoInput:      repoInput,
 		commandState: commandState{
 			commandInput: commandInput,
 		},
@@ -167,14 +193,56 @@ func NewModel(registryHost string, auth registry.Auth, logger registry.RequestLo
 	}
 }
 
+// WithSettings applies app-wide defaults loaded from the config file.
+func (m Model) WithSettings(settings config.Settings) Model {
+	m.cleanHistory = settings.CleanHistory
+	m.githubToken = settings.GitHubToken
+	m.dockerHubSkipResolve = settings.DockerHubSkipResolve
+	m.wrapNavigation = settings.WrapNavigation
+	m.stickyFilter = settings.StickyFilter
+	// The welcome screen only greets a first launch with nothing configured,
+	// and never hides a pending prompt such as the config repair.
+	m.welcomeActive = !settings.SeenWelcome && m.contextFormActive && m.contextFormAllowSkip && !m.isConfirmModalActive()
+	m.catalogLimit = settings.CatalogLimit
+	m.tagCountsEnabled = settings.TagCounts
+	m.historyLimit = maxInt(0, settings.HistoryLimit)
+	if settings.AutoRefresh > 0 {
+		m.watchDefault = time.Duration(settings.AutoRefresh) * time.Second
+		m.watchInterval = m.watchDefault
+	}
+	m.relativeTime = strings.EqualFold(strings.TrimSpace(settings.TimeFormat), "relative")
+	// main rejects a bad zone before we get here; fall back to local anyway.
+	m.location, _ = ResolveLocation(settings.TimeZone)
+	if settings.Sort != nil {
+		m.applySortSettings(*settings.Sort)
+	}
+	m.pullTool = "docker"
+	if strings.EqualFold(strings.TrimSpace(settings.PullTool), "podman") {
+		m.pullTool = "podman"
+	}
+	return m
+}
+
+// WithVersion sets the build version shown in the help footer.
+func (m Model) WithVersion(version string) Model {
+	m.version = version
+	return m
+}
+
 func (m Model) Init() tea.Cmd {
 	var cmds []tea.Cmd
 	if m.registryHost != "" && !m.authRequired && !m.isContextSelectionActive() {
 		cmds = append(cmds, initClientCmd(m.registryHost, m.auth, m.logger))
 	}
+	if m.contextSelectionActive {
+		cmds = append(cmds, m.startupContextProbesCmd())
+	}
 	if m.logCh != nil {
 		cmds = append(cmds, listenLogs(m.logCh))
 	}
+	if m.watchInterval > 0 {
+		cmds = append(cmds, autoRefreshCmd(m.watchID, m.watchInterval))
+	}
 	if len(cmds) == 0 {
 		return nil
 	}
@@ -182,6 +250,7 @@ func (m Model) Init() tea.Cmd {
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m.recordConnHealth(msg)
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		return m.updateKeyMsg(msg)
@@ -189,22 +258,56 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.updateMouseMsg(msg)
 	case tea.WindowSizeMsg:
 		return m.updateWindowSizeMsg(msg)
+	case tea.ResumeMsg:
+		return m.updateResumeMsg()
 	case imagesMsg:
 		return m.updateImagesMsg(msg)
+	case imagesPartialMsg:
+		return m.updateImagesPartialMsg(msg)
 	case projectsMsg:
 		return m.updateProjectsMsg(msg)
+	case projectArtifactCountsMsg:
+		return m.updateProjectArtifactCountsMsg(msg)
 	case projectImagesMsg:
 		return m.updateProjectImagesMsg(msg)
 	case tagsMsg:
 		return m.updateTagsMsg(msg)
+	case tagsPartialMsg:
+		return m.updateTagsPartialMsg(msg)
 	case historyMsg:
 		return m.updateHistoryMsg(msg)
+	case historyDiffMsg:
+		return m.updateHistoryDiffMsg(msg)
+	case sourceOpenMsg:
+		return m.updateSourceOpenMsg(msg)
+	case tagPlatformsMsg:
+		return m.updateTagPlatformsMsg(msg)
 	case dockerPullMsg:
 		return m.updateDockerPullMsg(msg)
+	case exportMsg:
+		return m.updateExportMsg(msg)
+	case retagMsg:
+		return m.updateRetagMsg(msg)
+	case deleteTagsMsg:
+		return m.updateDeleteTagsMsg(msg)
+	case imageTagCountMsg:
+		return m.updateImageTagCountMsg(msg)
+	case autoRefreshMsg:
+		return m.updateAutoRefreshMsg(msg)
+	case tagSearchMsg:
+		return m.updateTagSearchMsg(msg)
+	case contextProbeMsg:
+		return m.updateContextProbeMsg(msg)
+	case contextTestMsg:
+		return m.updateContextTestMsg(msg)
 	case dockerHubTagsMsg:
 		return m.updateDockerHubTagsMsg(msg)
+	case retryPendingMsg:
+		return m.updateRetryPendingMsg(msg)
 	case githubTagsMsg:
 		return m.updateGitHubTagsMsg(msg)
+	case githubPackagesMsg:
+		return m.updateGitHubPackagesMsg(msg)
 	case logMsg:
 		return m.updateLogMsg(msg)
 	case initClientMsg:
@@ -215,6 +318,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) View() string {
+	if m.terminalTooSmall() {
+		return m.renderTooSmall()
+	}
 	view := m.renderApp()
 	if m.isContextSelectionActive() {
 		view = m.renderModal(view, m.renderContextSelectionModal())
@@ -225,6 +331,27 @@ func (m Model) View() string {
 	if m.isAuthModalActive() {
 		view = m.renderModal(view, m.renderAuthModal())
 	}
+	if m.welcomeActive {
+		view = m.renderModal(view, m.renderWelcomeModal())
+	}
+	if m.historyDetailActive {
+		view = m.renderModal(view, m.renderHistoryDetailModal())
+	}
+	if m.tagDiffActive {
+		view = m.renderModal(view, m.renderTagDiffModal())
+	}
+	if m.rowPreviewActive {
+		view = m.renderModal(view, m.renderRowPreviewModal())
+	}
+	if m.tagSearchActive {
+		view = m.renderModal(view, m.renderTagSearchModal())
+	}
+	if m.recentContextsActive {
+		view = m.renderModal(view, m.renderRecentContextsModal())
+	}
+	if m.columnTogglesActive {
+		view = m.renderModal(view, m.renderColumnTogglesModal())
+	}
 	if m.isConfirmModalActive() {
 		view = m.renderModal(view, m.renderConfirmModal())
 	}
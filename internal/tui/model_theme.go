@@ -1,8 +1,12 @@
 package tui
 
 import (
+	"strings"
+
 	"github.com/charmbracelet/lipgloss"
 	lipglossv2 "github.com/charmbracelet/lipgloss/v2"
+
+	"github.com/scottbass3/beacon/internal/config"
 )
 
 var (
@@ -15,6 +19,7 @@ var (
 	colorSurface2  = lipgloss.Color("234")
 	colorTitleText = lipgloss.Color("230")
 	colorSuccess   = lipgloss.Color("78")
+	colorDanger    = lipgloss.Color("196")
 )
 
 var (
@@ -26,24 +31,36 @@ var (
 	modalColorSurface2 = lipglossv2.Color("234")
 	modalColorTitle    = lipglossv2.Color("230")
 	modalColorDanger   = lipglossv2.Color("196")
+	modalColorSuccess  = lipglossv2.Color("78")
 )
 
 var (
 	titleStyle             = lipgloss.NewStyle().Foreground(colorTitleText).Background(colorPrimary).Bold(true).Padding(0, 1).MarginRight(1)
 	statusStyle            = lipgloss.NewStyle().Foreground(colorTitleText).Background(colorSurface2).Padding(0, 1)
+	statusErrorStyle       = lipgloss.NewStyle().Foreground(colorTitleText).Background(colorDanger).Bold(true).Padding(0, 1)
 	statusLoadingStyle     = lipgloss.NewStyle().Foreground(colorSurface2).Background(colorSuccess).Bold(true).Padding(0, 1)
 	metaLabelStyle         = lipgloss.NewStyle().Foreground(colorMuted).Bold(true).MarginRight(1)
 	metaValueStyle         = lipgloss.NewStyle().Foreground(colorTitleText).MarginRight(2)
+	authKindStyle          = lipgloss.NewStyle().Foreground(colorMuted).MarginRight(2)
+	connHealthyStyle       = lipgloss.NewStyle().Foreground(colorSuccess).MarginRight(1)
+	connFailingStyle       = lipgloss.NewStyle().Foreground(colorDanger).MarginRight(1)
+	rateChipStyle          = lipgloss.NewStyle().Foreground(colorSurface2).Background(colorSuccess).Padding(0, 1)
+	rateChipLowStyle       = lipgloss.NewStyle().Foreground(colorSurface2).Background(colorAccent).Bold(true).Padding(0, 1)
+	rateChipEmptyStyle     = lipgloss.NewStyle().Foreground(colorTitleText).Background(colorDanger).Bold(true).Padding(0, 1)
 	modeInputStyle         = lipgloss.NewStyle().Foreground(colorAccent).Background(colorSurface2).Padding(0, 1)
 	shortcutHintStyle      = lipgloss.NewStyle().Foreground(colorMuted)
+	suggestionActiveStyle  = lipgloss.NewStyle().Foreground(colorAccent).Bold(true)
+	pageProgressStyle      = lipgloss.NewStyle().Foreground(colorSuccess)
 	helpHeadingStyle       = lipgloss.NewStyle().Foreground(colorAccent).Bold(true)
 	helpItemStyle          = lipgloss.NewStyle().Foreground(colorTitleText)
 	helpFooterStyle        = lipgloss.NewStyle().Foreground(colorMuted)
+	filterMatchStyle       = lipgloss.NewStyle().Foreground(colorAccent).Bold(true).Underline(true)
 	emptyStyle             = lipgloss.NewStyle().Foreground(colorMuted).Italic(true)
 	mainSectionStyle       = lipgloss.NewStyle().BorderStyle(lipgloss.RoundedBorder()).BorderForeground(colorBorder).Padding(0, 1)
 	mainSectionTitleStyle  = lipgloss.NewStyle().Foreground(colorSurface2).Background(colorAccent).Bold(true).Padding(0, 2)
 	mainSectionTitleLine   = lipgloss.NewStyle()
 	topSectionStyle        = lipgloss.NewStyle().BorderStyle(lipgloss.RoundedBorder()).BorderForeground(colorBorder).Padding(0, 1)
+	compactSectionStyle    = lipgloss.NewStyle()
 	logTitleStyle          = lipgloss.NewStyle().Foreground(colorTitleText).Background(colorPrimary).Bold(true).Padding(0, 1)
 	logBoxStyle            = lipgloss.NewStyle().BorderStyle(lipgloss.RoundedBorder()).BorderForeground(colorBorder).Background(colorSurface).Padding(0, 1)
 	modalBackdropStyle     = lipglossv2.NewStyle().Foreground(modalColorMuted).Background(modalColorSurface2).Faint(true)
@@ -62,6 +79,58 @@ var (
 	modalOptionFocusStyle  = lipglossv2.NewStyle().Foreground(modalColorSurface2).Background(modalColorAccent).BorderStyle(lipglossv2.NormalBorder()).BorderForeground(modalColorAccent).BorderBackground(modalColorSurface).Bold(true).Padding(0, 1)
 	modalOptionMutedStyle  = lipglossv2.NewStyle().Foreground(modalColorMuted)
 	modalOptionErrorStyle  = lipglossv2.NewStyle().Foreground(modalColorDanger).Faint(true)
+	modalSuccessStyle      = lipglossv2.NewStyle().Foreground(modalColorSuccess)
 	modalHelpStyle         = lipglossv2.NewStyle().Foreground(modalColorMuted)
 	modalDividerStyle      = lipglossv2.NewStyle().Foreground(modalColorBorder)
+
+	// filterMatchSelectedStyle keeps the cursor row's colors.
+	filterMatchSelectedStyle = lipgloss.NewStyle().Foreground(colorSelected).Background(colorAccent).Bold(true).Underline(true)
 )
+
+// statusColors are the semantic colors for health and severity: connection
+// dot, rate-limit chip, error and loading statuses and probe results. The
+// defaults match the styles above; settings can override them with
+// SetStatusColors.
+var statusColors = config.StatusColors{OK: "78", Warn: "214", Critical: "196", Info: "78"}
+
+// SetStatusColors overrides the semantic status colors; empty fields keep
+// the defaults.
+func SetStatusColors(colors config.StatusColors) {
+	for _, pair := range []struct {
+		dst *string
+		src string
+	}{
+		{&statusColors.OK, colors.OK},
+		{&statusColors.Warn, colors.Warn},
+		{&statusColors.Critical, colors.Critical},
+		{&statusColors.Info, colors.Info},
+	} {
+		if value := strings.TrimSpace(pair.src); value != "" {
+			*pair.dst = value
+		}
+	}
+	applyStatusColors()
+}
+
+func applyStatusColors() {
+	ok := lipgloss.Color(statusColors.OK)
+	warn := lipgloss.Color(statusColors.Warn)
+	critical := lipgloss.Color(statusColors.Critical)
+	info := lipgloss.Color(statusColors.Info)
+	modalOK := lipglossv2.Color(statusColors.OK)
+	modalCritical := lipglossv2.Color(statusColors.Critical)
+
+	statusErrorStyle = statusErrorStyle.Background(critical)
+	statusLoadingStyle = statusLoadingStyle.Background(info)
+	connHealthyStyle = connHealthyStyle.Foreground(ok)
+	connFailingStyle = connFailingStyle.Foreground(critical)
+	rateChipStyle = rateChipStyle.Background(ok)
+	rateChipLowStyle = rateChipLowStyle.Background(warn)
+	rateChipEmptyStyle = rateChipEmptyStyle.Background(critical)
+	pageProgressStyle = pageProgressStyle.Foreground(ok)
+	modalErrorStyle = modalErrorStyle.Foreground(modalCritical)
+	modalDangerButtonStyle = modalDangerButtonStyle.Foreground(modalCritical).BorderForeground(modalCritical)
+	modalDangerFocusStyle = modalDangerFocusStyle.Background(modalCritical).BorderForeground(modalCritical)
+	modalOptionErrorStyle = modalOptionErrorStyle.Foreground(modalCritical)
+	modalSuccessStyle = modalSuccessStyle.Foreground(modalOK)
+}
@@ -1,10 +1,12 @@
 package tui
 
 import (
+	"context"
 	"time"
 
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/scottbass3/beacon/internal/registry"
 )
@@ -18,6 +20,7 @@ const (
 	FocusHistory
 	FocusDockerHubTags
 	FocusGitHubTags
+	FocusGitHubPackages
 )
 
 type confirmAction int
@@ -25,6 +28,9 @@ type confirmAction int
 const (
 	confirmActionNone confirmAction = iota
 	confirmActionQuit
+	confirmActionRetag
+	confirmActionDeleteTags
+	confirmActionRepairConfig
 )
 
 const (
@@ -38,6 +44,11 @@ const (
 	mainSectionBorderLines  = 2
 	mainSectionHChromeChars = 4
 	defaultRenderWidth      = 80
+	maxPlatformLookups      = 50
+	platformLookupWorkers   = 4
+	// dockerHubFallbackRetry is used when a 429 carries neither Retry-After
+	// nor a reset time.
+	dockerHubFallbackRetry = 30 * time.Second
 )
 
 type Model struct {
@@ -47,12 +58,16 @@ type Model struct {
 	status  string
 	focus   Focus
 	context string
+	// errorStatus is the last text set by setErrorStatus; the status line is
+	// styled as an error while it still shows that text.
+	errorStatus string
 
 	contextSelectionState
 	contextFormState
 	confirmState
 
 	configPath string
+	version    string
 
 	registryHost   string
 	registryClient registry.Client
@@ -60,21 +75,66 @@ type Model struct {
 	provider       registry.Provider
 	authRequired   bool
 	authError      string
-	authFocus      int
-	usernameInput  textinput.Model
-	passwordInput  textinput.Model
-	remember       bool
-	logger         registry.RequestLogger
+	// authRejected is set when an anonymous context was answered with 401
+	// and the login modal opened in its place.
+	authRejected  bool
+	authFocus     int
+	usernameInput textinput.Model
+	passwordInput textinput.Model
+	remember      bool
+	logger        registry.RequestLogger
 
 	images   []registry.Image
 	projects []projectInfo
 	tags     []registry.Tag
 	history  []registry.HistoryEntry
+	// imagesStreaming is set between the first imagesPartialMsg and the
+	// final imagesMsg of a streamed listing.
+	imagesStreaming bool
+	// tagsStreaming is the tag-list counterpart of imagesStreaming.
+	tagsStreaming bool
+	// cancelTags stops the running tag stream once it is superseded or the
+	// user leaves the image; tagsStream tells its messages from older ones.
+	cancelTags context.CancelFunc
+	tagsStream int
+	// tagsLoadErr is the outcome of the last tag listing, so an empty list
+	// is only called an empty repository after a successful load.
+	tagsLoadErr error
+	// tagsQuery is the filter the current tag list was fetched with
+	// server-side; empty when the full list is loaded.
+	tagsQuery string
+	// imagesNext is the catalog cursor of the next batch when the catalog was
+	// loaded with a cap.
+	imagesNext        string
+	imagesLoadingMore bool
+	catalogLimit      int
 
 	selectionState
 
 	filterActive bool
 	filterInput  textinput.Model
+	// repoPromptActive shows repoInput for opening a repository by name,
+	// offered when the registry refuses to list its catalog.
+	repoPromptActive bool
+	repoInput        textinput.Model
+	// catalogForbidden is set when the catalog is refused or disabled.
+	catalogForbidden bool
+	hideArtifacts    bool
+	// minSizeBytes hides tags and layers smaller than this when non-zero.
+	minSizeBytes int64
+	cleanHistory bool
+	// historyCollapse folds runs of empty layers; historyExpanded holds the
+	// first entry index of each run the user unfolded.
+	historyCollapse bool
+	historyExpanded map[int]bool
+	// historyLimit caps the History view to the most recent entries until
+	// historyShowAll is set from the "+N older layers" row.
+	historyLimit   int
+	historyShowAll bool
+
+	historyDetailActive bool
+	historyDetailIndex  int
+	historyDetailOffset int
 
 	table table.Model
 
@@ -89,6 +149,12 @@ type Model struct {
 	dockerHubRateLimit  registry.DockerHubRateLimit
 	dockerHubRetryUntil time.Time
 	dockerHubLoading    bool
+	// dockerHubRetryPending is set while a rate-limited next-page load waits
+	// for dockerHubRetryUntil before resuming automatically.
+	dockerHubRetryPending   bool
+	dockerHubRetryForFilter bool
+	// dockerHubSkipResolve opens bare names as library/<name> directly.
+	dockerHubSkipResolve bool
 
 	githubActive     bool
 	githubPrevFocus  Focus
@@ -99,20 +165,76 @@ type Model struct {
 	githubTags       []registry.Tag
 	githubNext       string
 	githubLoading    bool
+	githubOwner      string
+	githubPackages   []registry.GitHubPackage
+	githubToken      string
+	// externalFilterPages counts the pages chained to satisfy a filter, for
+	// the progress line; it resets once the chain stops.
+	externalFilterPages int
+	// externalPendingTag opens in history once a pasted image:tag search loads.
+	externalPendingTag string
+
+	pullTool    string
+	defaultPath string
+	// namespaces limits the listed repositories and projects of the active
+	// context; empty lists everything.
+	namespaces []string
+	// pendingDefaultPath is the part of defaultPath still to open after connect.
+	pendingDefaultPath string
+	// pendingTag is selected once the tags of pendingDefaultPath load.
+	pendingTag     string
+	wrapNavigation bool
+	// stickyFilter keeps the filter text when moving between lists.
+	stickyFilter bool
+	relativeTime bool
+	// location is the zone absolute timestamps are shown in; nil is local.
+	location *time.Location
+	// projectsByCount sorts the Projects view by image count instead of name.
+	projectsByCount bool
+	// viewSorts remembers the sort chosen per view for the session.
+	viewSorts map[Focus]sortKey
 
 	commandState
-	helpActive       bool
-	contexts         []ContextOption
-	contextNameIndex map[string]int
-	tableColumns     []table.Column
-	tableYOffset     int
-
-	debug  bool
-	logCh  <-chan string
-	logs   []string
-	logMax int
+	columnToggleState
+	tagDiffState
+	tagSearchState
+	tagCountState
+	helpActive bool
+	helpTopic  string
+	contexts   []ContextOption
+	// recentContexts holds context names, most recently used first.
+	recentContexts       []string
+	recentContextsActive bool
+	recentContextsIndex  int
+	welcomeActive        bool
+	contextNameIndex     map[string]int
+	tableColumns         []table.Column
+	tableYOffset         int
+
+	debug bool
+	logCh <-chan string
+	// compact drops the meta line and section borders to fit more rows.
+	compact bool
+	// printSelection quits on Enter over a tag, keeping its reference in
+	// selection for --print-selection.
+	printSelection bool
+	selection      string
+	logs           []string
+	logMax         int
 
 	loadingCount int
+
+	// watchInterval re-runs refreshCurrent on a timer while non-zero.
+	// watchID drops ticks scheduled before the last toggle.
+	watchInterval time.Duration
+	watchDefault  time.Duration
+	watchID       int
+	// watchRefreshing is set while a tick's reload is in flight, so its
+	// result replaces the list without moving the filter or cursor.
+	watchRefreshing bool
+
+	// conn is the outcome of the last request to the connected registry.
+	conn connHealth
 }
 
 type contextSelectionState struct {
@@ -120,6 +242,7 @@ type contextSelectionState struct {
 	contextSelectionRequired bool
 	contextSelectionIndex    int
 	contextSelectionError    string
+	contextProbes            map[string]contextProbe
 }
 
 type contextFormState struct {
@@ -134,6 +257,7 @@ type contextFormState struct {
 	contextFormRegistryInput   textinput.Model
 	contextFormKindInput       textinput.Model
 	contextFormServiceInput    textinput.Model
+	contextFormTokenInput      textinput.Model
 	contextFormAnonymous       bool
 }
 
@@ -142,6 +266,13 @@ type confirmState struct {
 	confirmTitle   string
 	confirmMessage string
 	confirmFocus   int
+	// retag* hold the pending :retag while its confirmation is open.
+	retagImage string
+	retagFrom  string
+	retagTo    string
+	// deleteImage and deleteTags hold the pending batch delete.
+	deleteImage string
+	deleteTags  []string
 }
 
 type selectionState struct {
@@ -151,6 +282,69 @@ type selectionState struct {
 	hasSelectedImage   bool
 	selectedTag        registry.Tag
 	hasSelectedTag     bool
+	// historyDigest is the manifest digest the inspected tag resolved to.
+	historyDigest string
+	// historyLabels are the image config labels of the inspected tag.
+	historyLabels map[string]string
+	// historyAnnotations are the manifest annotations of the inspected tag.
+	historyAnnotations map[string]string
+}
+
+type tagDiffState struct {
+	hasMarkedTag   bool
+	markedTag      string
+	markedTagImage string
+	markedTagFocus Focus
+
+	// tagSelection holds the tags picked with space for batch operations;
+	// it belongs to tagSelectionImage only.
+	tagSelection      map[string]bool
+	tagSelectionImage string
+
+	rowPreviewActive  bool
+	rowPreviewHeaders []string
+	rowPreviewCells   []string
+	rowPreviewName    string
+
+	tagDiffActive  bool
+	tagDiffLoading bool
+	tagDiffErr     error
+	tagDiffImage   string
+	tagDiffBase    string
+	tagDiffTarget  string
+	tagDiffLines   []historyDiffLine
+	tagDiffOffset  int
+}
+
+type tagSearchState struct {
+	tagSearchActive   bool
+	tagSearchRunning  bool
+	tagSearchID       int
+	tagSearchCancel   context.CancelFunc
+	tagSearchTag      string
+	tagSearchAll      bool
+	tagSearchTotal    int
+	tagSearchSearched int
+	tagSearchResults  []tagSearchResult
+	tagSearchSkipped  []string
+	tagSearchIndex    int
+}
+
+// tagCountState tracks the background tag-count lookups for catalog
+// repositories; tagCountGen changes with every fresh catalog load.
+type tagCountState struct {
+	tagCountsEnabled bool
+	tagCountGen      int
+	tagCountCtx      context.Context
+	tagCountCancel   context.CancelFunc
+}
+
+type columnToggleState struct {
+	columnTogglesActive bool
+	columnTogglesIndex  int
+	hiddenColumns       map[string]bool
+	// showDigest adds the Digest column to tag views (:digests).
+	showDigest bool
 }
 
 type commandState struct {
@@ -167,6 +361,20 @@ type commandState struct {
 type imagesMsg struct {
 	images []registry.Image
 	err    error
+	// client, next and appendPage are set for capped catalog batches.
+	client     registry.Client
+	next       string
+	appendPage bool
+}
+
+// imagesPartialMsg is one batch of a streamed image listing. next waits for
+// the following batch or the final imagesMsg.
+type imagesPartialMsg struct {
+	client registry.Client
+	images []registry.Image
+	// total is the expected size of the full listing, or 0 when unknown.
+	total int
+	next  tea.Cmd
 }
 
 type projectsMsg struct {
@@ -174,6 +382,14 @@ type projectsMsg struct {
 	err      error
 }
 
+// projectArtifactCountsMsg carries the per-project artifact totals, loaded
+// after the project list because they take a full repository listing.
+type projectArtifactCountsMsg struct {
+	client registry.Client
+	counts map[string]int
+	err    error
+}
+
 type projectImagesMsg struct {
 	project string
 	images  []registry.Image
@@ -181,15 +397,113 @@ type projectImagesMsg struct {
 }
 
 type tagsMsg struct {
-	tags []registry.Tag
-	err  error
+	image string
+	// query is the server-side tag filter the list was fetched with.
+	query string
+	tags  []registry.Tag
+	err   error
+	// stream identifies the tag stream that sent the list; zero for
+	// unstreamed loads.
+	stream int
+}
+
+// tagsPartialMsg is one page of a streamed tag listing. next waits for the
+// following page or the final tagsMsg.
+type tagsPartialMsg struct {
+	image  string
+	query  string
+	tags   []registry.Tag
+	next   tea.Cmd
+	stream int
 }
 
 type historyMsg struct {
-	history []registry.HistoryEntry
+	history     []registry.HistoryEntry
+	digest      string
+	labels      map[string]string
+	annotations map[string]string
+	rateLimit   registry.DockerHubRateLimit
+	err         error
+}
+
+type deleteTagsMsg struct {
+	image   string
+	deleted []string
+	failed  []string
 	err     error
 }
 
+type retagMsg struct {
+	image string
+	from  string
+	to    string
+	err   error
+}
+
+type sourceOpenMsg struct {
+	url string
+	err error
+}
+
+type historyDiffMsg struct {
+	image         string
+	base          string
+	target        string
+	baseHistory   []registry.HistoryEntry
+	targetHistory []registry.HistoryEntry
+	err           error
+}
+
+// tagPlatformsMsg has an entry for every tag looked up; failed lookups carry
+// platformLookupFailed so they are not picked again. artifactTypes holds the
+// types read from the config media type of single manifests.
+type tagPlatformsMsg struct {
+	focus         Focus
+	image         string
+	platforms     map[string]int
+	artifactTypes map[string]string
+}
+
+// tagSearchMsg carries progress deltas from a running search. The channel is
+// closed when the search ends, which is reported as done.
+type tagSearchMsg struct {
+	id       int
+	total    int
+	searched int
+	found    []tagSearchResult
+	skipped  string
+	done     bool
+	ch       <-chan tagSearchMsg
+}
+
+// imageTagCountMsg streams one repository's tag count; count is -1 when the
+// lookup failed.
+type imageTagCountMsg struct {
+	gen   int
+	image string
+	count int
+	done  bool
+	ch    <-chan imageTagCountMsg
+}
+
+type contextProbeMsg struct {
+	host string
+	err  error
+}
+
+type contextTestMsg struct {
+	name      string
+	latency   time.Duration
+	noCatalog bool
+	err       error
+}
+
+type exportMsg struct {
+	path string
+	rows int
+	err  error
+}
+
 type dockerPullMsg struct {
 	reference string
 	err       error
@@ -200,22 +514,38 @@ type dockerHubTagsMsg struct {
 	image      string
 	next       string
 	rateLimit  registry.DockerHubRateLimit
+	digest     string
 	appendPage bool
 	retryAfter time.Duration
 	err        error
 }
 
+// retryPendingMsg ticks while a deferred Docker Hub page load waits out the
+// rate limit window. image and next identify the page it was scheduled for.
+type retryPendingMsg struct {
+	image string
+	next  string
+}
+
 type githubTagsMsg struct {
 	tags       []registry.Tag
 	image      string
 	next       string
+	digest     string
 	appendPage bool
 	err        error
 }
 
+type githubPackagesMsg struct {
+	owner    string
+	packages []registry.GitHubPackage
+	err      error
+}
+
 type projectInfo struct {
-	Name       string
-	ImageCount int
+	Name          string
+	ImageCount    int
+	ArtifactCount int
 }
 
 type helpEntry struct {
@@ -228,6 +558,11 @@ type commandHelp struct {
 	Usage   string
 }
 
+// autoRefreshMsg is the :watch tick; id is the watchID it was scheduled for.
+type autoRefreshMsg struct {
+	id int
+}
+
 type initClientMsg struct {
 	client registry.Client
 	err    error
@@ -236,7 +571,9 @@ type initClientMsg struct {
 type logMsg string
 
 type ContextOption struct {
-	Name string
-	Host string
-	Auth registry.Auth
+	Name        string
+	Host        string
+	Auth        registry.Auth
+	DefaultPath string
+	Namespaces  []string
 }
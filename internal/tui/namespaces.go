@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+// inNamespaces reports whether a repository or project path lies under one
+// of the context's namespaces. Without namespaces everything is listed.
+func (m Model) inNamespaces(path string) bool {
+	if len(m.namespaces) == 0 {
+		return true
+	}
+	path = strings.Trim(path, "/")
+	for _, namespace := range m.namespaces {
+		if path == namespace || strings.HasPrefix(path, namespace+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// containsNamespace reports whether a namespace lies below path, so a
+// project stays listed for a namespace like project/team.
+func (m Model) containsNamespace(path string) bool {
+	path = strings.Trim(path, "/")
+	for _, namespace := range m.namespaces {
+		if strings.HasPrefix(namespace, path+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// imagesInNamespaces is a display filter only: :image still opens any
+// repository by name.
+func (m Model) imagesInNamespaces() []registry.Image {
+	if len(m.namespaces) == 0 {
+		return m.images
+	}
+	filtered := make([]registry.Image, 0, len(m.images))
+	for _, image := range m.images {
+		if m.inNamespaces(image.Name) {
+			filtered = append(filtered, image)
+		}
+	}
+	return filtered
+}
+
+func (m Model) projectsInNamespaces() []projectInfo {
+	if len(m.namespaces) == 0 {
+		return m.projects
+	}
+	filtered := make([]projectInfo, 0, len(m.projects))
+	for _, project := range m.projects {
+		if m.inNamespaces(project.Name) || m.containsNamespace(project.Name) {
+			filtered = append(filtered, project)
+		}
+	}
+	return filtered
+}
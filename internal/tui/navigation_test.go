@@ -1,8 +1,14 @@
 package tui
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+
 	"github.com/scottbass3/beacon/internal/registry"
 )
 
@@ -46,3 +52,232 @@ func TestHandleEscapeFromImagesWithProjects(t *testing.T) {
 		t.Fatalf("expected selected project to be cleared")
 	}
 }
+
+func TestHistoryDetailScrollsTallCommands(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.width = 120
+	m.height = 20
+	m.focus = FocusHistory
+	steps := make([]string, 60)
+	for i := range steps {
+		steps[i] = fmt.Sprintf("echo step-%02d", i)
+	}
+	m.history = []registry.HistoryEntry{{CreatedBy: "RUN " + strings.Join(steps, " &&\n    ")}}
+	m.historyLabels = map[string]string{"maintainer": "ops"}
+	m.syncTable()
+
+	updated, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	view := m.renderHistoryDetailModal()
+	if lineCount(view) > m.height {
+		t.Fatalf("expected the card to fit %d lines, got %d:\n%s", m.height, lineCount(view), view)
+	}
+	if !strings.Contains(view, "step-00") || strings.Contains(view, "step-59") || !strings.Contains(view, "esc/enter close") {
+		t.Fatalf("expected the top of the command and the close hint, got:\n%s", view)
+	}
+
+	updated, _ = m.updateKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	if next := updated.(Model); next.historyDetailOffset != 1 {
+		t.Fatalf("expected j to scroll one line, got offset %d", next.historyDetailOffset)
+	}
+	updated, _ = m.updateKeyMsg(tea.KeyMsg{Type: tea.KeyPgDown})
+	if next := updated.(Model); next.historyDetailOffset != next.historyDetailVisibleLines() {
+		t.Fatalf("expected pgdown to scroll a page, got offset %d", next.historyDetailOffset)
+	}
+	updated, _ = m.updateKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("G")})
+	m = updated.(Model)
+	view = m.renderHistoryDetailModal()
+	if !strings.Contains(view, "step-59") || !strings.Contains(view, "maintainer = ops") || lineCount(view) > m.height {
+		t.Fatalf("expected the end of the command and the labels, got:\n%s", view)
+	}
+	updated, _ = m.updateKeyMsg(tea.KeyMsg{Type: tea.KeyPgUp})
+	if next := updated.(Model); next.historyDetailOffset != m.historyDetailOffset-m.historyDetailVisibleLines() {
+		t.Fatalf("expected pgup to scroll back a page, got offset %d", next.historyDetailOffset)
+	}
+}
+
+func TestEnterOnHistoryOpensDetailModal(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.focus = FocusHistory
+	long := "/bin/sh -c apt-get update && apt-get install -y " + strings.Repeat("package ", 30)
+	m.history = []registry.HistoryEntry{
+		{CreatedBy: "/bin/sh -c #(nop) CMD [\"sh\"]"},
+		{CreatedBy: long},
+	}
+	m.syncTable()
+	m.tableSetCursor(1)
+
+	updated, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	next := updated.(Model)
+	if !next.historyDetailActive || next.historyDetailIndex != 1 {
+		t.Fatalf("expected detail modal for entry 1, got active=%v index=%d", next.historyDetailActive, next.historyDetailIndex)
+	}
+	if view := next.View(); !strings.Contains(view, "Layer 2 of 2") {
+		t.Fatalf("expected detail modal in view")
+	}
+
+	updated, _ = next.updateKeyMsg(tea.KeyMsg{Type: tea.KeyEsc})
+	if updated.(Model).historyDetailActive {
+		t.Fatalf("expected esc to close the detail modal")
+	}
+}
+
+func TestWrapNavigationAtListEdges(t *testing.T) {
+	down := tea.KeyMsg{Type: tea.KeyDown}
+	up := tea.KeyMsg{Type: tea.KeyUp}
+
+	m := newMouseTestModel(t)
+	m.tableGotoBottom()
+	m.handleTableNavKey(down)
+	if got := m.table.Cursor(); got != 5 {
+		t.Fatalf("expected clamp at last row without wrap, got %d", got)
+	}
+
+	m.wrapNavigation = true
+	m.handleTableNavKey(down)
+	if got := m.table.Cursor(); got != 0 {
+		t.Fatalf("expected wrap to first row, got %d", got)
+	}
+	m.handleTableNavKey(up)
+	if got := m.table.Cursor(); got != 5 {
+		t.Fatalf("expected wrap to last row, got %d", got)
+	}
+
+	m.dockerHubActive = true
+	m.focus = FocusDockerHubTags
+	m.dockerHubImage = "library/nginx"
+	m.dockerHubTags = []registry.Tag{{Name: "a"}, {Name: "b"}}
+	m.dockerHubNext = "https://hub.docker.com/next"
+	m.syncTable()
+	m.tableGotoBottom()
+	m.handleTableNavKey(down)
+	if got := m.table.Cursor(); got != 1 {
+		t.Fatalf("expected no wrap while more pages can load, got %d", got)
+	}
+}
+
+func TestGroupRowJumps(t *testing.T) {
+	rows := []table.Row{{"alpine"}, {"Apache"}, {"busybox"}, {"team/api"}, {"team/web"}, {"tools/ci"}}
+	tests := []struct {
+		name   string
+		cursor int
+		next   int
+		prev   int
+	}{
+		{name: "first group", cursor: 0, next: 2, prev: 0},
+		{name: "inside letter group", cursor: 1, next: 2, prev: 0},
+		{name: "single row group", cursor: 2, next: 3, prev: 0},
+		{name: "namespace group", cursor: 4, next: 5, prev: 2},
+		{name: "last group", cursor: 5, next: 5, prev: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextGroupRow(rows, tt.cursor); got != tt.next {
+				t.Fatalf("nextGroupRow(%d) = %d, want %d", tt.cursor, got, tt.next)
+			}
+			if got := prevGroupRow(rows, tt.cursor); got != tt.prev {
+				t.Fatalf("prevGroupRow(%d) = %d, want %d", tt.cursor, got, tt.prev)
+			}
+		})
+	}
+}
+
+func TestEscDismissesErrorStatusBeforeGoingBack(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.focus = FocusTags
+	m.hasSelectedImage = true
+	m.selectedImage = registry.Image{Name: "team/service"}
+
+	updated, _ := m.Update(tagsMsg{err: errors.New("boom")})
+	m = updated.(Model)
+	if !m.statusIsError() {
+		t.Fatalf("expected %q to be flagged as an error", m.status)
+	}
+	if top := m.renderTopSection(); !strings.Contains(top, "esc to dismiss") {
+		t.Fatalf("expected the dismiss hint next to the error, got %q", top)
+	}
+
+	updated, _ = m.handleKey(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.statusIsError() || m.status != "" || m.focus != FocusTags {
+		t.Fatalf("expected esc to clear the error and stay on tags, got status=%q focus=%v", m.status, m.focus)
+	}
+
+	updated, _ = m.handleKey(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.focus != FocusImages {
+		t.Fatalf("expected a second esc to go back to images, got %v", m.focus)
+	}
+
+	m.setErrorStatus("Error loading images: boom")
+	m.status = "Loaded 3 images"
+	if m.statusIsError() {
+		t.Fatalf("expected a newer status to replace the error styling")
+	}
+}
+
+func TestExpandRowShowsFullImagePath(t *testing.T) {
+	auth := registry.Auth{Kind: "harbor"}
+	auth.Harbor.Anonymous = true
+	m := NewModel("https://harbor.example.com", auth, nil, false, nil, nil, "", "")
+	m.focus = FocusImages
+	m.hasSelectedProject = true
+	m.selectedProject = "prod"
+	long := "prod/platform/services/" + strings.Repeat("payments-", 8) + "api"
+	m.images = []registry.Image{{Name: "prod/web"}, {Name: long}}
+	m.syncTable()
+	m.tableSetCursor(1)
+
+	updated, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	next := updated.(Model)
+	if !next.rowPreviewActive || next.rowPreviewName != long {
+		t.Fatalf("expected the full path %q, got %q", long, next.rowPreviewName)
+	}
+	if next.rowPreviewCells[0] != strings.TrimPrefix(long, "prod/") {
+		t.Fatalf("expected the untruncated Name cell, got %q", next.rowPreviewCells[0])
+	}
+
+	updated, _ = next.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if updated.(Model).rowPreviewActive {
+		t.Fatalf("expected esc to close the preview")
+	}
+}
+
+func TestStickyFilterSurvivesNavigation(t *testing.T) {
+	for _, sticky := range []bool{false, true} {
+		auth := registry.Auth{Kind: "registry_v2"}
+		auth.RegistryV2.Anonymous = true
+		m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+		m.registryClient = &fakeTagSearchClient{}
+		m.stickyFilter = sticky
+		m.focus = FocusImages
+		m.images = []registry.Image{{Name: "alpine"}, {Name: "nginx"}}
+		m.filterInput.SetValue("alpine")
+		m.syncTable()
+
+		m.handleEnter()
+		updated, _ := m.updateTagsMsg(tagsMsg{image: "alpine", tags: []registry.Tag{{Name: "3.19-alpine"}, {Name: "3.19"}}})
+		m = updated.(Model)
+		wantFilter, wantRows := "", 2
+		if sticky {
+			wantFilter, wantRows = "alpine", 1
+		}
+		if got := m.filterInput.Value(); got != wantFilter {
+			t.Fatalf("sticky=%v: expected filter %q on tags, got %q", sticky, wantFilter, got)
+		}
+		if rows := len(m.listView().rows); rows != wantRows {
+			t.Fatalf("sticky=%v: expected %d tag rows, got %d", sticky, wantRows, rows)
+		}
+
+		m.handleEscape()
+		if m.focus != FocusImages || m.filterInput.Value() != wantFilter {
+			t.Fatalf("sticky=%v: expected images with filter %q, got %q", sticky, wantFilter, m.filterInput.Value())
+		}
+	}
+}
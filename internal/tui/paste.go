@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+// pastedText reports the text of a bracketed paste. Fast typing can also
+// deliver several runes in one key message, so only the paste flag counts.
+func pastedText(msg tea.KeyMsg) (string, bool) {
+	if msg.Type != tea.KeyRunes || !msg.Paste {
+		return "", false
+	}
+	return string(msg.Runes), true
+}
+
+// pinnedReference reports whether reference names a tag or digest, returning
+// the tag when it has one.
+func pinnedReference(reference string) (string, bool) {
+	reference = strings.TrimSpace(reference)
+	if at := strings.Index(reference, "@"); at != -1 {
+		return "", at < len(reference)-1
+	}
+	colon := strings.LastIndex(reference, ":")
+	if colon == -1 || colon < strings.LastIndex(reference, "/") {
+		return "", false
+	}
+	tag := strings.TrimSpace(reference[colon+1:])
+	return tag, tag != ""
+}
+
+// pasteExternalReference puts pasted text in the search input. A pinned
+// image:tag or image@digest reference is searched right away and its history
+// opened once the tags load.
+func (m *Model) pasteExternalReference(kind externalModeKind, text string) tea.Cmd {
+	text = strings.TrimSpace(text)
+	m.setExternalInputValue(kind, text)
+	m.setExternalInputFocus(kind, true)
+	cmd := m.focusExternalInput(kind)
+	m.externalInputCursorEnd(kind)
+
+	tag, pinned := pinnedReference(text)
+	if !pinned {
+		return cmd
+	}
+	search := m.searchExternal(kind, text)
+	if search == nil {
+		return cmd
+	}
+	m.externalPendingTag = tag
+	return search
+}
+
+// openExternalPendingTag opens the history of the tag named by a pasted
+// reference, even when it is not on the first page of tags.
+func (m *Model) openExternalPendingTag(kind externalModeKind) tea.Cmd {
+	tag := m.externalPendingTag
+	m.externalPendingTag = ""
+	if tag == "" {
+		return nil
+	}
+	image := strings.TrimSpace(m.externalImage(kind))
+	tags := m.externalTags(kind)
+	list := m.listView()
+	for cursor, index := range list.indices {
+		if index >= 0 && index < len(tags) && tags[index].Name == tag {
+			m.tableSetCursor(cursor)
+			return m.openExternalHistoryFor(kind, image, tags[index])
+		}
+	}
+	return m.openExternalHistoryFor(kind, image, registry.Tag{Name: tag})
+}
+
+// openFilterWith opens the filter prefilled with pasted text so it is not
+// read as a burst of shortcuts.
+func (m *Model) openFilterWith(text string) {
+	m.filterActive = true
+	m.filterInput.Focus()
+	m.filterInput.SetValue(strings.TrimSpace(text))
+	m.filterInput.CursorEnd()
+	m.tableSetCursor(0)
+	m.syncTable()
+}
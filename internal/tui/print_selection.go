@@ -0,0 +1,26 @@
+package tui
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// WithPrintSelection makes Beacon a picker: Enter on a tag quits, and
+// Selection returns the tag's reference for the caller to print.
+func (m Model) WithPrintSelection(enabled bool) Model {
+	m.printSelection = enabled
+	return m
+}
+
+// Selection is the reference picked in print-selection mode, or "" when
+// Beacon quit without one.
+func (m Model) Selection() string {
+	return m.selection
+}
+
+func (m Model) pickSelectedTag() (tea.Model, tea.Cmd) {
+	reference, ok := m.selectedTagPullCommandReference()
+	if !ok {
+		m.status = "No tag selected"
+		return m, nil
+	}
+	m.selection = reference
+	return m, tea.Quit
+}
@@ -41,6 +41,58 @@ func (m Model) selectedTagReferenceForPull() (string, bool) {
 	}
 }
 
+// selectedTagPullCommandReference is the fully qualified reference used in
+// copied pull commands: GHCR images get their ghcr.io host and Docker Hub
+// official images drop the library/ namespace.
+func (m Model) selectedTagPullCommandReference() (string, bool) {
+	image, tag, ok := m.selectedTagImageAndTag()
+	if !ok {
+		return "", false
+	}
+	if _, ok := formatTagReference(image, tag); !ok {
+		return "", false
+	}
+	switch m.focus {
+	case FocusDockerHubTags:
+		return registry.DockerHubPullReference(image, tag), true
+	case FocusGitHubTags:
+		return registry.GitHubPullReference(image, tag), true
+	default:
+		return m.selectedTagReferenceForPull()
+	}
+}
+
+// selectedTagKubernetesReference is the reference for a manifest's image:
+// field, always with a registry host.
+func (m Model) selectedTagKubernetesReference() (string, bool) {
+	image, tag, ok := m.selectedTagImageAndTag()
+	if !ok {
+		return "", false
+	}
+	if _, ok := formatTagReference(image, tag); !ok {
+		return "", false
+	}
+	if m.focus == FocusDockerHubTags {
+		return registry.DockerHubQualifiedReference(image, tag), true
+	}
+	return m.selectedTagPullCommandReference()
+}
+
+func (m *Model) copySelectedPullCommand() bool {
+	reference, ok := m.selectedTagPullCommandReference()
+	if !ok {
+		m.status = "No tag selected to copy"
+		return false
+	}
+	command := registry.PullCommandWith(m.pullTool, reference)
+	if err := writeClipboard(command); err != nil {
+		m.setErrorStatus(fmt.Sprintf("Failed to copy %s: %v", command, err))
+		return false
+	}
+	m.status = fmt.Sprintf("Copied %s", command)
+	return true
+}
+
 func pullSelectedTagCmd(reference string) tea.Cmd {
 	return func() tea.Msg {
 		return dockerPullMsg{reference: reference, err: runDockerPull(reference)}
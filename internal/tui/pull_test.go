@@ -161,3 +161,78 @@ func TestPullSelectedTagWithDockerWithoutSelection(t *testing.T) {
 		t.Fatalf("expected no selection status, got %q", next.status)
 	}
 }
+
+func TestPrintSelectionQuitsWithReference(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "").WithPrintSelection(true)
+	m.registryClient = &fakeTagSearchClient{}
+	m.focus = FocusTags
+	m.selectedImage = registry.Image{Name: "team/app"}
+	m.hasSelectedImage = true
+	m.tags = []registry.Tag{{Name: "1.2.3"}}
+	m.syncTable()
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatalf("expected a quit command")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Fatalf("expected enter to quit")
+	}
+	if got := m.Selection(); got != "registry.example.com/team/app:1.2.3" {
+		t.Fatalf("unexpected selection %q", got)
+	}
+}
+
+func TestSourceURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		labels      map[string]string
+		annotations map[string]string
+		want        string
+	}{
+		{name: "https label", labels: map[string]string{ociSourceLabel: "https://github.com/acme/app"}, want: "https://github.com/acme/app"},
+		{name: "git suffix", labels: map[string]string{ociSourceLabel: "https://github.com/acme/app.git"}, want: "https://github.com/acme/app"},
+		{name: "ssh remote", labels: map[string]string{ociSourceLabel: "git@github.com:acme/app.git"}, want: "https://github.com/acme/app"},
+		{name: "annotation fallback", annotations: map[string]string{ociSourceLabel: "git+https://gitlab.com/acme/app"}, want: "https://gitlab.com/acme/app"},
+		{name: "not a url", labels: map[string]string{ociSourceLabel: "acme/app"}},
+		{name: "missing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := sourceURL(tt.labels, tt.annotations)
+			if got != tt.want || ok != (tt.want != "") {
+				t.Fatalf("sourceURL() = %q, %v; want %q", got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpenSourceFromHistory(t *testing.T) {
+	var opened string
+	openInBrowser = func(target string) error {
+		opened = target
+		return nil
+	}
+	defer func() { openInBrowser = startBrowser }()
+
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.focus = FocusHistory
+
+	updated, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'O'}})
+	m = updated.(Model)
+	if opened != "" || m.status != "No source label" {
+		t.Fatalf("without label: opened %q, status %q", opened, m.status)
+	}
+
+	m.historyLabels = map[string]string{ociSourceLabel: "https://github.com/acme/app"}
+	updated, _ = m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'O'}})
+	m = updated.(Model)
+	if opened != "https://github.com/acme/app" || m.status != "Opened https://github.com/acme/app" {
+		t.Fatalf("with label: opened %q, status %q", opened, m.status)
+	}
+}
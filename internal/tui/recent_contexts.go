@@ -0,0 +1,112 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/scottbass3/beacon/internal/contextstore"
+)
+
+// WithRecentContexts seeds the quick switcher with names loaded from disk.
+func (m Model) WithRecentContexts(names []string) Model {
+	m.recentContexts = names
+	return m
+}
+
+func (m *Model) rememberRecentContext(name string) {
+	m.recentContexts = contextstore.PushRecent(m.recentContexts, name)
+	_ = contextstore.SaveRecent(m.recentContexts)
+}
+
+// recentContextIndices maps the MRU names to indices in m.contexts, with the
+// active context first so the previous one sits right below it.
+func (m Model) recentContextIndices() []int {
+	current := m.currentContextIndex()
+	var out []int
+	seen := make(map[int]bool)
+	if current >= 0 {
+		out = append(out, current)
+		seen[current] = true
+	}
+	for _, name := range m.recentContexts {
+		index, ok := m.contextNameIndex[strings.ToLower(strings.TrimSpace(name))]
+		if !ok || seen[index] {
+			continue
+		}
+		seen[index] = true
+		out = append(out, index)
+	}
+	return out
+}
+
+func (m Model) openRecentContexts() (tea.Model, tea.Cmd) {
+	indices := m.recentContextIndices()
+	if len(indices) < 2 {
+		m.status = "No other recent context; use :context to pick one"
+		return m, nil
+	}
+	m.recentContextsActive = true
+	m.recentContextsIndex = 1
+	return m, nil
+}
+
+func (m Model) handleRecentContextsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	indices := m.recentContextIndices()
+	if len(indices) == 0 {
+		m.recentContextsActive = false
+		return m, nil
+	}
+	key := msg.String()
+	switch {
+	case key == "ctrl+c":
+		m.recentContextsActive = false
+		return m.openQuitConfirm()
+	case key == "esc", key == "q":
+		m.recentContextsActive = false
+	case key == "enter":
+		m.recentContextsActive = false
+		selected := indices[clampInt(m.recentContextsIndex, 0, len(indices)-1)]
+		if selected == m.currentContextIndex() {
+			return m, nil
+		}
+		return m.switchContextAt(selected)
+	case isShortcut(msg, shortcutRecentContexts), isShortcut(msg, shortcutMoveDown), key == "tab":
+		m.recentContextsIndex = (m.recentContextsIndex + 1) % len(indices)
+	case isShortcut(msg, shortcutMoveUp), key == "shift+tab":
+		m.recentContextsIndex = (m.recentContextsIndex - 1 + len(indices)) % len(indices)
+	case len(key) == 1 && key[0] >= '1' && key[0] <= '9':
+		if n := int(key[0] - '1'); n < len(indices) {
+			m.recentContextsActive = false
+			if indices[n] == m.currentContextIndex() {
+				return m, nil
+			}
+			return m.switchContextAt(indices[n])
+		}
+	}
+	return m, nil
+}
+
+func (m Model) renderRecentContextsModal() string {
+	lines := []string{
+		modalTitleStyle.Render("Recent Contexts"),
+		modalDividerStyle.Render(strings.Repeat("─", 24)),
+	}
+	current := m.currentContextIndex()
+	for i, index := range m.recentContextIndices() {
+		ctx := m.contexts[index]
+		label := fmt.Sprintf("%d  %s", i+1, contextDisplayName(ctx, index))
+		if index == current {
+			label += " (current)"
+		}
+		host := modalOptionMutedStyle.Render(strings.TrimSpace(ctx.Host))
+		if i == m.recentContextsIndex {
+			lines = append(lines, modalFocusStyle.Render("> "+label)+"  "+host)
+		} else {
+			lines = append(lines, "  "+label+"  "+host)
+		}
+	}
+	lines = append(lines, "", modalHelpStyle.Render("ctrl+o/down next • 1-9 jump • enter switch • esc close"))
+	return m.renderModalCard(strings.Join(lines, "\n"), 64)
+}
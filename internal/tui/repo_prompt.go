@@ -0,0 +1,83 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+func runImageCommand(m Model, args []string) (tea.Model, tea.Cmd) {
+	if m.dockerHubActive || m.githubActive {
+		m.status = ":image opens repositories on the active registry; use the search input here"
+		return m, nil
+	}
+	switch len(args) {
+	case 0:
+		return m, m.openRepoPrompt()
+	case 1:
+		return m, m.openRepository(args[0])
+	default:
+		m.status = "Usage: :image <repo>"
+		return m, nil
+	}
+}
+
+func (m *Model) openRepoPrompt() tea.Cmd {
+	m.repoPromptActive = true
+	m.repoInput.SetValue("")
+	return m.repoInput.Focus()
+}
+
+func (m *Model) closeRepoPrompt() {
+	m.repoPromptActive = false
+	m.repoInput.Blur()
+}
+
+func (m Model) handleRepoPromptKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		m.closeRepoPrompt()
+		return m.openQuitConfirm()
+	case "esc":
+		m.closeRepoPrompt()
+		return m, nil
+	case "enter":
+		image := m.repoInput.Value()
+		m.closeRepoPrompt()
+		return m, m.openRepository(image)
+	}
+	var cmd tea.Cmd
+	m.repoInput, cmd = m.repoInput.Update(msg)
+	return m, cmd
+}
+
+// openRepository lists the tags of image on the active registry without going
+// through the catalog.
+func (m *Model) openRepository(image string) tea.Cmd {
+	image = strings.Trim(strings.TrimSpace(image), "/")
+	if image == "" {
+		m.status = "Enter a repository name"
+		return nil
+	}
+	if m.registryClient == nil {
+		m.status = "Registry client not ready"
+		return nil
+	}
+	m.selectedImage = registry.Image{Name: image, Repository: image, TagCount: -1, PullCount: -1}
+	m.hasSelectedImage = true
+	m.selectedTag = registry.Tag{}
+	m.hasSelectedTag = false
+	m.tags = nil
+	m.tagsLoadErr = nil
+	m.history = nil
+	m.focus = FocusTags
+	m.status = fmt.Sprintf("Loading tags for %s...", image)
+	m.clearFilter()
+	m.tagsQuery = ""
+	m.syncTable()
+	m.startLoading()
+	return m.tagsLoadCmd(image)
+}
@@ -0,0 +1,96 @@
+package tui
+
+import (
+	"fmt"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+func TestCatalogForbiddenOffersRepositoryPrompt(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.registryClient = &fakeTagSearchClient{}
+
+	updated, _ := m.Update(imagesMsg{err: fmt.Errorf("%w: 403 Forbidden", registry.ErrCatalogForbidden)})
+	m = updated.(Model)
+	if !m.repoPromptActive || !m.catalogForbidden {
+		t.Fatalf("expected the repository prompt after a forbidden catalog, status %q", m.status)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("team/app")})
+	m = updated.(Model)
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.repoPromptActive || cmd == nil {
+		t.Fatalf("expected enter to close the prompt and load tags")
+	}
+	if m.focus != FocusTags || m.selectedImage.Name != "team/app" {
+		t.Fatalf("expected tags of team/app, got focus %v image %q", m.focus, m.selectedImage.Name)
+	}
+
+	updated, _ = m.Update(imagesMsg{})
+	m = updated.(Model)
+	if m.catalogForbidden {
+		t.Fatalf("expected a successful catalog load to clear the forbidden state")
+	}
+}
+
+func TestCatalogDisabledOffersRepositoryPrompt(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.registryClient = &fakeTagSearchClient{}
+
+	updated, _ := m.Update(imagesMsg{err: fmt.Errorf("%w: 404 Not Found", registry.ErrCatalogDisabled)})
+	m = updated.(Model)
+	if !m.repoPromptActive || m.focus != FocusImages {
+		t.Fatalf("expected the repository prompt on Images, got focus %v", m.focus)
+	}
+	if m.statusIsError() || m.status != "Catalog listing disabled on this registry — enter a repository path" {
+		t.Fatalf("expected a disabled-catalog hint instead of an error, got %q", m.status)
+	}
+}
+
+func TestImageCommandOpensRepositoryTags(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.registryClient = &fakeTagSearchClient{}
+
+	updated, cmd := runImageCommand(m, []string{"/team/app/"})
+	m = updated.(Model)
+	if cmd == nil || m.focus != FocusTags || m.selectedImage.Name != "team/app" {
+		t.Fatalf("expected tags of team/app to load, got focus %v image %q", m.focus, m.selectedImage.Name)
+	}
+
+	updated, _ = m.Update(tagsMsg{err: fmt.Errorf("tags request failed: 404 Not Found")})
+	m = updated.(Model)
+	if m.status != "Error loading tags for team/app: tags request failed: 404 Not Found" {
+		t.Fatalf("expected the 404 in the status, got %q", m.status)
+	}
+}
+
+func TestTagsCommandOpensRepositoryOutsideCatalog(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.registryClient = &fakeTagSearchClient{}
+	m.images = []registry.Image{{Name: "nginx"}}
+
+	m.commandInput.SetValue("tags team/app")
+	updated, cmd := m.runCommand()
+	m = updated.(Model)
+	if cmd == nil || m.focus != FocusTags || m.selectedImage.Name != "team/app" {
+		t.Fatalf("expected tags of team/app to load, got focus %v image %q", m.focus, m.selectedImage.Name)
+	}
+
+	updated, _ = m.Update(tagsMsg{image: "team/app", err: fmt.Errorf("tags request failed: %w: 404 Not Found", registry.ErrRepositoryNotFound)})
+	m = updated.(Model)
+	if m.status != "Repository team/app not found" || !m.statusIsError() {
+		t.Fatalf("expected a not found error, got %q", m.status)
+	}
+}
@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+func renameTagCmd(client registry.Client, image, from, to string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		err := client.RenameTag(ctx, image, from, to)
+		return retagMsg{image: image, from: from, to: to, err: err}
+	}
+}
+
+func runRetagCommand(m Model, args []string) (tea.Model, tea.Cmd) {
+	if len(args) != 2 {
+		m.status = "Usage: :retag <old> <new>"
+		return m, nil
+	}
+	if m.dockerHubActive || m.githubActive || m.focus != FocusTags || !m.hasSelectedImage {
+		m.status = "Open an image's tags to retag"
+		return m, nil
+	}
+	if m.registryClient == nil {
+		m.status = "Registry client not ready"
+		return m, nil
+	}
+	from, to := args[0], args[1]
+	if !hasTagNamed(m.tags, from) {
+		m.status = fmt.Sprintf("Tag %s not found in %s", from, m.selectedImage.Name)
+		return m, nil
+	}
+	if from == to {
+		m.status = "The new tag must differ from the old one"
+		return m, nil
+	}
+
+	image := m.selectedImage.Name
+	m.confirmAction = confirmActionRetag
+	m.confirmTitle = fmt.Sprintf("Retag %s:%s as %s?", image, from, to)
+	m.confirmMessage = fmt.Sprintf("The manifest is pushed as %s, then %s is deleted. Needs push and delete rights.", to, from)
+	if hasTagNamed(m.tags, to) {
+		m.confirmMessage = fmt.Sprintf("%s already exists and will point to %s's manifest; %s is then deleted.", to, from, from)
+	}
+	m.confirmFocus = 0
+	m.retagImage = image
+	m.retagFrom = from
+	m.retagTo = to
+	return m, nil
+}
+
+func (m Model) startRetag(image, from, to string) (tea.Model, tea.Cmd) {
+	if m.registryClient == nil {
+		m.status = "Registry client not ready"
+		return m, nil
+	}
+	m.status = fmt.Sprintf("Retagging %s:%s as %s...", image, from, to)
+	m.startLoading()
+	return m, renameTagCmd(m.registryClient, image, from, to)
+}
+
+func (m Model) updateRetagMsg(msg retagMsg) (tea.Model, tea.Cmd) {
+	m.stopLoading()
+	switch {
+	case errors.Is(msg.err, registry.ErrNotSupported):
+		m.setErrorStatus("Retagging is not supported by this registry")
+		return m, nil
+	case msg.err != nil:
+		m.setErrorStatus(fmt.Sprintf("Error retagging %s:%s: %v", msg.image, msg.from, msg.err))
+		return m, nil
+	}
+	if !m.hasSelectedImage || m.selectedImage.Name != msg.image || m.registryClient == nil {
+		m.status = fmt.Sprintf("Retagged %s:%s as %s", msg.image, msg.from, msg.to)
+		return m, nil
+	}
+	m.status = fmt.Sprintf("Retagged %s:%s as %s; refreshing tags...", msg.image, msg.from, msg.to)
+	m.pendingTag = msg.to
+	m.startLoading()
+	return m, m.tagsLoadCmd(msg.image)
+}
@@ -0,0 +1,83 @@
+package tui
+
+import (
+	"context"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+type fakeRetagClient struct {
+	registry.Client
+	renamed []string
+	tags    []registry.Tag
+}
+
+func (c *fakeRetagClient) RenameTag(_ context.Context, image, from, to string) error {
+	c.renamed = append(c.renamed, image+":"+from+"->"+to)
+	c.tags = []registry.Tag{{Name: to}}
+	return nil
+}
+
+func (c *fakeRetagClient) ListTags(context.Context, string) ([]registry.Tag, error) {
+	return c.tags, nil
+}
+
+func TestRetagCommandConfirmsAndRefreshesTags(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	client := &fakeRetagClient{}
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.registryClient = client
+	m.focus = FocusTags
+	m.hasSelectedImage = true
+	m.selectedImage = registry.Image{Name: "team/app"}
+	m.tags = []registry.Tag{{Name: "old"}}
+	m.syncTable()
+
+	updated, _ := runRetagCommand(m, []string{"missing", "new"})
+	if updated.(Model).isConfirmModalActive() {
+		t.Fatalf("expected an unknown tag to be rejected before confirming")
+	}
+
+	updated, _ = runRetagCommand(m, []string{"old", "new"})
+	m = updated.(Model)
+	if m.confirmAction != confirmActionRetag {
+		t.Fatalf("expected a retag confirmation, got %v", m.confirmAction)
+	}
+
+	updated, cmd := m.handleConfirmKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m = updated.(Model)
+	if cmd == nil || m.isConfirmModalActive() {
+		t.Fatalf("expected confirming to start the retag")
+	}
+	updated, cmd = m.Update(cmd())
+	m = updated.(Model)
+	if len(client.renamed) != 1 || client.renamed[0] != "team/app:old->new" {
+		t.Fatalf("unexpected rename calls %v", client.renamed)
+	}
+	if cmd == nil {
+		t.Fatalf("expected tags to be refreshed after retagging")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if len(m.tags) != 1 || m.tags[0].Name != "new" {
+		t.Fatalf("expected the refreshed tags, got %+v", m.tags)
+	}
+}
+
+func TestRetagConfirmQCancels(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.confirmAction = confirmActionRetag
+	m.retagFrom = "old"
+
+	updated, cmd := m.handleConfirmKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	m = updated.(Model)
+	if cmd != nil || m.isConfirmModalActive() || m.retagFrom != "" {
+		t.Fatalf("expected q to cancel the retag without running it")
+	}
+}
@@ -0,0 +1,74 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openRowPreview shows every cell of the selected row untruncated. Inside a
+// project the Name column drops the project prefix, so the full repository
+// path is shown as well.
+func (m Model) openRowPreview() (tea.Model, tea.Cmd) {
+	list := m.listView()
+	cursor := m.table.Cursor()
+	if cursor < 0 || cursor >= len(list.rows) || cursor >= len(list.indices) {
+		m.status = "No row selected"
+		return m, nil
+	}
+	row := list.rows[cursor]
+	name := ""
+	if len(row) > 0 {
+		name = row[0]
+	}
+	switch index := list.indices[cursor]; {
+	case index < 0:
+	case m.focus == FocusImages:
+		if images := m.visibleImages(); index < len(images) {
+			name = images[index].Name
+		}
+	case m.focus == FocusTags, m.focus == FocusDockerHubTags, m.focus == FocusGitHubTags:
+		// The Name cell may carry selection and signature marks.
+		if tags := m.currentTags(); index < len(tags) {
+			name = tags[index].Name
+		}
+	}
+	m.rowPreviewActive = true
+	m.rowPreviewHeaders = list.headers
+	m.rowPreviewCells = row
+	m.rowPreviewName = name
+	return m, nil
+}
+
+func (m Model) handleRowPreviewKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.String() == "ctrl+c":
+		m.rowPreviewActive = false
+		return m.openQuitConfirm()
+	case msg.String() == "esc", msg.String() == "enter", msg.String() == "q", isShortcut(msg, shortcutExpandRow):
+		m.rowPreviewActive = false
+	case msg.String() == "y":
+		m.rowPreviewActive = false
+		if strings.TrimSpace(m.rowPreviewName) == "" {
+			m.status = "Nothing to copy"
+			return m, nil
+		}
+		m.copyText(m.rowPreviewName, m.rowPreviewName)
+	}
+	return m, nil
+}
+
+func (m Model) renderRowPreviewModal() string {
+	lines := []string{
+		modalTitleStyle.Render(m.rowPreviewName),
+		modalDividerStyle.Render(strings.Repeat("─", 24)),
+	}
+	for i, header := range m.rowPreviewHeaders {
+		if i >= len(m.rowPreviewCells) {
+			break
+		}
+		lines = append(lines, modalLabelStyle.Render(header)+"  "+m.rowPreviewCells[i])
+	}
+	lines = append(lines, "", modalHelpStyle.Render("y copy name • esc close"))
+	return m.renderModalCard(strings.Join(lines, "\n"), 96)
+}
@@ -12,19 +12,41 @@ const (
 	shortcutOpenHelp shortcutAction = iota
 	shortcutQuit
 	shortcutForceQuit
+	shortcutSuspend
 	shortcutOpenCommand
 	shortcutOpenFilter
 	shortcutRefresh
+	shortcutReload
 	shortcutBack
 	shortcutExitExternalMode
 	shortcutFocusExternalSearch
 	shortcutCopyImageTag
+	shortcutCopyPullCommand
 	shortcutPullImageTag
+	shortcutToggleArtifacts
+	shortcutToggleHistoryClean
+	shortcutToggleHistoryCollapse
+	shortcutMarkTag
+	shortcutCompareTags
+	shortcutOpenSource
+	shortcutRecentContexts
+	shortcutToggleWatch
+	shortcutCopyHost
+	shortcutCopyRequestURL
+	shortcutExpandRow
+	shortcutToggleProjectSort
+	shortcutCopyDigestReference
+	shortcutSelectTag
+	shortcutDeleteTags
+	shortcutOpenDockerHub
+	shortcutOpenGitHub
 
 	shortcutOpenProjectImages
 	shortcutOpenImageTags
 	shortcutOpenTagHistory
 	shortcutOpenExternalTagHistory
+	shortcutOpenHistoryDetail
+	shortcutOpenGitHubPackage
 
 	shortcutTypeCommand
 	shortcutCommandAutocomplete
@@ -51,6 +73,8 @@ const (
 	shortcutMoveHalfDown
 	shortcutMoveTop
 	shortcutMoveBottom
+	shortcutMoveNextGroup
+	shortcutMovePrevGroup
 )
 
 type shortcutDefinition struct {
@@ -83,6 +107,13 @@ var shortcutDefinitions = map[shortcutAction]shortcutDefinition{
 		Description: "Quit",
 		HintLabel:   "quit",
 	},
+	shortcutSuspend: {
+		Keys:        []string{"ctrl+z"},
+		HelpKeys:    "Ctrl+Z",
+		HintKeys:    "ctrl+z",
+		Description: "Suspend to the shell (fg resumes)",
+		HintLabel:   "suspend",
+	},
 	shortcutOpenCommand: {
 		Keys:        []string{":"},
 		HelpKeys:    ":",
@@ -104,6 +135,13 @@ var shortcutDefinitions = map[shortcutAction]shortcutDefinition{
 		Description: "Refresh current data",
 		HintLabel:   "refresh",
 	},
+	shortcutReload: {
+		Keys:        []string{"R"},
+		HelpKeys:    "R",
+		HintKeys:    "R",
+		Description: "Reload everything from the catalog root",
+		HintLabel:   "reload",
+	},
 	shortcutBack: {
 		Keys:        []string{"esc"},
 		HelpKeys:    "Esc",
@@ -132,6 +170,13 @@ var shortcutDefinitions = map[shortcutAction]shortcutDefinition{
 		Description: "Copy selected image:tag",
 		HintLabel:   "copy",
 	},
+	shortcutCopyPullCommand: {
+		Keys:        []string{"P"},
+		HelpKeys:    "P",
+		HintKeys:    "P",
+		Description: "Copy pull command for selected image:tag",
+		HintLabel:   "copy pull",
+	},
 	shortcutPullImageTag: {
 		Keys:        []string{"p"},
 		HelpKeys:    "p",
@@ -139,6 +184,123 @@ var shortcutDefinitions = map[shortcutAction]shortcutDefinition{
 		Description: "Pull selected image:tag with docker",
 		HintLabel:   "pull",
 	},
+	shortcutToggleArtifacts: {
+		Keys:        []string{"a"},
+		HelpKeys:    "a",
+		HintKeys:    "a",
+		Description: "Toggle non-image artifacts (signatures, SBOMs, charts)",
+		HintLabel:   "artifacts",
+	},
+	shortcutOpenSource: {
+		Keys:        []string{"O"},
+		HelpKeys:    "O",
+		HintKeys:    "O",
+		Description: "Open the tag's source repository (org.opencontainers.image.source)",
+		HintLabel:   "source",
+	},
+	shortcutToggleHistoryClean: {
+		Keys:        []string{"v"},
+		HelpKeys:    "v",
+		HintKeys:    "v",
+		Description: "Toggle cleaned/raw history commands",
+		HintLabel:   "raw/clean",
+	},
+	shortcutToggleHistoryCollapse: {
+		Keys:        []string{"z"},
+		HelpKeys:    "z",
+		HintKeys:    "z",
+		Description: "Collapse/expand runs of empty-layer metadata steps (Enter expands one)",
+		HintLabel:   "fold",
+	},
+	shortcutMarkTag: {
+		Keys:        []string{"m"},
+		HelpKeys:    "m",
+		HintKeys:    "m",
+		Description: "Mark/unmark selected tag for comparison",
+		HintLabel:   "mark",
+	},
+	shortcutCompareTags: {
+		Keys:        []string{"x"},
+		HelpKeys:    "x",
+		HintKeys:    "x",
+		Description: "Compare selected tag's history with the marked tag",
+		HintLabel:   "diff",
+	},
+	shortcutOpenDockerHub: {
+		Keys:        []string{"D"},
+		HelpKeys:    "D",
+		HintKeys:    "D",
+		Description: "Search Docker Hub (:dockerhub)",
+		HintLabel:   "docker hub",
+	},
+	shortcutOpenGitHub: {
+		Keys:        []string{"H"},
+		HelpKeys:    "H",
+		HintKeys:    "H",
+		Description: "Search GHCR (:github)",
+		HintLabel:   "ghcr",
+	},
+	shortcutRecentContexts: {
+		Keys:        []string{"ctrl+o"},
+		HelpKeys:    "Ctrl+O",
+		HintKeys:    "ctrl+o",
+		Description: "Quick-switch between recently used contexts",
+		HintLabel:   "recent",
+	},
+	shortcutToggleWatch: {
+		Keys:        []string{"w"},
+		HelpKeys:    "w",
+		HintKeys:    "w",
+		Description: "Toggle auto-refresh of the current view (:watch <seconds>)",
+		HintLabel:   "watch",
+	},
+	shortcutCopyHost: {
+		Keys:        []string{"y"},
+		HelpKeys:    "y",
+		HintKeys:    "y",
+		Description: "Copy the registry host (:copy host)",
+		HintLabel:   "copy host",
+	},
+	shortcutCopyRequestURL: {
+		Keys:        []string{"L"},
+		HelpKeys:    "L",
+		HintKeys:    "L",
+		Description: "Copy the last request URL from the debug log (:copy url)",
+		HintLabel:   "copy url",
+	},
+	shortcutExpandRow: {
+		Keys:        []string{"e"},
+		HelpKeys:    "e",
+		HintKeys:    "e",
+		Description: "Show the selected row untruncated (y copies the full name)",
+		HintLabel:   "expand",
+	},
+	shortcutToggleProjectSort: {
+		Keys:        []string{"o"},
+		HelpKeys:    "o",
+		HintKeys:    "o",
+		Description: "Sort projects by image count/name (:sort)",
+		HintLabel:   "sort",
+	},
+	shortcutCopyDigestReference: {
+		Keys:        []string{"c"},
+		HelpKeys:    "c",
+		HintKeys:    "c",
+		Description: "Copy image@digest of the inspected tag",
+		HintLabel:   "copy digest",
+	},
+	shortcutSelectTag: {
+		Keys:        []string{" "},
+		HelpKeys:    "Space",
+		HintKeys:    "space",
+		Description: "Select/unselect tag for batch delete",
+		HintLabel:   "select",
+	},
+	shortcutDeleteTags: {
+		Keys:        []string{"delete"},
+		HelpKeys:    "Delete",
+		Description: "Delete the selected tags, or the tag under the cursor (:delete)",
+	},
 	shortcutOpenProjectImages: {
 		Keys:        []string{"enter"},
 		HelpKeys:    "Enter",
@@ -160,6 +322,13 @@ var shortcutDefinitions = map[shortcutAction]shortcutDefinition{
 		Description: "Open selected tag history",
 		HintLabel:   "open",
 	},
+	shortcutOpenHistoryDetail: {
+		Keys:        []string{"enter"},
+		HelpKeys:    "Enter",
+		HintKeys:    "enter",
+		Description: "Show the full command for the selected layer",
+		HintLabel:   "details",
+	},
 	shortcutOpenExternalTagHistory: {
 		Keys:        []string{"enter"},
 		HelpKeys:    "Enter",
@@ -167,6 +336,13 @@ var shortcutDefinitions = map[shortcutAction]shortcutDefinition{
 		Description: "Open selected tag history",
 		HintLabel:   "open",
 	},
+	shortcutOpenGitHubPackage: {
+		Keys:        []string{"enter"},
+		HelpKeys:    "Enter",
+		HintKeys:    "enter",
+		Description: "Open selected package tags",
+		HintLabel:   "open",
+	},
 	shortcutTypeCommand: {
 		HelpKeys:    "Type",
 		HintKeys:    "type",
@@ -262,8 +438,8 @@ var shortcutDefinitions = map[shortcutAction]shortcutDefinition{
 		Description: "Move one page up",
 	},
 	shortcutMovePageDown: {
-		Keys:        []string{"pgdown", "f", " "},
-		HelpKeys:    "PgDn/f/Space",
+		Keys:        []string{"pgdown", "f"},
+		HelpKeys:    "PgDn/f",
 		Description: "Move one page down",
 	},
 	shortcutMoveHalfUp: {
@@ -286,6 +462,16 @@ var shortcutDefinitions = map[shortcutAction]shortcutDefinition{
 		HelpKeys:    "End/G",
 		Description: "Jump to bottom",
 	},
+	shortcutMoveNextGroup: {
+		Keys:        []string{"]"},
+		HelpKeys:    "]",
+		Description: "Jump to the next first-letter or namespace group",
+	},
+	shortcutMovePrevGroup: {
+		Keys:        []string{"["},
+		HelpKeys:    "[",
+		Description: "Jump to the previous first-letter or namespace group",
+	},
 }
 
 type shortcutPage int
@@ -302,12 +488,14 @@ const (
 	shortcutPageHistory
 	shortcutPageDockerHubTags
 	shortcutPageGitHubTags
+	shortcutPageGitHubPackages
 )
 
 var listHelpActions = []shortcutAction{
 	shortcutOpenHelp,
 	shortcutOpenCommand,
 	shortcutQuit,
+	shortcutSuspend,
 	shortcutOpenFilter,
 	shortcutMoveUp,
 	shortcutMoveDown,
@@ -317,7 +505,13 @@ var listHelpActions = []shortcutAction{
 	shortcutMoveHalfDown,
 	shortcutMoveTop,
 	shortcutMoveBottom,
+	shortcutMoveNextGroup,
+	shortcutMovePrevGroup,
 	shortcutRefresh,
+	shortcutToggleWatch,
+	shortcutCopyHost,
+	shortcutCopyRequestURL,
+	shortcutExpandRow,
 }
 
 var listHintActions = []shortcutAction{
@@ -371,6 +565,8 @@ func (m Model) shortcutPage(includeHelpOverlay bool) shortcutPage {
 		return shortcutPageDockerHubTags
 	case FocusGitHubTags:
 		return shortcutPageGitHubTags
+	case FocusGitHubPackages:
+		return shortcutPageGitHubPackages
 	default:
 		if m.dockerHubActive {
 			return shortcutPageDockerHubTags
@@ -406,6 +602,8 @@ func (m Model) shortcutPageTitle(includeHelpOverlay bool) string {
 		return "Docker Hub Tags"
 	case shortcutPageGitHubTags:
 		return "GHCR Tags"
+	case shortcutPageGitHubPackages:
+		return "GHCR Packages"
 	default:
 		return focusLabel(m.focus)
 	}
@@ -467,7 +665,12 @@ func (m Model) helpActionsForPage(page shortcutPage) []shortcutAction {
 		actions = append(actions,
 			shortcutOpenExternalTagHistory,
 			shortcutCopyImageTag,
+			shortcutCopyPullCommand,
 			shortcutPullImageTag,
+			shortcutToggleArtifacts,
+			shortcutMarkTag,
+			shortcutCompareTags,
+			shortcutOpenSource,
 			shortcutFocusExternalSearch,
 			shortcutExitExternalMode,
 		)
@@ -477,24 +680,35 @@ func (m Model) helpActionsForPage(page shortcutPage) []shortcutAction {
 		actions = append(actions,
 			shortcutOpenExternalTagHistory,
 			shortcutCopyImageTag,
+			shortcutCopyPullCommand,
 			shortcutPullImageTag,
+			shortcutToggleArtifacts,
+			shortcutMarkTag,
+			shortcutCompareTags,
+			shortcutOpenSource,
 			shortcutFocusExternalSearch,
 			shortcutExitExternalMode,
 		)
 		return actions
+	case shortcutPageGitHubPackages:
+		actions := cloneActions(listHelpActions)
+		return append(actions, shortcutOpenGitHubPackage, shortcutFocusExternalSearch, shortcutExitExternalMode)
 	case shortcutPageProjects:
 		actions := cloneActions(listHelpActions)
-		return append(actions, shortcutOpenProjectImages, shortcutBack)
+		return append(actions, shortcutReload, shortcutRecentContexts, shortcutOpenDockerHub, shortcutOpenGitHub, shortcutToggleProjectSort, shortcutOpenProjectImages, shortcutBack)
 	case shortcutPageImages:
 		actions := cloneActions(listHelpActions)
-		return append(actions, shortcutOpenImageTags, shortcutBack)
+		return append(actions, shortcutReload, shortcutRecentContexts, shortcutOpenDockerHub, shortcutOpenGitHub, shortcutOpenImageTags, shortcutBack)
 	case shortcutPageTags:
 		actions := cloneActions(listHelpActions)
-		return append(actions, shortcutOpenTagHistory, shortcutCopyImageTag, shortcutPullImageTag, shortcutBack)
+		return append(actions, shortcutReload, shortcutRecentContexts, shortcutOpenDockerHub, shortcutOpenGitHub, shortcutOpenTagHistory, shortcutCopyImageTag, shortcutCopyPullCommand, shortcutPullImageTag, shortcutToggleArtifacts, shortcutMarkTag, shortcutCompareTags, shortcutOpenSource, shortcutSelectTag, shortcutDeleteTags, shortcutBack)
 	case shortcutPageHistory:
 		actions := cloneActions(listHelpActions)
+		actions = append(actions, shortcutOpenHistoryDetail, shortcutCopyDigestReference, shortcutOpenSource, shortcutToggleHistoryClean, shortcutToggleHistoryCollapse)
 		if m.dockerHubActive || m.githubActive {
 			actions = append(actions, shortcutFocusExternalSearch)
+		} else {
+			actions = append(actions, shortcutOpenDockerHub, shortcutOpenGitHub)
 		}
 		return append(actions, shortcutBack)
 	default:
@@ -548,9 +762,12 @@ func (m Model) hintActionsForPage(page shortcutPage) []shortcutAction {
 			shortcutExitExternalMode,
 		)
 		return actions
+	case shortcutPageGitHubPackages:
+		actions := cloneActions(listHintActions)
+		return append(actions, shortcutFocusExternalSearch, shortcutOpenGitHubPackage, shortcutExitExternalMode)
 	case shortcutPageProjects:
 		actions := cloneActions(listHintActions)
-		return append(actions, shortcutOpenProjectImages, shortcutBack)
+		return append(actions, shortcutOpenProjectImages, shortcutOpenDockerHub, shortcutOpenGitHub, shortcutBack)
 	case shortcutPageImages:
 		actions := cloneActions(listHintActions)
 		return append(actions, shortcutOpenImageTags, shortcutBack)
@@ -559,6 +776,7 @@ func (m Model) hintActionsForPage(page shortcutPage) []shortcutAction {
 		return append(actions, shortcutOpenTagHistory, shortcutCopyImageTag, shortcutPullImageTag, shortcutBack)
 	case shortcutPageHistory:
 		actions := cloneActions(listHintActions)
+		actions = append(actions, shortcutOpenHistoryDetail)
 		if m.dockerHubActive || m.githubActive {
 			actions = append(actions, shortcutFocusExternalSearch)
 		}
@@ -0,0 +1,147 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+const ociSourceLabel = "org.opencontainers.image.source"
+
+// errNoSourceLabel marks a tag whose config and manifest carry no source.
+var errNoSourceLabel = errors.New("no source label")
+
+// openInBrowser is swapped out in tests.
+var openInBrowser = startBrowser
+
+func startBrowser(target string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", target)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", target)
+	default:
+		cmd = exec.Command("xdg-open", target)
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
+
+// sourceURL reads the OCI source label, preferring the image config over
+// manifest annotations, and turns git remotes into browsable https URLs.
+func sourceURL(labels, annotations map[string]string) (string, bool) {
+	raw := strings.TrimSpace(labels[ociSourceLabel])
+	if raw == "" {
+		raw = strings.TrimSpace(annotations[ociSourceLabel])
+	}
+	if raw == "" {
+		return "", false
+	}
+	raw = strings.TrimPrefix(raw, "git+")
+	if host, path, ok := strings.Cut(strings.TrimPrefix(raw, "git@"), ":"); ok && strings.HasPrefix(raw, "git@") {
+		raw = "https://" + host + "/" + path
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", false
+	}
+	parsed.User = nil
+	parsed.Path = strings.TrimSuffix(parsed.Path, ".git")
+	return parsed.String(), true
+}
+
+type tagInspectFunc func(ctx context.Context, image, tag string) (registry.TagDetails, error)
+
+func (m Model) tagInspector() tagInspectFunc {
+	switch m.focus {
+	case FocusDockerHubTags:
+		client := registry.NewDockerHubClient(m.logger, m.auth.Proxy)
+		return client.InspectTag
+	case FocusGitHubTags:
+		client := registry.NewGitHubContainerClient(m.logger, m.auth.Proxy)
+		return client.InspectTag
+	default:
+		if m.registryClient == nil {
+			return nil
+		}
+		client := m.registryClient
+		return func(ctx context.Context, image, tag string) (registry.TagDetails, error) {
+			return inspectTag(ctx, client, image, tag)
+		}
+	}
+}
+
+func openTagSourceCmd(inspect tagInspectFunc, image, tag string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		details, err := inspect(ctx, image, tag)
+		if err != nil {
+			return sourceOpenMsg{err: err}
+		}
+		target, ok := sourceURL(details.Labels, details.Annotations)
+		if !ok {
+			return sourceOpenMsg{err: errNoSourceLabel}
+		}
+		return sourceOpenMsg{url: target, err: openInBrowser(target)}
+	}
+}
+
+// openTagSource opens the source repository of the inspected tag on History,
+// or inspects the selected tag first on the tag views.
+func (m *Model) openTagSource() tea.Cmd {
+	if m.focus == FocusHistory {
+		target, ok := sourceURL(m.historyLabels, m.historyAnnotations)
+		if !ok {
+			m.status = "No source label"
+			return nil
+		}
+		if err := openInBrowser(target); err != nil {
+			m.setErrorStatus(fmt.Sprintf("Error opening %s: %v", target, err))
+			return nil
+		}
+		m.status = fmt.Sprintf("Opened %s", target)
+		return nil
+	}
+
+	image, tag, ok := m.selectedTagImageAndTag()
+	if !ok {
+		m.status = "No tag selected"
+		return nil
+	}
+	inspect := m.tagInspector()
+	if inspect == nil {
+		m.status = "Registry client not ready"
+		return nil
+	}
+	m.status = fmt.Sprintf("Reading source label of %s:%s...", image, tag)
+	return openTagSourceCmd(inspect, image, tag)
+}
+
+func (m Model) updateSourceOpenMsg(msg sourceOpenMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case errors.Is(msg.err, errNoSourceLabel):
+		m.status = "No source label"
+	case msg.err != nil && msg.url != "":
+		m.setErrorStatus(fmt.Sprintf("Error opening %s: %v", msg.url, msg.err))
+	case msg.err != nil:
+		m.setErrorStatus(fmt.Sprintf("Error reading source label: %v", msg.err))
+	default:
+		m.status = fmt.Sprintf("Opened %s", msg.url)
+	}
+	return m, nil
+}
@@ -0,0 +1,23 @@
+package tui
+
+import tea "github.com/charmbracelet/bubbletea"
+
+func runStickyFilterCommand(m Model, _ []string) (tea.Model, tea.Cmd) {
+	m.stickyFilter = !m.stickyFilter
+	if m.stickyFilter {
+		m.status = "Filter kept across navigation"
+	} else {
+		m.status = "Filter cleared on navigation"
+	}
+	return m, nil
+}
+
+// resetFilterOnNavigate clears the filter when the list changes, unless the
+// filter is sticky, in which case the text stays and applies to the new list.
+func (m *Model) resetFilterOnNavigate() {
+	if m.stickyFilter {
+		m.stopFilterEditing()
+		return
+	}
+	m.clearFilter()
+}
@@ -26,16 +26,30 @@ func makeColumns(focus Focus, width int, spec registry.TableSpec) []table.Column
 	pullWidth := 6
 	sizeWidth := 10
 	commentWidth := 20
+	platformWidth := 11
+	labelWidth := 16
+	userWidth := 12
+	digestWidth := 21
 
 	switch focus {
 	case FocusProjects:
+		artifactWidth := 9
 		columnCount := 2
+		fixed := countWidth
+		if spec.Project.ShowArtifactCount {
+			columnCount++
+			fixed += artifactWidth
+		}
 		content := contentWidth(columnCount)
-		nameWidth := maxInt(1, content-countWidth)
-		return []table.Column{
+		nameWidth := maxInt(1, content-fixed)
+		columns := []table.Column{
 			{Title: "Name", Width: nameWidth},
 			{Title: "Images", Width: countWidth},
 		}
+		if spec.Project.ShowArtifactCount {
+			columns = append(columns, table.Column{Title: "Artifacts", Width: artifactWidth})
+		}
+		return columns
 	case FocusImages:
 		fixed := 0
 		columns := []table.Column{}
@@ -55,6 +69,15 @@ func makeColumns(focus Focus, width int, spec registry.TableSpec) []table.Column
 		content := contentWidth(columnCount)
 		nameWidth := maxInt(1, content-fixed)
 		return append([]table.Column{{Title: "Name", Width: nameWidth}}, columns...)
+	case FocusGitHubPackages:
+		visibilityWidth := 10
+		content := contentWidth(3)
+		nameWidth := maxInt(1, content-visibilityWidth-timeWidth)
+		return []table.Column{
+			{Title: "Name", Width: nameWidth},
+			{Title: "Visibility", Width: visibilityWidth},
+			{Title: "Updated", Width: timeWidth},
+		}
 	case FocusHistory:
 		columnCount := 2
 		fixed := timeWidth
@@ -94,10 +117,26 @@ func makeColumns(focus Focus, width int, spec registry.TableSpec) []table.Column
 			columns = append(columns, table.Column{Title: "Pushed", Width: timeWidth})
 			fixed += timeWidth
 		}
+		if spec.Tag.ShowPushedBy {
+			columns = append(columns, table.Column{Title: "Pushed By", Width: userWidth})
+			fixed += userWidth
+		}
 		if spec.Tag.ShowLastPulled {
 			columns = append(columns, table.Column{Title: "Last Pull", Width: timeWidth})
 			fixed += timeWidth
 		}
+		if spec.Tag.ShowPlatforms {
+			columns = append(columns, table.Column{Title: "Platforms", Width: platformWidth})
+			fixed += platformWidth
+		}
+		if spec.Tag.ShowLabels {
+			columns = append(columns, table.Column{Title: "Labels", Width: labelWidth})
+			fixed += labelWidth
+		}
+		if spec.Tag.ShowDigest {
+			columns = append(columns, table.Column{Title: "Digest", Width: digestWidth})
+			fixed += digestWidth
+		}
 		columnCount := len(columns) + 1
 		content := contentWidth(columnCount)
 		nameWidth := maxInt(1, content-fixed)
@@ -13,11 +13,62 @@ func formatCount(value int) string {
 	return fmt.Sprintf("%d", value)
 }
 
-func formatTime(value time.Time) string {
+// formatTagCount is formatCount with "…" for counts still being fetched.
+func formatTagCount(value int) string {
+	if value == tagCountPending {
+		return "…"
+	}
+	return formatCount(value)
+}
+
+// ResolveLocation parses a time zone setting: empty or "local" for the
+// system zone, "UTC", or an IANA name such as "Europe/Paris".
+func ResolveLocation(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	switch {
+	case name == "" || strings.EqualFold(name, "local"):
+		return time.Local, nil
+	case strings.EqualFold(name, "utc"):
+		return time.UTC, nil
+	}
+	return time.LoadLocation(name)
+}
+
+func formatTime(value time.Time, loc *time.Location) string {
+	if value.IsZero() {
+		return "-"
+	}
+	return value.In(loc).Format("2006-01-02 15:04")
+}
+
+type timeFormatter func(time.Time) string
+
+func formatRelativeTime(value time.Time) string {
+	return formatRelativeTimeAt(value, time.Now())
+}
+
+func formatRelativeTimeAt(value, now time.Time) string {
 	if value.IsZero() {
 		return "-"
 	}
-	return value.Local().Format("2006-01-02 15:04")
+	age := now.Sub(value)
+	if age < 0 {
+		return "just now"
+	}
+	switch {
+	case age < time.Minute:
+		return "just now"
+	case age < time.Hour:
+		return fmt.Sprintf("%dm ago", int(age/time.Minute))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(age/time.Hour))
+	case age < 30*24*time.Hour:
+		return fmt.Sprintf("%dd ago", int(age/(24*time.Hour)))
+	case age < 365*24*time.Hour:
+		return fmt.Sprintf("%dmo ago", int(age/(30*24*time.Hour)))
+	default:
+		return fmt.Sprintf("%dy ago", int(age/(365*24*time.Hour)))
+	}
 }
 
 func formatSize(sizeBytes int64) string {
@@ -37,6 +88,38 @@ func formatSize(sizeBytes int64) string {
 	return fmt.Sprintf("%.1f %s", value, units[unit])
 }
 
+func formatPlatforms(count int) string {
+	switch {
+	case count <= 0:
+		return "-"
+	case count == 1:
+		return "single"
+	default:
+		return fmt.Sprintf("multi (%d)", count)
+	}
+}
+
+// shortDigest trims a digest to its algorithm and first 12 hex characters.
+func shortDigest(digest string) string {
+	algorithm, hex, ok := strings.Cut(strings.TrimSpace(digest), ":")
+	if !ok || len(hex) <= 12 {
+		return digest
+	}
+	return algorithm + ":" + hex[:12]
+}
+
+// formatDigest shortens a digest for a table cell, marking the cut.
+func formatDigest(digest string) string {
+	digest = strings.TrimSpace(digest)
+	if digest == "" {
+		return "-"
+	}
+	if short := shortDigest(digest); short != digest {
+		return short + "…"
+	}
+	return digest
+}
+
 func formatHistoryCommand(value string) string {
 	value = strings.TrimSpace(value)
 	if value == "" {
@@ -45,6 +128,34 @@ func formatHistoryCommand(value string) string {
 	return value
 }
 
+// cleanHistoryCommand turns a raw CreatedBy value into a Dockerfile-like
+// line by stripping the shell wrapper, #(nop) markers, and BuildKit comments.
+func cleanHistoryCommand(value string) string {
+	command := strings.TrimSpace(value)
+	command = strings.TrimSuffix(command, "# buildkit")
+	command = strings.TrimSpace(command)
+
+	prefix := ""
+	if strings.HasPrefix(command, "RUN ") {
+		prefix = "RUN "
+		command = strings.TrimSpace(strings.TrimPrefix(command, "RUN "))
+	}
+	// Build args are recorded as "|<n> KEY=value ... /bin/sh -c ...".
+	if strings.HasPrefix(command, "|") {
+		if idx := strings.Index(command, "/bin/sh -c "); idx >= 0 {
+			command = command[idx:]
+		}
+	}
+	if !strings.HasPrefix(command, "/bin/sh -c ") {
+		return prefix + command
+	}
+	command = strings.TrimSpace(strings.TrimPrefix(command, "/bin/sh -c "))
+	if strings.HasPrefix(command, "#(nop)") {
+		return strings.TrimSpace(strings.TrimPrefix(command, "#(nop)"))
+	}
+	return "RUN " + command
+}
+
 func firstNonEmpty(value, fallback string) string {
 	value = strings.TrimSpace(value)
 	if value == "" {
@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/scottbass3/beacon/internal/config"
+)
+
+func TestCleanHistoryCommand(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{name: "nop env", raw: "/bin/sh -c #(nop)  ENV PATH=/usr/local/bin", want: "ENV PATH=/usr/local/bin"},
+		{name: "nop copy", raw: "/bin/sh -c #(nop) COPY dir:abc in / ", want: "COPY dir:abc in /"},
+		{name: "shell run", raw: "/bin/sh -c apt-get update", want: "RUN apt-get update"},
+		{name: "build args", raw: "|1 VERSION=1.2 /bin/sh -c make install", want: "RUN make install"},
+		{name: "buildkit run", raw: "RUN /bin/sh -c go build ./... # buildkit", want: "RUN go build ./..."},
+		{name: "buildkit instruction", raw: "WORKDIR /app", want: "WORKDIR /app"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cleanHistoryCommand(tt.raw); got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFormatRelativeTimeAt(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		value time.Time
+		want  string
+	}{
+		{name: "zero", value: time.Time{}, want: "-"},
+		{name: "future", value: now.Add(time.Hour), want: "just now"},
+		{name: "seconds", value: now.Add(-30 * time.Second), want: "just now"},
+		{name: "minutes", value: now.Add(-5 * time.Minute), want: "5m ago"},
+		{name: "hours", value: now.Add(-3 * time.Hour), want: "3h ago"},
+		{name: "days", value: now.Add(-3 * 24 * time.Hour), want: "3d ago"},
+		{name: "months", value: now.Add(-65 * 24 * time.Hour), want: "2mo ago"},
+		{name: "years", value: now.Add(-800 * 24 * time.Hour), want: "2y ago"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatRelativeTimeAt(tt.value, now); got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFormatTimeInDisplayLocation(t *testing.T) {
+	value := time.Date(2024, 5, 1, 22, 30, 0, 0, time.UTC)
+	tests := []struct {
+		zone string
+		want string
+	}{
+		{zone: "UTC", want: "2024-05-01 22:30"},
+		{zone: "Asia/Tokyo", want: "2024-05-02 07:30"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.zone, func(t *testing.T) {
+			if _, err := ResolveLocation(tt.zone); err != nil {
+				t.Skipf("zone data unavailable: %v", err)
+			}
+			m := Model{}.WithSettings(config.Settings{TimeZone: tt.zone})
+			if got := m.timeFormatter()(value); got != tt.want {
+				t.Fatalf("formatTime in %s = %q, want %q", tt.zone, got, tt.want)
+			}
+		})
+	}
+	if got := (Model{}).timeFormatter()(value); got != formatTime(value, time.Local) {
+		t.Fatalf("expected the local zone by default, got %q", got)
+	}
+	if _, err := ResolveLocation("Not/AZone"); err == nil {
+		t.Fatalf("expected an unknown zone to be rejected")
+	}
+}
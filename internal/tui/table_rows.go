@@ -2,6 +2,7 @@ package tui
 
 import (
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/table"
 
@@ -17,20 +18,67 @@ type listView struct {
 func (m Model) listView() listView {
 	filter := m.filterInput.Value()
 	spec := m.effectiveTableSpec()
+	when := m.timeFormatter()
 	switch m.focus {
 	case FocusProjects:
-		return filterRows(projectHeaders(), projectRows(m.projects), filter)
+		return filterRows(projectHeaders(spec.Project), projectRows(m.visibleProjects(), spec.Project), filter)
 	case FocusImages:
-		return filterRows(imageHeaders(spec.Image), imageRows(m.visibleImages(), m.selectedProject, spec.SupportsProjects, spec.Image), filter)
+		return filterRows(imageHeaders(spec.Image), imageRows(m.visibleImages(), m.selectedProject, spec.SupportsProjects, spec.Image, when), filter)
 	case FocusHistory:
-		return filterRows(historyHeaders(spec.History), historyRows(m.history, spec.History), filter)
+		rows := historyRows(m.history, spec.History, m.cleanHistory, when)
+		historySize := func(index int) int64 { return m.history[index].SizeBytes }
+		if m.historyCollapse && filter == "" {
+			return m.capHistoryRows(m.sortRowsBySize(m.dropSmallRows(collapseEmptyLayerRows(historyHeaders(spec.History), rows, m.history, m.historyExpanded), historySize), historySize))
+		}
+		return m.capHistoryRows(m.sortRowsBySize(m.dropSmallRows(filterRows(historyHeaders(spec.History), rows, filter), historySize), historySize))
 	case FocusDockerHubTags:
-		return filterRows(tagHeaders(spec.Tag), tagRows(m.dockerHubTags, spec.Tag), filter)
+		return m.tagListView(m.dockerHubTags, spec.Tag, filter)
 	case FocusGitHubTags:
-		return filterRows(tagHeaders(spec.Tag), tagRows(m.githubTags, spec.Tag), filter)
+		return m.tagListView(m.githubTags, spec.Tag, filter)
+	case FocusGitHubPackages:
+		return filterRows(githubPackageHeaders(), githubPackageRows(m.githubPackages, when), filter)
 	default:
-		return filterRows(tagHeaders(spec.Tag), tagRows(m.tags, spec.Tag), filter)
+		return m.tagListView(m.tags, spec.Tag, filter)
+	}
+}
+
+func (m Model) timeFormatter() timeFormatter {
+	if m.relativeTime {
+		return formatRelativeTime
+	}
+	loc := m.displayLocation()
+	return func(value time.Time) string { return formatTime(value, loc) }
+}
+
+// displayLocation is the zone absolute timestamps are shown in.
+func (m Model) displayLocation() *time.Location {
+	if m.location == nil {
+		return time.Local
+	}
+	return m.location
+}
+
+func (m Model) tagListView(tags []registry.Tag, spec registry.TagTableSpec, filter string) listView {
+	list := markSignedTags(filterRows(tagHeaders(spec), tagRows(tags, spec, m.timeFormatter()), filter), tags)
+	tagSize := func(index int) int64 { return tags[index].SizeBytes }
+	list = m.sortRowsBySize(m.dropSmallRows(list, tagSize), tagSize)
+	if !m.hideArtifacts {
+		return list
 	}
+	return hideArtifactRows(list, tags)
+}
+
+// hideArtifactRows drops non-image rows while keeping indices into tags.
+func hideArtifactRows(list listView, tags []registry.Tag) listView {
+	out := listView{headers: list.headers}
+	for i, index := range list.indices {
+		if index >= 0 && index < len(tags) && !registry.IsImageArtifact(tags[index].ArtifactType) {
+			continue
+		}
+		out.rows = append(out.rows, list.rows[i])
+		out.indices = append(out.indices, index)
+	}
+	return out
 }
 
 func imageHeaders(spec registry.ImageTableSpec) []string {
@@ -47,8 +95,12 @@ func imageHeaders(spec registry.ImageTableSpec) []string {
 	return headers
 }
 
-func projectHeaders() []string {
-	return []string{"Name", "Images"}
+func projectHeaders(spec registry.ProjectTableSpec) []string {
+	headers := []string{"Name", "Images"}
+	if spec.ShowArtifactCount {
+		headers = append(headers, "Artifacts")
+	}
+	return headers
 }
 
 func tagHeaders(spec registry.TagTableSpec) []string {
@@ -59,12 +111,28 @@ func tagHeaders(spec registry.TagTableSpec) []string {
 	if spec.ShowPushed {
 		headers = append(headers, "Pushed")
 	}
+	if spec.ShowPushedBy {
+		headers = append(headers, "Pushed By")
+	}
 	if spec.ShowLastPulled {
 		headers = append(headers, "Last Pull")
 	}
+	if spec.ShowPlatforms {
+		headers = append(headers, "Platforms")
+	}
+	if spec.ShowLabels {
+		headers = append(headers, "Labels")
+	}
+	if spec.ShowDigest {
+		headers = append(headers, "Digest")
+	}
 	return headers
 }
 
+func githubPackageHeaders() []string {
+	return []string{"Name", "Visibility", "Updated"}
+}
+
 func historyHeaders(spec registry.HistoryTableSpec) []string {
 	headers := []string{"Command", "Created"}
 	if spec.ShowSize {
@@ -76,7 +144,7 @@ func historyHeaders(spec registry.HistoryTableSpec) []string {
 	return headers
 }
 
-func imageRows(images []registry.Image, selectedProject string, supportsProjects bool, spec registry.ImageTableSpec) [][]string {
+func imageRows(images []registry.Image, selectedProject string, supportsProjects bool, spec registry.ImageTableSpec, when timeFormatter) [][]string {
 	if len(images) == 0 {
 		return nil
 	}
@@ -91,34 +159,67 @@ func imageRows(images []registry.Image, selectedProject string, supportsProjects
 		}
 		row := []string{name}
 		if spec.ShowTagCount {
-			row = append(row, formatCount(image.TagCount))
+			row = append(row, formatTagCount(image.TagCount))
 		}
 		if spec.ShowPulls {
 			row = append(row, formatCount(image.PullCount))
 		}
 		if spec.ShowUpdated {
-			row = append(row, formatTime(image.UpdatedAt))
+			row = append(row, when(image.UpdatedAt))
 		}
 		rows = append(rows, row)
 	}
 	return rows
 }
 
-func projectRows(projects []projectInfo) [][]string {
+func projectRows(projects []projectInfo, spec registry.ProjectTableSpec) [][]string {
 	if len(projects) == 0 {
 		return nil
 	}
 	rows := make([][]string, 0, len(projects))
 	for _, project := range projects {
-		rows = append(rows, []string{
+		row := []string{
 			project.Name,
 			formatCount(project.ImageCount),
+		}
+		if spec.ShowArtifactCount {
+			row = append(row, formatCount(project.ArtifactCount))
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func githubPackageRows(packages []registry.GitHubPackage, when timeFormatter) [][]string {
+	if len(packages) == 0 {
+		return nil
+	}
+	rows := make([][]string, 0, len(packages))
+	for _, pkg := range packages {
+		rows = append(rows, []string{
+			pkg.Name,
+			firstNonEmpty(pkg.Visibility, "-"),
+			when(pkg.UpdatedAt),
 		})
 	}
 	return rows
 }
 
-func tagRows(tags []registry.Tag, spec registry.TagTableSpec) [][]string {
+// signedTagMark follows the name of tags with a cosign signature.
+const signedTagMark = " (signed)"
+
+// markSignedTags appends signedTagMark after filtering, so the filter and its
+// highlight only ever see the tag name.
+func markSignedTags(list listView, tags []registry.Tag) listView {
+	for i, index := range list.indices {
+		if index >= 0 && index < len(tags) && tags[index].Signed && len(list.rows[i]) > 0 {
+			list.rows[i][0] += signedTagMark
+		}
+	}
+	return list
+}
+
+func tagRows(tags []registry.Tag, spec registry.TagTableSpec, when timeFormatter) [][]string {
 	if len(tags) == 0 {
 		return nil
 	}
@@ -129,17 +230,29 @@ func tagRows(tags []registry.Tag, spec registry.TagTableSpec) [][]string {
 			row = append(row, formatSize(tag.SizeBytes))
 		}
 		if spec.ShowPushed {
-			row = append(row, formatTime(tag.PushedAt))
+			row = append(row, when(tag.PushedAt))
+		}
+		if spec.ShowPushedBy {
+			row = append(row, firstNonEmpty(tag.PushedBy, "-"))
 		}
 		if spec.ShowLastPulled {
-			row = append(row, formatTime(tag.LastPulledAt))
+			row = append(row, when(tag.LastPulledAt))
+		}
+		if spec.ShowPlatforms {
+			row = append(row, formatPlatforms(tag.Platforms))
+		}
+		if spec.ShowLabels {
+			row = append(row, firstNonEmpty(strings.Join(tag.Labels, ","), "-"))
+		}
+		if spec.ShowDigest {
+			row = append(row, formatDigest(tag.Digest))
 		}
 		rows = append(rows, row)
 	}
 	return rows
 }
 
-func historyRows(entries []registry.HistoryEntry, spec registry.HistoryTableSpec) [][]string {
+func historyRows(entries []registry.HistoryEntry, spec registry.HistoryTableSpec, clean bool, when timeFormatter) [][]string {
 	if len(entries) == 0 {
 		return nil
 	}
@@ -149,9 +262,13 @@ func historyRows(entries []registry.HistoryEntry, spec registry.HistoryTableSpec
 		if comment == "" && entry.EmptyLayer {
 			comment = "empty layer"
 		}
+		command := entry.CreatedBy
+		if clean {
+			command = cleanHistoryCommand(command)
+		}
 		row := []string{
-			formatHistoryCommand(entry.CreatedBy),
-			formatTime(entry.CreatedAt),
+			formatHistoryCommand(command),
+			when(entry.CreatedAt),
 		}
 		if spec.ShowSize {
 			row = append(row, formatSize(entry.SizeBytes))
@@ -0,0 +1,406 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/table"
+
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+func TestHideArtifactsKeepsSourceIndices(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.focus = FocusTags
+	m.tags = []registry.Tag{
+		{Name: "sha256-abc.sig", ArtifactType: registry.ArtifactTypeSignature},
+		{Name: "v1"},
+		{Name: "chart", ArtifactType: registry.ArtifactTypeHelmChart},
+		{Name: "v2", ArtifactType: registry.ArtifactTypeImage},
+	}
+
+	if got := len(m.listView().rows); got != 4 {
+		t.Fatalf("expected all 4 rows by default, got %d", got)
+	}
+
+	m.toggleHideArtifacts()
+	list := m.listView()
+	if len(list.rows) != 2 {
+		t.Fatalf("expected 2 image rows, got %d", len(list.rows))
+	}
+	if list.indices[0] != 1 || list.indices[1] != 3 {
+		t.Fatalf("expected indices [1 3], got %v", list.indices)
+	}
+	if list.rows[0][0] != "v1" || list.rows[1][0] != "v2" {
+		t.Fatalf("unexpected rows: %v", list.rows)
+	}
+}
+
+func TestProjectRowsArtifactCount(t *testing.T) {
+	projects := []projectInfo{
+		{Name: "library", ImageCount: 2, ArtifactCount: 8},
+		{Name: "derived", ImageCount: 1, ArtifactCount: -1},
+	}
+
+	rows := projectRows(projects, registry.ProjectTableSpec{})
+	if len(rows[0]) != 2 {
+		t.Fatalf("expected artifact column hidden without spec support, got %v", rows[0])
+	}
+
+	spec := registry.ProjectTableSpec{ShowArtifactCount: true}
+	rows = projectRows(projects, spec)
+	if len(projectHeaders(spec)) != 3 || rows[0][2] != "8" || rows[1][2] != "-" {
+		t.Fatalf("unexpected artifact column rows: %v", rows)
+	}
+}
+
+func TestProjectsSortByImageCount(t *testing.T) {
+	auth := registry.Auth{Kind: "harbor"}
+	auth.Harbor.Anonymous = true
+	m := NewModel("https://harbor.example.com", auth, nil, false, nil, nil, "", "")
+	m.focus = FocusProjects
+	m.projects = []projectInfo{
+		{Name: "alpha", ImageCount: 2},
+		{Name: "beta", ImageCount: 40},
+		{Name: "gamma", ImageCount: 2},
+	}
+
+	m.toggleProjectSort()
+	list := m.listView()
+	if list.rows[0][0] != "beta" || list.rows[1][0] != "alpha" || list.rows[2][0] != "gamma" {
+		t.Fatalf("expected largest first with names as tiebreak, got %v", list.rows)
+	}
+	if title := m.tableColumns[1].Title; title != "Images"+sortIndicator {
+		t.Fatalf("expected a sort indicator on Images, got %q", title)
+	}
+
+	if got := m.visibleProjects()[list.indices[0]].Name; got != "beta" {
+		t.Fatalf("expected row indices to follow the sorted order, got %q", got)
+	}
+}
+
+func TestMarkSortedColumnFindsTitle(t *testing.T) {
+	columns := []table.Column{
+		{Title: "Name", Width: 20},
+		{Title: "Artifacts", Width: 9},
+		{Title: "Images", Width: 8},
+	}
+	markSortedColumn(columns, "Images")
+	if columns[2].Title != "Images"+sortIndicator || columns[1].Title != "Artifacts" {
+		t.Fatalf("expected only the Images column marked, got %v", columns)
+	}
+
+	markSortedColumn(columns, "Size")
+	for _, column := range columns[:2] {
+		if strings.HasSuffix(column.Title, sortIndicator) {
+			t.Fatalf("expected a missing title to leave the columns alone, got %v", columns)
+		}
+	}
+}
+
+func TestMinSizeHidesSmallTags(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.focus = FocusTags
+	m.tags = []registry.Tag{
+		{Name: "small", SizeBytes: 10 << 20},
+		{Name: "large", SizeBytes: 800 << 20},
+		{Name: "unknown", SizeBytes: -1},
+	}
+
+	updated, _ := runMinSizeCommand(m, []string{"500MB"})
+	m = updated.(Model)
+	list := m.listView()
+	if len(list.rows) != 1 || m.tags[list.indices[0]].Name != "large" {
+		t.Fatalf("expected only the large tag, got %v", list.rows)
+	}
+
+	updated, _ = runMinSizeCommand(m, []string{"off"})
+	if list := updated.(Model).listView(); len(list.rows) != 3 {
+		t.Fatalf("expected :minsize off to show every tag, got %v", list.rows)
+	}
+
+	m.focus = FocusImages
+	m.minSizeBytes = 0
+	updated, _ = runMinSizeCommand(m, []string{"1GB"})
+	if next := updated.(Model); next.minSizeBytes != 0 || !strings.Contains(next.status, "no size data") {
+		t.Fatalf("expected views without sizes to refuse the filter, got %d %q", next.minSizeBytes, next.status)
+	}
+}
+
+func TestSignedTagsAreMarked(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.hasSelectedImage = true
+	m.selectedImage = registry.Image{Name: "app"}
+	updated, _ := m.updateTagsMsg(tagsMsg{image: "app", tags: []registry.Tag{
+		{Name: "v1", Digest: "sha256:abc"},
+		{Name: "sha256-abc.sig"},
+	}})
+	m = updated.(Model)
+	list := m.listView()
+	if list.rows[0][0] != "v1"+signedTagMark {
+		t.Fatalf("expected v1 to carry the signed mark, got %q", list.rows[0][0])
+	}
+	if list.rows[1][0] != "sha256-abc.sig" {
+		t.Fatalf("expected the signature tag itself to stay unmarked, got %q", list.rows[1][0])
+	}
+
+	for _, filter := range []string{"signed", "(", "ed"} {
+		m.filterInput.SetValue(filter)
+		if list := m.listView(); len(list.rows) != 0 {
+			t.Fatalf("expected filter %q not to match the signed mark, got %v", filter, list.rows)
+		}
+	}
+	m.filterInput.SetValue("v")
+	if list := m.listView(); len(list.rows) != 1 || list.rows[0][0] != "v1"+signedTagMark {
+		t.Fatalf("expected a name match to keep its mark, got %v", list.rows)
+	}
+}
+
+func TestDigestsCommandAddsDigestColumn(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.focus = FocusTags
+	m.tags = []registry.Tag{
+		{Name: "v1", Digest: "sha256:0123456789abcdef0123"},
+		{Name: "v2"},
+	}
+
+	updated, _ := runDigestsCommand(m, nil)
+	m = updated.(Model)
+	list := m.listView()
+	last := len(list.headers) - 1
+	if list.headers[last] != "Digest" || m.tableColumns[last].Title != "Digest" {
+		t.Fatalf("expected a trailing Digest column, got %v", list.headers)
+	}
+	if got := list.rows[0][last]; got != "sha256:0123456789ab…" {
+		t.Fatalf("expected a short digest, got %q", got)
+	}
+	if got := list.rows[1][last]; got != "-" {
+		t.Fatalf("expected - for a tag without digest, got %q", got)
+	}
+}
+
+func TestEmptyAndMissingRepositoryTags(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.focus = FocusImages
+	m.hasSelectedImage = true
+	m.selectedImage = registry.Image{Name: "app"}
+
+	updated, _ := m.updateTagsMsg(tagsMsg{image: "app", err: fmt.Errorf("tags request failed: %w", registry.ErrRepositoryNotFound)})
+	if next := updated.(Model); next.status != "Repository app not found" || next.focus != FocusImages {
+		t.Fatalf("expected a not-found status on Images, got %q on %v", next.status, next.focus)
+	}
+	for _, err := range []error{
+		fmt.Errorf("tags request failed: %w", registry.ErrRepositoryNotFound),
+		withTimeout(context.DeadlineExceeded, registryLoadTimeout),
+	} {
+		tagsView := m
+		tagsView.focus = FocusTags
+		updated, _ = tagsView.updateTagsMsg(tagsMsg{image: "app", err: err})
+		if got := updated.(Model).emptyBodyMessage(); strings.Contains(got, "empty") {
+			t.Fatalf("expected a failed load not to call the repository empty, got %q", got)
+		}
+	}
+
+	updated, _ = m.updateTagsMsg(tagsMsg{image: "app"})
+	next := updated.(Model)
+	if next.status != "No tags (repository is empty)" {
+		t.Fatalf("expected an empty-repository status, got %q", next.status)
+	}
+	if got := next.emptyBodyMessage(); !strings.Contains(got, "is empty") {
+		t.Fatalf("expected the empty body to say the repository is empty, got %q", got)
+	}
+}
+
+func TestHistoryDetailListsImageLabels(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.width = 140
+	m.height = 40
+	updated, _ := m.updateHistoryMsg(historyMsg{
+		history: []registry.HistoryEntry{{CreatedBy: "RUN true", SizeBytes: 10}},
+		labels: map[string]string{
+			"maintainer":                        "ops",
+			"org.opencontainers.image.revision": "abc123",
+			"org.opencontainers.image.source":   "https://github.com/acme/app",
+		},
+	})
+	m = updated.(Model)
+	m.openHistoryDetail()
+
+	view := m.renderHistoryDetailModal()
+	source := strings.Index(view, "org.opencontainers.image.source = https://github.com/acme/app")
+	revision := strings.Index(view, "org.opencontainers.image.revision = abc123")
+	maintainer := strings.Index(view, "maintainer = ops")
+	if source < 0 || revision < source || maintainer < revision {
+		t.Fatalf("expected source, revision, then other labels, got:\n%s", view)
+	}
+}
+
+func TestHistoryDetailListsManifestAnnotations(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.width = 140
+	m.height = 40
+	updated, _ := m.updateHistoryMsg(historyMsg{
+		history:     []registry.HistoryEntry{{CreatedBy: "RUN true", SizeBytes: 10}},
+		annotations: map[string]string{"org.opencontainers.image.title": "chart"},
+	})
+	m = updated.(Model)
+	m.openHistoryDetail()
+
+	view := m.renderHistoryDetailModal()
+	if strings.Contains(view, "Image labels") {
+		t.Fatalf("expected no labels section without labels, got:\n%s", view)
+	}
+	if !strings.Contains(view, "Manifest annotations") || !strings.Contains(view, "org.opencontainers.image.title = chart") {
+		t.Fatalf("expected the manifest annotations, got:\n%s", view)
+	}
+}
+
+func TestHistoryLimitShowsMostRecentEntries(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.focus = FocusHistory
+	for i := 0; i < 5; i++ {
+		m.history = append(m.history, registry.HistoryEntry{CreatedBy: fmt.Sprintf("RUN step %d", i), SizeBytes: 1})
+	}
+
+	updated, _ := runHistoryLimitCommand(m, []string{"2"})
+	m = updated.(Model)
+	list := m.listView()
+	if len(list.rows) != 3 || list.rows[2][0] != "+3 older layers" {
+		t.Fatalf("expected 2 entries and a +3 row, got %v", list.rows)
+	}
+
+	m.filterInput.SetValue("step 4")
+	if list := m.listView(); len(list.rows) != 1 || list.indices[0] != 4 {
+		t.Fatalf("expected filtering to search hidden entries, got %v", list.rows)
+	}
+	m.filterInput.SetValue("")
+
+	m.tableSetCursor(2)
+	m.openHistoryDetail()
+	if m.historyDetailActive {
+		t.Fatalf("expected enter on the +N row to expand, not open details")
+	}
+	if got := len(m.listView().rows); got != 5 {
+		t.Fatalf("expected every entry after expanding, got %d rows", got)
+	}
+}
+
+func TestHistoryLimitCapsLoadedOrderWhenSortedBySize(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.focus = FocusHistory
+	for i, size := range []int64{10, 20, 500, 400} {
+		m.history = append(m.history, registry.HistoryEntry{CreatedBy: fmt.Sprintf("RUN step %d", i), SizeBytes: size})
+	}
+	updated, _ := runHistoryLimitCommand(m, []string{"2"})
+	m = updated.(Model)
+	m.sortCurrentViewBySize()
+
+	list := m.listView()
+	if len(list.rows) != 3 || list.indices[0] != 1 || list.indices[1] != 0 || list.rows[2][0] != "+2 older layers" {
+		t.Fatalf("expected the 2 most recent entries largest first, got %v %v", list.indices, list.rows)
+	}
+}
+
+func TestHistoryLimitRowPointsAtNoEntry(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.focus = FocusHistory
+	for i := 0; i < 4; i++ {
+		m.history = append(m.history, registry.HistoryEntry{CreatedBy: fmt.Sprintf("RUN step %d", i), SizeBytes: 1})
+	}
+	updated, _ := runHistoryLimitCommand(m, []string{"2"})
+	m = updated.(Model)
+
+	list := m.listView()
+	if index := list.indices[2]; index >= 0 {
+		t.Fatalf("expected the +N row to carry no entry index, got %d", index)
+	}
+	m.tableSetCursor(2)
+	m.openHistoryDetail()
+	if m.historyDetailActive || !m.historyShowAll {
+		t.Fatalf("expected enter on the +N row to expand the list")
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+	}{
+		{"2048", 2048},
+		{"500MB", 500 << 20},
+		{"1.5 GB", 3 << 29},
+		{"200k", 200 << 10},
+		{"1GiB", 1 << 30},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseSize(tt.in)
+			if err != nil || got != tt.want {
+				t.Fatalf("parseSize(%q) = %d, %v; want %d", tt.in, got, err, tt.want)
+			}
+		})
+	}
+	if _, err := parseSize("5 parsecs"); err == nil {
+		t.Fatalf("expected an unknown unit to fail")
+	}
+}
+
+func TestCollapseEmptyLayerRuns(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.focus = FocusHistory
+	m.history = []registry.HistoryEntry{
+		{CreatedBy: "CMD [\"nginx\"]", EmptyLayer: true},
+		{CreatedBy: "EXPOSE 80", EmptyLayer: true},
+		{CreatedBy: "ENV A=1", EmptyLayer: true},
+		{CreatedBy: "RUN apt-get install", SizeBytes: 1024},
+		{CreatedBy: "WORKDIR /app", EmptyLayer: true},
+		{CreatedBy: "ADD rootfs.tar", SizeBytes: 2048},
+	}
+
+	m.toggleHistoryCollapse()
+	list := m.listView()
+	if len(list.rows) != 4 || list.rows[0][0] != "+3 metadata steps" {
+		t.Fatalf("expected the leading run folded into one row, got %v", list.rows)
+	}
+	if list.indices[0] != 0 || list.indices[1] != 3 || list.indices[2] != 4 {
+		t.Fatalf("expected indices to point at source entries, got %v", list.indices)
+	}
+
+	m.tableSetCursor(0)
+	m.openHistoryDetail()
+	if m.historyDetailActive {
+		t.Fatalf("expected enter on a folded run to expand it, not open details")
+	}
+	if got := len(m.listView().rows); got != 6 {
+		t.Fatalf("expected the run to be expanded, got %d rows", got)
+	}
+
+	m.filterInput.SetValue("ENV")
+	if list := m.listView(); len(list.rows) != 1 || list.indices[0] != 2 {
+		t.Fatalf("expected filtering to match steps inside runs, got %v", list.rows)
+	}
+}
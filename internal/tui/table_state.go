@@ -1,6 +1,11 @@
 package tui
 
-import tea "github.com/charmbracelet/bubbletea"
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+)
 
 type tableMouseRegion struct {
 	x      int
@@ -143,9 +148,9 @@ func (m Model) tableMouseRowsRegion() (tableMouseRegion, bool) {
 	// title line
 	// table header
 	// table rows...
-	rowsY := topLines + 1 + 1 + mainSectionTitleLines + 1
+	rowsY := topLines + 1 + m.mainSectionBorderLines()/2 + mainSectionTitleLines + 1
 	// main section has a left border and horizontal padding of 1.
-	contentX := 2
+	contentX := m.mainSectionHChrome() / 2
 	return tableMouseRegion{
 		x:      contentX,
 		y:      rowsY,
@@ -171,3 +176,51 @@ func (m Model) tableRowAtMouse(msg tea.MouseMsg) (int, bool) {
 	}
 	return row, true
 }
+
+// rowGroup is the group ]/[ jump between: a row's namespace when its name
+// has one, otherwise its first letter.
+func rowGroup(row table.Row) string {
+	if len(row) == 0 {
+		return ""
+	}
+	name := strings.ToLower(strings.TrimLeft(row[0], tagSelectedMark+" "))
+	if namespace, _, ok := strings.Cut(name, "/"); ok {
+		return namespace + "/"
+	}
+	for _, r := range name {
+		return string(r)
+	}
+	return ""
+}
+
+func groupStartRow(rows []table.Row, cursor int) int {
+	group := rowGroup(rows[cursor])
+	for cursor > 0 && rowGroup(rows[cursor-1]) == group {
+		cursor--
+	}
+	return cursor
+}
+
+func nextGroupRow(rows []table.Row, cursor int) int {
+	if cursor < 0 || cursor >= len(rows) {
+		return cursor
+	}
+	group := rowGroup(rows[cursor])
+	for i := cursor + 1; i < len(rows); i++ {
+		if rowGroup(rows[i]) != group {
+			return i
+		}
+	}
+	return cursor
+}
+
+func prevGroupRow(rows []table.Row, cursor int) int {
+	if cursor <= 0 || cursor >= len(rows) {
+		return cursor
+	}
+	start := groupStartRow(rows, cursor)
+	if start == 0 {
+		return 0
+	}
+	return groupStartRow(rows, start-1)
+}
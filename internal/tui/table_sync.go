@@ -10,6 +10,10 @@ import (
 
 func (m *Model) syncTable() {
 	list := m.listView()
+	if m.focus == FocusTags {
+		// The mark is display-only so copy and export see plain tag names.
+		list = m.markSelectedTagRows(list)
+	}
 	width := m.width
 	if width <= 0 {
 		width = defaultRenderWidth
@@ -18,10 +22,17 @@ func (m *Model) syncTable() {
 	m.filterInput.Width = filterWidth
 	m.dockerHubInput.Width = filterWidth
 	m.githubInput.Width = filterWidth
+	m.repoInput.Width = filterWidth
 	m.commandInput.Width = filterWidth
 
 	tableWidth := maxInt(10, m.mainSectionContentWidth())
 	columns := makeColumns(m.focus, tableWidth, m.effectiveTableSpec())
+	if m.focus == FocusProjects && m.projectsByCount {
+		markSortedColumn(columns, "Images")
+	}
+	if m.focus != FocusProjects && m.viewSorts[m.focus] == sortBySize {
+		markSortedColumn(columns, "Size")
+	}
 	rows := normalizeTableRows(toTableRows(list.rows), len(columns))
 	columnsChanged := !equalTableColumns(m.tableColumns, columns)
 	if columnsChanged {
@@ -69,7 +80,7 @@ func (m Model) tableHeight() int {
 	}
 	// bubbles/table height controls only row viewport height; header + header border
 	// plus the bordered main section and title consume extra terminal lines.
-	available := m.height - topLines - mainSectionTitleLines - mainSectionBorderLines - debugLines - tableChromeLines - sectionSeparators
+	available := m.height - topLines - mainSectionTitleLines - m.mainSectionBorderLines() - debugLines - tableChromeLines - sectionSeparators
 	if available < minTableHeight {
 		return minTableHeight
 	}
@@ -88,6 +99,8 @@ func focusLabel(focus Focus) string {
 		return "Docker Hub Tags"
 	case FocusGitHubTags:
 		return "GHCR Tags"
+	case FocusGitHubPackages:
+		return "GHCR Packages"
 	default:
 		return "Tags"
 	}
@@ -121,31 +134,45 @@ func (m Model) tableSpec() registry.TableSpec {
 }
 
 func (m Model) effectiveTableSpec() registry.TableSpec {
+	spec := m.modeTableSpec()
+	m.applyHiddenColumns(&spec)
+	return spec
+}
+
+// modeTableSpec is the provider spec adjusted for external modes, before any
+// columns hidden with :columns are removed.
+func (m Model) modeTableSpec() registry.TableSpec {
 	spec := m.tableSpec()
+	if m.tagCountsEnabled {
+		spec.Image.ShowTagCount = true
+	}
 	if m.dockerHubActive || m.focus == FocusDockerHubTags {
 		spec.Tag = registry.TagTableSpec{
 			ShowSize:       true,
 			ShowPushed:     true,
 			ShowLastPulled: true,
+			ShowPlatforms:  true,
 		}
 	} else if m.githubActive || m.focus == FocusGitHubTags {
 		spec.Tag = registry.TagTableSpec{
 			ShowSize:       false,
 			ShowPushed:     false,
 			ShowLastPulled: false,
+			ShowPlatforms:  true,
 		}
 	}
+	spec.Tag.ShowDigest = m.showDigest
 	return spec
 }
 
 func (m Model) visibleImages() []registry.Image {
 	if !m.tableSpec().SupportsProjects || !m.hasSelectedProject {
-		return m.images
+		return m.imagesInNamespaces()
 	}
 	prefix := m.selectedProject + "/"
 	filtered := make([]registry.Image, 0, len(m.images))
 	for _, image := range m.images {
-		if strings.HasPrefix(image.Name, prefix) {
+		if strings.HasPrefix(image.Name, prefix) && m.inNamespaces(image.Name) {
 			filtered = append(filtered, image)
 		}
 	}
@@ -177,7 +204,7 @@ func deriveProjects(images []registry.Image) []projectInfo {
 
 	projects := make([]projectInfo, 0, len(counts))
 	for name, count := range counts {
-		projects = append(projects, projectInfo{Name: name, ImageCount: count})
+		projects = append(projects, projectInfo{Name: name, ImageCount: count, ArtifactCount: -1})
 	}
 	sort.Slice(projects, func(i, j int) bool {
 		return projects[i].Name < projects[j].Name
@@ -192,8 +219,9 @@ func toProjectInfos(projects []registry.Project) []projectInfo {
 	items := make([]projectInfo, 0, len(projects))
 	for _, project := range projects {
 		items = append(items, projectInfo{
-			Name:       project.Name,
-			ImageCount: project.ImageCount,
+			Name:          project.Name,
+			ImageCount:    project.ImageCount,
+			ArtifactCount: project.ArtifactCount,
 		})
 	}
 	sort.Slice(items, func(i, j int) bool {
@@ -0,0 +1,113 @@
+package tui
+
+import (
+	"context"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+const (
+	tagCountWorkers = 4
+	// tagCountPending marks an Image.TagCount that is still being fetched.
+	tagCountPending = -2
+)
+
+func listenTagCounts(gen int, ch <-chan imageTagCountMsg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-ch
+		if !ok {
+			return imageTagCountMsg{gen: gen, done: true}
+		}
+		msg.ch = ch
+		return msg
+	}
+}
+
+func runTagCounts(ctx context.Context, gen int, client registry.Client, images []string, ch chan<- imageTagCountMsg) {
+	defer close(ch)
+	jobs := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < tagCountWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for image := range jobs {
+				tags, err := client.ListTags(ctx, image)
+				if ctx.Err() != nil {
+					continue
+				}
+				count := len(tags)
+				if err != nil {
+					count = -1
+				}
+				ch <- imageTagCountMsg{gen: gen, image: image, count: count}
+			}
+		}()
+	}
+	for _, image := range images {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- image
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// startTagCounts marks listed repositories without a known count as pending
+// and fetches their counts in the background; repositories outside the
+// context's namespaces are never shown, so they are skipped. fresh starts a new generation for a
+// reloaded catalog; appended pages join the current one.
+func (m *Model) startTagCounts(fresh bool) tea.Cmd {
+	if !m.tagCountsEnabled || m.registryClient == nil {
+		return nil
+	}
+	if fresh || m.tagCountCtx == nil {
+		m.stopTagCounts()
+		m.tagCountGen++
+		m.tagCountCtx, m.tagCountCancel = context.WithCancel(context.Background())
+	}
+	var names []string
+	for i := range m.images {
+		if m.images[i].TagCount == -1 && m.inNamespaces(m.images[i].Name) {
+			m.images[i].TagCount = tagCountPending
+			names = append(names, m.images[i].Name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	ch := make(chan imageTagCountMsg, 64)
+	go runTagCounts(m.tagCountCtx, m.tagCountGen, m.registryClient, names, ch)
+	return listenTagCounts(m.tagCountGen, ch)
+}
+
+func (m *Model) stopTagCounts() {
+	if m.tagCountCancel != nil {
+		m.tagCountCancel()
+	}
+	m.tagCountCtx = nil
+	m.tagCountCancel = nil
+}
+
+func (m Model) updateImageTagCountMsg(msg imageTagCountMsg) (tea.Model, tea.Cmd) {
+	if msg.done {
+		return m, nil
+	}
+	// Stale lookups are drained until their workers exit.
+	next := listenTagCounts(msg.gen, msg.ch)
+	if msg.gen != m.tagCountGen {
+		return m, next
+	}
+	for i := range m.images {
+		if m.images[i].Name == msg.image && m.images[i].TagCount == tagCountPending {
+			m.images[i].TagCount = msg.count
+			break
+		}
+	}
+	m.syncTable()
+	return m, next
+}
@@ -0,0 +1,183 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/scottbass3/beacon/internal/config"
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+type fakeTagCountClient struct {
+	registry.Client
+	tags map[string]int
+}
+
+func (c *fakeTagCountClient) ListTags(_ context.Context, image string) ([]registry.Tag, error) {
+	count, ok := c.tags[image]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return make([]registry.Tag, count), nil
+}
+
+func TestTagCountsShowPendingUntilFetched(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "").WithSettings(config.Settings{TagCounts: true})
+	client := &fakeTagCountClient{tags: map[string]int{"a": 3}}
+	m.registryClient = client
+
+	images := []registry.Image{{Name: "a", TagCount: -1}, {Name: "b", TagCount: -1}}
+	updated, cmd := m.Update(imagesMsg{client: client, images: images})
+	m = updated.(Model)
+	rows := m.listView().rows
+	if len(rows) != 2 || rows[0][1] != "…" || rows[1][1] != "…" {
+		t.Fatalf("expected pending counts, got %v", rows)
+	}
+
+	for i := 0; i < 2; i++ {
+		msg := findMsg[imageTagCountMsg](t, cmd)
+		updated, cmd = m.Update(msg)
+		m = updated.(Model)
+	}
+	rows = m.listView().rows
+	if rows[0][1] != "3" || rows[1][1] != "-" {
+		t.Fatalf("expected counts 3 and unknown, got %v", rows)
+	}
+}
+
+func TestTagCountsSkipRepositoriesOutsideNamespaces(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	contexts := []ContextOption{{Name: "prod", Host: "https://registry.example.com", Auth: auth, Namespaces: []string{"team"}}}
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, contexts, "prod", "").WithSettings(config.Settings{TagCounts: true})
+	client := &fakeTagCountClient{tags: map[string]int{"team/app": 2, "other/app": 5}}
+	m.registryClient = client
+
+	images := []registry.Image{{Name: "other/app", TagCount: -1}, {Name: "team/app", TagCount: -1}}
+	updated, cmd := m.Update(imagesMsg{client: client, images: images})
+	m = updated.(Model)
+	if m.status != "Loaded 1 images" {
+		t.Fatalf("expected the status to count listed images, got %q", m.status)
+	}
+	if m.images[0].TagCount != -1 {
+		t.Fatalf("expected other/app not to be fetched, got count %d", m.images[0].TagCount)
+	}
+
+	msg := findMsg[imageTagCountMsg](t, cmd)
+	if msg.image != "team/app" {
+		t.Fatalf("expected only team/app to be counted, got %q", msg.image)
+	}
+	if next, ok := <-msg.ch; ok {
+		t.Fatalf("expected no further lookups, got %q", next.image)
+	}
+}
+
+// findMsg runs cmd, descending into batches, and returns the first message
+// of type T.
+func findMsg[T tea.Msg](t *testing.T, cmd tea.Cmd) T {
+	t.Helper()
+	msg, ok := tryFindMsg[T](cmd)
+	if !ok {
+		t.Fatalf("no %T produced", msg)
+	}
+	return msg
+}
+
+func tryFindMsg[T tea.Msg](cmd tea.Cmd) (T, bool) {
+	var zero T
+	if cmd == nil {
+		return zero, false
+	}
+	switch msg := cmd().(type) {
+	case T:
+		return msg, true
+	case tea.BatchMsg:
+		for _, c := range msg {
+			if found, ok := tryFindMsg[T](c); ok {
+				return found, true
+			}
+		}
+	}
+	return zero, false
+}
+
+type fakePlatformClient struct {
+	registry.Client
+	counts map[string]int
+	types  map[string]string
+	mu     sync.Mutex
+	asked  []string
+}
+
+func (c *fakePlatformClient) CountPlatforms(_ context.Context, _, tag string) (registry.ManifestSummary, error) {
+	c.mu.Lock()
+	c.asked = append(c.asked, tag)
+	c.mu.Unlock()
+	count, ok := c.counts[tag]
+	if !ok {
+		return registry.ManifestSummary{}, errors.New("manifest unknown")
+	}
+	return registry.ManifestSummary{Platforms: count, ArtifactType: c.types[tag]}, nil
+}
+
+func TestFailedPlatformLookupsAreNotRetried(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	client := &fakePlatformClient{counts: map[string]int{"ok": 2}}
+	m.registryClient = client
+	m.hasSelectedImage = true
+	m.selectedImage = registry.Image{Name: "app"}
+	m.focus = FocusTags
+	m.tags = []registry.Tag{{Name: "broken"}, {Name: "ok"}}
+
+	cmd := m.loadTagPlatforms(FocusTags)
+	if cmd == nil {
+		t.Fatalf("expected platform lookups for new tags")
+	}
+	updated, _ := m.Update(cmd())
+	m = updated.(Model)
+	if m.tags[0].Platforms != platformLookupFailed || m.tags[1].Platforms != 2 {
+		t.Fatalf("expected the failure recorded and the count kept, got %+v", m.tags)
+	}
+
+	m.tags = append(m.tags, registry.Tag{Name: "next"})
+	client.asked = nil
+	if cmd := m.loadTagPlatforms(FocusTags); cmd != nil {
+		cmd()
+	}
+	if len(client.asked) != 1 || client.asked[0] != "next" {
+		t.Fatalf("expected only the untried tag to be looked up, got %v", client.asked)
+	}
+}
+
+func TestPlatformLookupsTypeArtifactsFromConfig(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	client := &fakePlatformClient{
+		counts: map[string]int{"1.0.0": 1, "latest": 2},
+		types:  map[string]string{"1.0.0": registry.ArtifactTypeHelmChart, "latest": registry.ArtifactTypeImage},
+	}
+	m.registryClient = client
+	m.hasSelectedImage = true
+	m.selectedImage = registry.Image{Name: "charts/app"}
+	m.focus = FocusTags
+	m.tags = []registry.Tag{{Name: "1.0.0"}, {Name: "latest"}}
+	m.hideArtifacts = true
+
+	updated, _ := m.Update(m.loadTagPlatforms(FocusTags)())
+	m = updated.(Model)
+	if m.tags[0].ArtifactType != registry.ArtifactTypeHelmChart {
+		t.Fatalf("expected the chart typed from its config, got %q", m.tags[0].ArtifactType)
+	}
+	if rows := m.listView().rows; len(rows) != 1 || rows[0][0] != "latest" {
+		t.Fatalf("expected hiding artifacts to drop the chart, got %v", rows)
+	}
+}
@@ -0,0 +1,278 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+type historyDiffOp int
+
+const (
+	historyDiffSame historyDiffOp = iota
+	historyDiffAdded
+	historyDiffRemoved
+	historyDiffChanged
+)
+
+type historyDiffLine struct {
+	op    historyDiffOp
+	entry registry.HistoryEntry
+	// before is the base entry for changed lines.
+	before registry.HistoryEntry
+}
+
+type historyFetcher func(ctx context.Context, image, tag string) ([]registry.HistoryEntry, error)
+
+func loadHistoryDiffCmd(fetch historyFetcher, image, base, target string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+		defer cancel()
+
+		msg := historyDiffMsg{image: image, base: base, target: target}
+		msg.baseHistory, msg.err = fetch(ctx, image, base)
+		if msg.err != nil {
+			return msg
+		}
+		msg.targetHistory, msg.err = fetch(ctx, image, target)
+		return msg
+	}
+}
+
+// diffHistories aligns two layer histories on their commands and reports
+// layers only in b as added, only in a as removed, and matching commands
+// whose size differs as changed.
+func diffHistories(a, b []registry.HistoryEntry) []historyDiffLine {
+	key := func(entry registry.HistoryEntry) string {
+		return strings.TrimSpace(cleanHistoryCommand(entry.CreatedBy))
+	}
+
+	// lcs[i][j] is the common-subsequence length of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if key(a[i]) == key(b[j]) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = maxInt(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	out := make([]historyDiffLine, 0, maxInt(len(a), len(b)))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case key(a[i]) == key(b[j]):
+			op := historyDiffSame
+			if a[i].SizeBytes >= 0 && b[j].SizeBytes >= 0 && a[i].SizeBytes != b[j].SizeBytes {
+				op = historyDiffChanged
+			}
+			out = append(out, historyDiffLine{op: op, entry: b[j], before: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			out = append(out, historyDiffLine{op: historyDiffRemoved, entry: a[i]})
+			i++
+		default:
+			out = append(out, historyDiffLine{op: historyDiffAdded, entry: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		out = append(out, historyDiffLine{op: historyDiffRemoved, entry: a[i]})
+	}
+	for ; j < len(b); j++ {
+		out = append(out, historyDiffLine{op: historyDiffAdded, entry: b[j]})
+	}
+	return out
+}
+
+func (m *Model) toggleMarkedTag() {
+	image, tag, ok := m.selectedTagImageAndTag()
+	if !ok {
+		m.status = "No tag selected to mark"
+		return
+	}
+	if m.hasMarkedTag && m.markedTagFocus == m.focus && m.markedTagImage == image && m.markedTag == tag {
+		m.clearMarkedTag()
+		m.status = fmt.Sprintf("Unmarked %s:%s", image, tag)
+		return
+	}
+	m.hasMarkedTag = true
+	m.markedTag = tag
+	m.markedTagImage = image
+	m.markedTagFocus = m.focus
+	m.status = fmt.Sprintf("Marked %s:%s; select another tag and press x to compare", image, tag)
+}
+
+func (m *Model) clearMarkedTag() {
+	m.hasMarkedTag = false
+	m.markedTag = ""
+	m.markedTagImage = ""
+}
+
+func (m *Model) compareWithMarkedTag() tea.Cmd {
+	image, tag, ok := m.selectedTagImageAndTag()
+	if !ok {
+		m.status = "No tag selected to compare"
+		return nil
+	}
+	if !m.hasMarkedTag || m.markedTagFocus != m.focus || m.markedTagImage != image {
+		m.status = "Mark a tag of this image with m first"
+		return nil
+	}
+	if m.markedTag == tag {
+		m.status = "Select a different tag than the marked one"
+		return nil
+	}
+
+	fetch := m.historyFetcher()
+	if fetch == nil {
+		m.status = "Registry client not ready"
+		return nil
+	}
+	m.tagDiffActive = true
+	m.tagDiffLoading = true
+	m.tagDiffErr = nil
+	m.tagDiffLines = nil
+	m.tagDiffOffset = 0
+	m.tagDiffBase = m.markedTag
+	m.tagDiffTarget = tag
+	m.tagDiffImage = image
+	m.startLoading()
+	return loadHistoryDiffCmd(fetch, image, m.markedTag, tag)
+}
+
+func (m Model) historyFetcher() historyFetcher {
+	switch m.focus {
+	case FocusDockerHubTags:
+		client := registry.NewDockerHubClient(m.logger, m.auth.Proxy)
+		return client.ListTagHistory
+	case FocusGitHubTags:
+		client := registry.NewGitHubContainerClient(m.logger, m.auth.Proxy)
+		return client.ListTagHistory
+	default:
+		if m.registryClient == nil {
+			return nil
+		}
+		return m.registryClient.ListTagHistory
+	}
+}
+
+func (m Model) updateHistoryDiffMsg(msg historyDiffMsg) (tea.Model, tea.Cmd) {
+	m.stopLoading()
+	if !m.tagDiffActive || msg.image != m.tagDiffImage || msg.base != m.tagDiffBase || msg.target != m.tagDiffTarget {
+		return m, nil
+	}
+	m.tagDiffLoading = false
+	if msg.err != nil {
+		m.tagDiffErr = msg.err
+		m.setErrorStatus(fmt.Sprintf("Error comparing %s and %s: %v", msg.base, msg.target, msg.err))
+		return m, nil
+	}
+	m.tagDiffLines = diffHistories(msg.baseHistory, msg.targetHistory)
+	m.status = fmt.Sprintf("Compared %s:%s with %s", msg.image, msg.base, msg.target)
+	return m, nil
+}
+
+func (m Model) handleTagDiffKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	visible := m.tagDiffVisibleLines()
+	maxOffset := maxInt(0, len(m.tagDiffLines)-visible)
+	switch {
+	case msg.String() == "ctrl+c":
+		m.tagDiffActive = false
+		return m.openQuitConfirm()
+	case msg.String() == "esc", msg.String() == "enter", msg.String() == "q":
+		m.tagDiffActive = false
+	case isShortcut(msg, shortcutMoveUp):
+		m.tagDiffOffset = clampInt(m.tagDiffOffset-1, 0, maxOffset)
+	case isShortcut(msg, shortcutMoveDown):
+		m.tagDiffOffset = clampInt(m.tagDiffOffset+1, 0, maxOffset)
+	case isShortcut(msg, shortcutMovePageUp):
+		m.tagDiffOffset = clampInt(m.tagDiffOffset-visible, 0, maxOffset)
+	case isShortcut(msg, shortcutMovePageDown):
+		m.tagDiffOffset = clampInt(m.tagDiffOffset+visible, 0, maxOffset)
+	case isShortcut(msg, shortcutMoveTop):
+		m.tagDiffOffset = 0
+	case isShortcut(msg, shortcutMoveBottom):
+		m.tagDiffOffset = maxOffset
+	}
+	return m, nil
+}
+
+func (m Model) tagDiffVisibleLines() int {
+	height := m.height
+	if height <= 0 {
+		height = 24
+	}
+	return maxInt(3, height-14)
+}
+
+func (m Model) renderTagDiffModal() string {
+	title := modalTitleStyle.Render(fmt.Sprintf("%s: %s → %s", m.tagDiffImage, m.tagDiffBase, m.tagDiffTarget))
+	help := modalHelpStyle.Render("up/down scroll • esc close")
+	switch {
+	case m.tagDiffLoading:
+		return m.renderModalCard(strings.Join([]string{title, "", modalLabelStyle.Render("Loading histories..."), "", help}, "\n"), 110)
+	case m.tagDiffErr != nil:
+		return m.renderModalCard(strings.Join([]string{title, "", modalErrorStyle.Render(m.tagDiffErr.Error()), "", help}, "\n"), 110)
+	}
+
+	added, removed, changed := 0, 0, 0
+	for _, line := range m.tagDiffLines {
+		switch line.op {
+		case historyDiffAdded:
+			added++
+		case historyDiffRemoved:
+			removed++
+		case historyDiffChanged:
+			changed++
+		}
+	}
+	lines := []string{
+		title,
+		modalLabelStyle.Render(fmt.Sprintf("+%d added  -%d removed  ~%d changed", added, removed, changed)),
+		modalDividerStyle.Render(strings.Repeat("─", 24)),
+	}
+	if added+removed+changed == 0 {
+		lines = append(lines, modalLabelStyle.Render("Histories are identical."))
+	}
+
+	start := clampInt(m.tagDiffOffset, 0, len(m.tagDiffLines))
+	end := minInt(len(m.tagDiffLines), start+m.tagDiffVisibleLines())
+	for _, line := range m.tagDiffLines[start:end] {
+		lines = append(lines, m.renderTagDiffLine(line))
+	}
+	if end < len(m.tagDiffLines) || start > 0 {
+		lines = append(lines, modalLabelStyle.Render(fmt.Sprintf("%d-%d of %d layers", start+1, end, len(m.tagDiffLines))))
+	}
+	lines = append(lines, "", help)
+	return m.renderModalCard(strings.Join(lines, "\n"), 110)
+}
+
+func (m Model) renderTagDiffLine(line historyDiffLine) string {
+	command := line.entry.CreatedBy
+	if m.cleanHistory {
+		command = cleanHistoryCommand(command)
+	}
+	command = truncateLogLine(formatHistoryCommand(command), m.modalWidth(110)-8)
+	switch line.op {
+	case historyDiffAdded:
+		return modalFocusStyle.Render("+ " + command)
+	case historyDiffRemoved:
+		return modalErrorStyle.Render("- " + command)
+	case historyDiffChanged:
+		return modalTitleStyle.Render(fmt.Sprintf("~ %s (%s → %s)", command, formatSize(line.before.SizeBytes), formatSize(line.entry.SizeBytes)))
+	default:
+		return modalLabelStyle.Render("  " + command)
+	}
+}
@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"context"
+	"testing"
+
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+func TestDiffHistories(t *testing.T) {
+	base := []registry.HistoryEntry{
+		{CreatedBy: "/bin/sh -c #(nop) ADD file:base in /", SizeBytes: 100},
+		{CreatedBy: "/bin/sh -c apt-get install -y curl", SizeBytes: 50},
+		{CreatedBy: "/bin/sh -c #(nop) CMD [\"sh\"]", SizeBytes: 0},
+	}
+	target := []registry.HistoryEntry{
+		{CreatedBy: "/bin/sh -c #(nop) ADD file:base in /", SizeBytes: 120},
+		{CreatedBy: "/bin/sh -c apt-get install -y wget", SizeBytes: 40},
+		{CreatedBy: "/bin/sh -c #(nop) CMD [\"sh\"]", SizeBytes: 0},
+	}
+
+	got := diffHistories(base, target)
+	want := []historyDiffOp{historyDiffChanged, historyDiffRemoved, historyDiffAdded, historyDiffSame}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d diff lines, got %d: %+v", len(want), len(got), got)
+	}
+	for i, op := range want {
+		if got[i].op != op {
+			t.Fatalf("line %d: expected op %v, got %v (%+v)", i, op, got[i].op, got[i])
+		}
+	}
+	if got[0].before.SizeBytes != 100 || got[0].entry.SizeBytes != 120 {
+		t.Fatalf("expected changed line to keep both sizes, got %+v", got[0])
+	}
+}
+
+func TestCompareWithMarkedTagLoadsDiff(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.focus = FocusTags
+	m.selectedImage = registry.Image{Name: "app"}
+	m.hasSelectedImage = true
+	m.tags = []registry.Tag{{Name: "v1"}, {Name: "v2"}}
+	m.syncTable()
+
+	if cmd := m.compareWithMarkedTag(); cmd != nil || m.tagDiffActive {
+		t.Fatalf("expected compare without a marked tag to be rejected")
+	}
+	m.toggleMarkedTag()
+	if !m.hasMarkedTag || m.markedTag != "v1" {
+		t.Fatalf("expected v1 to be marked, got %+v", m.tagDiffState)
+	}
+
+	m.tableSetCursor(1)
+	histories := map[string][]registry.HistoryEntry{
+		"v1": {{CreatedBy: "RUN a"}},
+		"v2": {{CreatedBy: "RUN a"}, {CreatedBy: "RUN b"}},
+	}
+	fetch := func(_ context.Context, _ string, tag string) ([]registry.HistoryEntry, error) {
+		return histories[tag], nil
+	}
+	m.tagDiffActive = true
+	m.tagDiffImage, m.tagDiffBase, m.tagDiffTarget = "app", "v1", "v2"
+	msg := loadHistoryDiffCmd(fetch, "app", "v1", "v2")()
+
+	updated, _ := m.Update(msg)
+	next := updated.(Model)
+	if next.tagDiffLoading || len(next.tagDiffLines) != 2 || next.tagDiffLines[1].op != historyDiffAdded {
+		t.Fatalf("expected one shared and one added layer, got %+v", next.tagDiffLines)
+	}
+}
@@ -0,0 +1,346 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+const tagSearchWorkers = 4
+
+type tagSearchResult struct {
+	// contextIndex is -1 when the registry was opened without a context.
+	contextIndex int
+	context      string
+	image        string
+}
+
+type tagSearchTarget struct {
+	contextIndex int
+	context      string
+	client       registry.Client
+}
+
+func listenTagSearch(id int, ch <-chan tagSearchMsg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-ch
+		if !ok {
+			return tagSearchMsg{id: id, done: true}
+		}
+		msg.ch = ch
+		return msg
+	}
+}
+
+func runTagSearch(ctx context.Context, id int, tag string, targets []tagSearchTarget, ch chan<- tagSearchMsg) {
+	defer close(ch)
+	for _, target := range targets {
+		if ctx.Err() != nil {
+			return
+		}
+		jobs := make(chan string)
+		var wg sync.WaitGroup
+		for i := 0; i < tagSearchWorkers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for image := range jobs {
+					msg := tagSearchMsg{id: id, searched: 1}
+					tags, err := target.client.ListTags(ctx, image)
+					if err == nil && hasTagNamed(tags, tag) {
+						msg.found = []tagSearchResult{{contextIndex: target.contextIndex, context: target.context, image: image}}
+					}
+					ch <- msg
+				}
+			}()
+		}
+		search := func(images []registry.Image) {
+			ch <- tagSearchMsg{id: id, total: len(images)}
+			for _, image := range images {
+				if ctx.Err() != nil {
+					return
+				}
+				jobs <- image.Name
+			}
+		}
+		err := listSearchImages(ctx, target.client, search)
+		close(jobs)
+		wg.Wait()
+		if err != nil && ctx.Err() == nil {
+			ch <- tagSearchMsg{id: id, skipped: fmt.Sprintf("%s: %v", target.context, err)}
+		}
+	}
+}
+
+// listSearchImages hands repositories to search as they are listed, so a
+// streaming client like Harbor's starts searching with its first project.
+func listSearchImages(ctx context.Context, client registry.Client, search func([]registry.Image)) error {
+	if streamer, ok := client.(registry.ImageStreamer); ok {
+		return streamer.StreamImages(ctx, search)
+	}
+	images, err := client.ListImages(ctx)
+	if err != nil {
+		return err
+	}
+	search(images)
+	return nil
+}
+
+func hasTagNamed(tags []registry.Tag, name string) bool {
+	for _, tag := range tags {
+		if tag.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func runFindTagCommand(m Model, args []string) (tea.Model, tea.Cmd) {
+	all := false
+	var rest []string
+	for _, arg := range args {
+		if arg == "--all" || arg == "-a" {
+			all = true
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	if len(rest) == 0 && !all && m.tagSearchTag != "" {
+		m.tagSearchActive = true
+		return m, nil
+	}
+	if len(rest) != 1 {
+		m.status = "Usage: :findtag [--all] <tag>"
+		return m, nil
+	}
+	return m.startTagSearch(rest[0], all)
+}
+
+func (m Model) startTagSearch(tag string, all bool) (tea.Model, tea.Cmd) {
+	targets, skipped := m.tagSearchTargets(all)
+	if len(targets) == 0 {
+		m.status = "Registry client not ready"
+		return m, nil
+	}
+
+	m.stopTagSearch()
+	m.tagSearchID++
+	ctx, cancel := context.WithCancel(context.Background())
+	m.tagSearchCancel = cancel
+	m.tagSearchActive = true
+	m.tagSearchRunning = true
+	m.tagSearchTag = tag
+	m.tagSearchAll = all
+	m.tagSearchTotal = 0
+	m.tagSearchSearched = 0
+	m.tagSearchResults = nil
+	m.tagSearchSkipped = skipped
+	m.tagSearchIndex = 0
+	m.status = fmt.Sprintf("Searching for tag %s...", tag)
+
+	ch := make(chan tagSearchMsg, 64)
+	go runTagSearch(ctx, m.tagSearchID, tag, targets, ch)
+	return m, listenTagSearch(m.tagSearchID, ch)
+}
+
+// tagSearchTargets returns the current registry plus, with all set, every
+// other context that can connect without prompting for credentials.
+func (m Model) tagSearchTargets(all bool) ([]tagSearchTarget, []string) {
+	var (
+		targets []tagSearchTarget
+		skipped []string
+	)
+	current := m.currentContextIndex()
+	if m.registryClient != nil {
+		name := strings.TrimSpace(m.context)
+		if name == "" {
+			name = m.registryHost
+		}
+		targets = append(targets, tagSearchTarget{contextIndex: current, context: name, client: m.registryClient})
+	}
+	if !all {
+		return targets, nil
+	}
+	for i, option := range m.contexts {
+		if i == current || strings.TrimSpace(option.Host) == "" {
+			continue
+		}
+		name := contextDisplayName(option, i)
+		auth := option.Auth
+		auth.Normalize()
+		registry.ApplyAuthCache(&auth, option.Host)
+		if registry.ProviderForAuth(option.Host, auth).NeedsAuthPrompt(auth) {
+			skipped = append(skipped, name+": needs login")
+			continue
+		}
+		client, err := registry.NewClientWithLogger(option.Host, auth, m.logger)
+		if err != nil {
+			skipped = append(skipped, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		targets = append(targets, tagSearchTarget{contextIndex: i, context: name, client: client})
+	}
+	return targets, skipped
+}
+
+func (m *Model) stopTagSearch() {
+	if m.tagSearchCancel != nil {
+		m.tagSearchCancel()
+		m.tagSearchCancel = nil
+	}
+	m.tagSearchRunning = false
+}
+
+func (m Model) updateTagSearchMsg(msg tagSearchMsg) (tea.Model, tea.Cmd) {
+	if msg.done {
+		if msg.id == m.tagSearchID && m.tagSearchRunning {
+			m.stopTagSearch()
+			m.status = fmt.Sprintf("Tag %s found in %d of %d repositories searched", m.tagSearchTag, len(m.tagSearchResults), m.tagSearchSearched)
+		}
+		return m, nil
+	}
+	// Stale searches are drained until their worker goroutine exits.
+	next := listenTagSearch(msg.id, msg.ch)
+	if msg.id != m.tagSearchID {
+		return m, next
+	}
+	m.tagSearchTotal += msg.total
+	m.tagSearchSearched += msg.searched
+	m.tagSearchResults = append(m.tagSearchResults, msg.found...)
+	if msg.skipped != "" {
+		m.tagSearchSkipped = append(m.tagSearchSkipped, msg.skipped)
+	}
+	return m, next
+}
+
+func (m Model) handleTagSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	last := len(m.tagSearchResults) - 1
+	switch {
+	case msg.String() == "ctrl+c":
+		m.stopTagSearch()
+		m.tagSearchActive = false
+		return m.openQuitConfirm()
+	case msg.String() == "esc":
+		if m.tagSearchRunning {
+			m.stopTagSearch()
+			m.status = fmt.Sprintf("Search for tag %s canceled", m.tagSearchTag)
+			return m, nil
+		}
+		m.tagSearchActive = false
+	case msg.String() == "q":
+		m.stopTagSearch()
+		m.tagSearchActive = false
+	case msg.String() == "enter":
+		return m.openTagSearchResult()
+	case isShortcut(msg, shortcutMoveUp):
+		m.tagSearchIndex = clampInt(m.tagSearchIndex-1, 0, maxInt(0, last))
+	case isShortcut(msg, shortcutMoveDown):
+		m.tagSearchIndex = clampInt(m.tagSearchIndex+1, 0, maxInt(0, last))
+	case isShortcut(msg, shortcutMovePageUp):
+		m.tagSearchIndex = clampInt(m.tagSearchIndex-m.tagSearchVisibleRows(), 0, maxInt(0, last))
+	case isShortcut(msg, shortcutMovePageDown):
+		m.tagSearchIndex = clampInt(m.tagSearchIndex+m.tagSearchVisibleRows(), 0, maxInt(0, last))
+	case isShortcut(msg, shortcutMoveTop):
+		m.tagSearchIndex = 0
+	case isShortcut(msg, shortcutMoveBottom):
+		m.tagSearchIndex = maxInt(0, last)
+	}
+	return m, nil
+}
+
+// openTagSearchResult drills into the result's image, switching context
+// first when needed, and leaves the cursor on the searched tag.
+func (m Model) openTagSearchResult() (tea.Model, tea.Cmd) {
+	if m.tagSearchIndex < 0 || m.tagSearchIndex >= len(m.tagSearchResults) {
+		return m, nil
+	}
+	result := m.tagSearchResults[m.tagSearchIndex]
+	m.stopTagSearch()
+	m.tagSearchActive = false
+
+	if result.contextIndex >= 0 && result.contextIndex != m.currentContextIndex() {
+		updated, cmd := m.switchContextAt(result.contextIndex)
+		next := updated.(Model)
+		next.pendingDefaultPath = result.image
+		next.pendingTag = m.tagSearchTag
+		return next, cmd
+	}
+	m.pendingDefaultPath = result.image
+	m.pendingTag = m.tagSearchTag
+	return m, m.initialLoadCmd()
+}
+
+// selectPendingTag moves the cursor to pendingTag after a tag list loads.
+func (m *Model) selectPendingTag() {
+	tag := m.pendingTag
+	m.pendingTag = ""
+	if tag == "" {
+		return
+	}
+	list := m.listView()
+	for row, index := range list.indices {
+		if index >= 0 && index < len(m.tags) && m.tags[index].Name == tag {
+			m.tableSetCursor(row)
+			return
+		}
+	}
+}
+
+func (m Model) tagSearchVisibleRows() int {
+	height := m.height
+	if height <= 0 {
+		height = 24
+	}
+	return maxInt(3, height-16)
+}
+
+func (m Model) renderTagSearchModal() string {
+	scope := "current registry"
+	if m.tagSearchAll {
+		scope = "all contexts"
+	}
+	lines := []string{
+		modalTitleStyle.Render(fmt.Sprintf("Find tag %s", m.tagSearchTag)),
+		modalLabelStyle.Render(fmt.Sprintf("Searching %s", scope)),
+		modalDividerStyle.Render(strings.Repeat("─", 24)),
+	}
+
+	progress := fmt.Sprintf("%d/%d repositories searched • %d found", m.tagSearchSearched, m.tagSearchTotal, len(m.tagSearchResults))
+	if m.tagSearchRunning {
+		progress = "Searching... " + progress
+	}
+	lines = append(lines, modalLabelStyle.Render(progress))
+	for _, note := range m.tagSearchSkipped {
+		lines = append(lines, modalOptionErrorStyle.Render("skipped "+note))
+	}
+	lines = append(lines, "")
+
+	if len(m.tagSearchResults) == 0 && !m.tagSearchRunning {
+		lines = append(lines, modalLabelStyle.Render("No repository has this tag."))
+	}
+	visible := m.tagSearchVisibleRows()
+	start := clampInt(m.tagSearchIndex-visible+1, 0, maxInt(0, len(m.tagSearchResults)-visible))
+	end := minInt(len(m.tagSearchResults), start+visible)
+	width := m.modalWidth(96) - 8
+	for i := start; i < end; i++ {
+		result := m.tagSearchResults[i]
+		label := truncateLogLine(fmt.Sprintf("%s/%s:%s", result.context, result.image, m.tagSearchTag), width)
+		if i == m.tagSearchIndex {
+			lines = append(lines, modalFocusStyle.Render("> "+label))
+		} else {
+			lines = append(lines, modalLabelStyle.Render("  "+label))
+		}
+	}
+
+	help := "up/down move • enter open • esc close"
+	if m.tagSearchRunning {
+		help = "up/down move • enter open • esc cancel search"
+	}
+	lines = append(lines, "", modalHelpStyle.Render(help))
+	return m.renderModalCard(strings.Join(lines, "\n"), 96)
+}
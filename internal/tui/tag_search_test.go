@@ -0,0 +1,133 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+type fakeTagSearchClient struct {
+	registry.Client
+	tags map[string][]string
+}
+
+func (c fakeTagSearchClient) ListImages(context.Context) ([]registry.Image, error) {
+	var images []registry.Image
+	for name := range c.tags {
+		images = append(images, registry.Image{Name: name})
+	}
+	return images, nil
+}
+
+func (c fakeTagSearchClient) ListTags(_ context.Context, image string) ([]registry.Tag, error) {
+	var tags []registry.Tag
+	for _, name := range c.tags[image] {
+		tags = append(tags, registry.Tag{Name: name})
+	}
+	return tags, nil
+}
+
+func TestFindTagSearchesAndOpensResult(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.registryClient = fakeTagSearchClient{tags: map[string][]string{
+		"team/app": {"latest", "v2.3.1"},
+		"team/web": {"v2.3.0"},
+		"tool":     {"v2.3.1", "v1"},
+	}}
+
+	updated, cmd := runFindTagCommand(m, []string{"v2.3.1"})
+	next := updated.(Model)
+	if !next.tagSearchActive || !next.tagSearchRunning || cmd == nil {
+		t.Fatalf("expected a running search")
+	}
+	for cmd != nil {
+		updated, cmd = next.Update(cmd())
+		next = updated.(Model)
+	}
+
+	if next.tagSearchRunning {
+		t.Fatalf("expected search to finish")
+	}
+	if next.tagSearchTotal != 3 || next.tagSearchSearched != 3 {
+		t.Fatalf("expected 3/3 repositories searched, got %d/%d", next.tagSearchSearched, next.tagSearchTotal)
+	}
+	found := map[string]bool{}
+	for _, result := range next.tagSearchResults {
+		found[result.image] = true
+	}
+	if len(found) != 2 || !found["team/app"] || !found["tool"] {
+		t.Fatalf("unexpected results %+v", next.tagSearchResults)
+	}
+
+	for i, result := range next.tagSearchResults {
+		if result.image == "team/app" {
+			next.tagSearchIndex = i
+		}
+	}
+	updated, _ = next.openTagSearchResult()
+	next = updated.(Model)
+	if next.tagSearchActive || next.pendingDefaultPath != "team/app" {
+		t.Fatalf("expected result to open team/app, got %q", next.pendingDefaultPath)
+	}
+
+	updated, _ = next.Update(imagesMsg{images: []registry.Image{{Name: "team/app"}, {Name: "tool"}}})
+	next = updated.(Model)
+	updated, _ = next.Update(tagsMsg{tags: []registry.Tag{{Name: "latest"}, {Name: "v1"}, {Name: "v2.3.1"}}})
+	next = updated.(Model)
+	list := next.listView()
+	if got := next.tags[list.indices[next.table.Cursor()]].Name; got != "v2.3.1" {
+		t.Fatalf("expected cursor on v2.3.1, got %s", got)
+	}
+}
+
+// streamingTagSearchClient lists images only in batches, like Harbor.
+type streamingTagSearchClient struct {
+	fakeTagSearchClient
+	batches [][]string
+}
+
+func (c streamingTagSearchClient) ListImages(context.Context) ([]registry.Image, error) {
+	return nil, errors.New("full listing not expected")
+}
+
+func (c streamingTagSearchClient) StreamImages(_ context.Context, emit func([]registry.Image)) error {
+	for _, batch := range c.batches {
+		var images []registry.Image
+		for _, name := range batch {
+			images = append(images, registry.Image{Name: name})
+		}
+		emit(images)
+	}
+	return nil
+}
+
+func TestFindTagSearchesStreamedBatches(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.registryClient = streamingTagSearchClient{
+		fakeTagSearchClient: fakeTagSearchClient{tags: map[string][]string{
+			"team/app": {"v1"},
+			"team/web": {"v2"},
+			"infra/db": {"v1"},
+		}},
+		batches: [][]string{{"team/app", "team/web"}, {"infra/db"}},
+	}
+
+	updated, cmd := runFindTagCommand(m, []string{"v1"})
+	next := updated.(Model)
+	for cmd != nil {
+		updated, cmd = next.Update(cmd())
+		next = updated.(Model)
+	}
+	if next.tagSearchTotal != 3 || next.tagSearchSearched != 3 || len(next.tagSearchSkipped) != 0 {
+		t.Fatalf("expected 3/3 streamed repositories searched, got %d/%d skipped %v", next.tagSearchSearched, next.tagSearchTotal, next.tagSearchSkipped)
+	}
+	if len(next.tagSearchResults) != 2 {
+		t.Fatalf("expected team/app and infra/db, got %+v", next.tagSearchResults)
+	}
+}
@@ -0,0 +1,231 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+const tagSelectedMark = "✓"
+
+func (m *Model) toggleTagSelection() {
+	image, tag, ok := m.selectedTagImageAndTag()
+	if !ok || m.focus != FocusTags {
+		m.status = "No tag selected"
+		return
+	}
+	if m.tagSelectionImage != image || m.tagSelection == nil {
+		m.tagSelection = make(map[string]bool)
+		m.tagSelectionImage = image
+	}
+	if m.tagSelection[tag] {
+		delete(m.tagSelection, tag)
+	} else {
+		m.tagSelection[tag] = true
+	}
+	m.status = fmt.Sprintf("%d tags selected; press delete to remove them", len(m.tagSelection))
+	m.syncTable()
+	m.tableSetCursor(minInt(m.table.Cursor()+1, len(m.table.Rows())-1))
+}
+
+// clearTagSelection reports whether there was a selection to clear.
+func (m *Model) clearTagSelection() bool {
+	if len(m.selectedTagNames()) == 0 {
+		return false
+	}
+	m.tagSelection = nil
+	m.tagSelectionImage = ""
+	m.status = "Selection cleared"
+	m.syncTable()
+	return true
+}
+
+// pruneTagSelection drops selected tags that are gone after a reload.
+func (m *Model) pruneTagSelection() {
+	if !m.hasSelectedImage || m.tagSelectionImage != m.selectedImage.Name {
+		m.tagSelection = nil
+		m.tagSelectionImage = ""
+		return
+	}
+	for tag := range m.tagSelection {
+		if !hasTagNamed(m.tags, tag) {
+			delete(m.tagSelection, tag)
+		}
+	}
+}
+
+// selectedTagNames lists the selected tags of the open image in list order.
+func (m Model) selectedTagNames() []string {
+	if len(m.tagSelection) == 0 || !m.hasSelectedImage || m.tagSelectionImage != m.selectedImage.Name {
+		return nil
+	}
+	var names []string
+	for _, tag := range m.tags {
+		if m.tagSelection[tag.Name] {
+			names = append(names, tag.Name)
+		}
+	}
+	return names
+}
+
+func (m Model) markSelectedTagRows(list listView) listView {
+	if len(m.selectedTagNames()) == 0 {
+		return list
+	}
+	rows := make([][]string, len(list.rows))
+	for i, row := range list.rows {
+		marked := append([]string(nil), row...)
+		index := list.indices[i]
+		if index >= 0 && index < len(m.tags) && m.tagSelection[m.tags[index].Name] {
+			marked[0] = tagSelectedMark + " " + marked[0]
+		} else {
+			marked[0] = "  " + marked[0]
+		}
+		rows[i] = marked
+	}
+	list.rows = rows
+	return list
+}
+
+func runDeleteCommand(m Model, args []string) (tea.Model, tea.Cmd) {
+	if len(args) != 0 {
+		m.status = "Usage: :delete"
+		return m, nil
+	}
+	return m.confirmDeleteTags()
+}
+
+// confirmDeleteTags asks before deleting the selection, or the tag under the
+// cursor when nothing is selected.
+func (m Model) confirmDeleteTags() (tea.Model, tea.Cmd) {
+	if m.dockerHubActive || m.githubActive || m.focus != FocusTags || !m.hasSelectedImage {
+		m.status = "Open an image's tags to delete tags"
+		return m, nil
+	}
+	if m.registryClient == nil {
+		m.status = "Registry client not ready"
+		return m, nil
+	}
+	tags := m.selectedTagNames()
+	if len(tags) == 0 {
+		_, tag, ok := m.selectedTagImageAndTag()
+		if !ok {
+			m.status = "No tag selected to delete"
+			return m, nil
+		}
+		tags = []string{tag}
+	}
+
+	image := m.selectedImage.Name
+	m.confirmAction = confirmActionDeleteTags
+	m.confirmTitle = fmt.Sprintf("Delete %s from %s?", pluralTags(len(tags)), image)
+	m.confirmMessage = summarizeTags(tags, 5) + ". This cannot be undone."
+	m.confirmFocus = 0
+	m.deleteImage = image
+	m.deleteTags = tags
+	return m, nil
+}
+
+func (m Model) startDeleteTags(image string, tags []string) (tea.Model, tea.Cmd) {
+	if m.registryClient == nil {
+		m.status = "Registry client not ready"
+		return m, nil
+	}
+	m.status = fmt.Sprintf("Deleting %s from %s...", pluralTags(len(tags)), image)
+	m.startLoading()
+	return m, deleteTagsCmd(m.registryClient, image, tags)
+}
+
+// deleteTagsCmd deletes the tags one by one, stopping early when the
+// registry does not support deletion at all.
+func deleteTagsCmd(client registry.Client, image string, tags []string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(len(tags))*15*time.Second)
+		defer cancel()
+
+		msg := deleteTagsMsg{image: image}
+		for _, tag := range tags {
+			err := client.DeleteTag(ctx, image, tag)
+			if errors.Is(err, registry.ErrNotSupported) {
+				msg.err = err
+				return msg
+			}
+			if err != nil {
+				msg.failed = append(msg.failed, tag)
+				if msg.err == nil {
+					msg.err = fmt.Errorf("%s: %w", tag, err)
+				}
+				continue
+			}
+			msg.deleted = append(msg.deleted, tag)
+		}
+		return msg
+	}
+}
+
+func (m Model) updateDeleteTagsMsg(msg deleteTagsMsg) (tea.Model, tea.Cmd) {
+	m.stopLoading()
+	if errors.Is(msg.err, registry.ErrNotSupported) {
+		m.setErrorStatus("Deleting tags is not supported by this registry")
+		return m, nil
+	}
+	if m.tagSelectionImage == msg.image {
+		for _, tag := range msg.deleted {
+			delete(m.tagSelection, tag)
+		}
+	}
+	if msg.err != nil {
+		m.setErrorStatus(fmt.Sprintf("Deleted %d of %d tags; error deleting %v", len(msg.deleted), len(msg.deleted)+len(msg.failed), msg.err))
+	} else {
+		m.status = fmt.Sprintf("Deleted %s from %s", pluralTags(len(msg.deleted)), msg.image)
+	}
+	if m.hasSelectedImage && m.selectedImage.Name == msg.image {
+		m.removeTags(msg.deleted)
+	}
+	m.syncTable()
+	return m, nil
+}
+
+// removeTags drops deleted tags from the open list without a reload, so the
+// result stays in the status line.
+func (m *Model) removeTags(names []string) {
+	deleted := make(map[string]bool, len(names))
+	for _, name := range names {
+		deleted[name] = true
+	}
+	kept := m.tags[:0:0]
+	for _, tag := range m.tags {
+		if !deleted[tag.Name] {
+			kept = append(kept, tag)
+		}
+	}
+	m.tags = kept
+	m.selectedImage.TagCount = len(kept)
+	for i := range m.images {
+		if m.images[i].Name == m.selectedImage.Name {
+			m.images[i].TagCount = len(kept)
+			break
+		}
+	}
+}
+
+func pluralTags(n int) string {
+	if n == 1 {
+		return "1 tag"
+	}
+	return fmt.Sprintf("%d tags", n)
+}
+
+// summarizeTags lists up to limit names and counts the rest.
+func summarizeTags(tags []string, limit int) string {
+	if len(tags) <= limit {
+		return strings.Join(tags, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(tags[:limit], ", "), len(tags)-limit)
+}
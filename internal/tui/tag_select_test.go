@@ -0,0 +1,112 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+type fakeDeleteClient struct {
+	registry.Client
+	deleted []string
+	refuse  string
+}
+
+func (c *fakeDeleteClient) DeleteTag(_ context.Context, image, tag string) error {
+	if tag == c.refuse {
+		return errors.New("405 Method Not Allowed")
+	}
+	c.deleted = append(c.deleted, image+":"+tag)
+	return nil
+}
+
+func newTagSelectModel(client registry.Client) Model {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.registryClient = client
+	m.focus = FocusTags
+	m.hasSelectedImage = true
+	m.selectedImage = registry.Image{Name: "team/app"}
+	m.tags = []registry.Tag{{Name: "v1"}, {Name: "v2"}, {Name: "v3"}}
+	m.syncTable()
+	return m
+}
+
+func TestSpaceSelectsTagsAndDeleteRemovesThem(t *testing.T) {
+	client := &fakeDeleteClient{}
+	m := newTagSelectModel(client)
+	space := tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+
+	updated, _ := m.handleKey(space)
+	m = updated.(Model)
+	m.tableSetCursor(2)
+	updated, _ = m.handleKey(space)
+	m = updated.(Model)
+	if got := strings.Join(m.selectedTagNames(), ","); got != "v1,v3" {
+		t.Fatalf("expected v1 and v3 selected, got %q", got)
+	}
+	if rows := m.table.Rows(); rows[0][0] != "✓ v1" || rows[1][0] != "  v2" {
+		t.Fatalf("expected selected rows to be marked, got %q and %q", rows[0][0], rows[1][0])
+	}
+	var copied string
+	writeClipboard = func(value string) error {
+		copied = value
+		return nil
+	}
+	defer func() { writeClipboard = clipboardWriteAll }()
+	if runCopyCommand(m, []string{"all"}); !strings.Contains(copied, "\nv1") || strings.Contains(copied, "✓") {
+		t.Fatalf("expected copied rows without the selection mark, got %q", copied)
+	}
+
+	updated, _ = m.handleKey(tea.KeyMsg{Type: tea.KeyDelete})
+	m = updated.(Model)
+	if m.confirmAction != confirmActionDeleteTags || !strings.Contains(m.confirmTitle, "2 tags") {
+		t.Fatalf("expected a confirmation for 2 tags, got %v %q", m.confirmAction, m.confirmTitle)
+	}
+
+	updated, cmd := m.handleConfirmKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatalf("expected confirming to start the delete")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if strings.Join(client.deleted, ",") != "team/app:v1,team/app:v3" {
+		t.Fatalf("unexpected deletes %v", client.deleted)
+	}
+	if len(m.tags) != 1 || m.tags[0].Name != "v2" || len(m.selectedTagNames()) != 0 {
+		t.Fatalf("expected only v2 left and no selection, got %+v", m.tags)
+	}
+}
+
+func TestBatchDeleteReportsPartialFailure(t *testing.T) {
+	client := &fakeDeleteClient{refuse: "v2"}
+	m := newTagSelectModel(client)
+	m.tagSelection = map[string]bool{"v1": true, "v2": true}
+	m.tagSelectionImage = "team/app"
+
+	updated, cmd := m.startDeleteTags("team/app", m.selectedTagNames())
+	m = updated.(Model)
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if !m.statusIsError() || !strings.Contains(m.status, "Deleted 1 of 2 tags") {
+		t.Fatalf("expected a partial failure status, got %q", m.status)
+	}
+	if got := strings.Join(m.selectedTagNames(), ","); got != "v2" {
+		t.Fatalf("expected the failed tag to stay selected, got %q", got)
+	}
+
+	updated, _ = m.handleKey(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	updated, _ = m.handleKey(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if len(m.selectedTagNames()) != 0 || m.focus != FocusTags {
+		t.Fatalf("expected esc to clear the selection before leaving tags")
+	}
+}
@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+// tagsLoadCmd lists an image's tags, streaming pages and reusing the
+// server-side query when the registry supports it.
+func (m *Model) tagsLoadCmd(image string) tea.Cmd {
+	if streamer, ok := m.registryClient.(registry.TagStreamer); ok {
+		return m.startTagStream(streamer, image, m.tagsQuery)
+	}
+	return loadTagsCmd(m.registryClient, image)
+}
+
+// startTagStream cancels the previous tag stream before starting the next.
+func (m *Model) startTagStream(streamer registry.TagStreamer, image, query string) tea.Cmd {
+	m.cancelTagStream()
+	ctx, cancel := context.WithTimeout(context.Background(), tagStreamTimeout)
+	m.cancelTags = cancel
+	return streamTagsCmd(ctx, cancel, m.tagsStream, streamer, image, query)
+}
+
+// cancelTagStream stops the running stream; its remaining pages and final
+// list are ignored.
+func (m *Model) cancelTagStream() {
+	if m.cancelTags != nil {
+		m.cancelTags()
+		m.cancelTags = nil
+	}
+	m.tagsStream++
+	m.tagsStreaming = false
+}
+
+// queryTagsForFilter refetches the tag list when the applied filter differs
+// from the one it was fetched with, so registries that filter server-side
+// only page through matching artifacts. Clearing the filter reloads the
+// full list.
+func (m *Model) queryTagsForFilter() tea.Cmd {
+	if m.focus != FocusTags || !m.hasSelectedImage {
+		return nil
+	}
+	streamer, ok := m.registryClient.(registry.TagStreamer)
+	if !ok {
+		return nil
+	}
+	query := strings.TrimSpace(m.filterInput.Value())
+	if query == m.tagsQuery {
+		return nil
+	}
+	m.tagsQuery = query
+	m.tagsStreaming = false
+	if query == "" {
+		m.status = fmt.Sprintf("Loading tags for %s...", m.selectedImage.Name)
+	} else {
+		m.status = fmt.Sprintf("Searching %s tags matching %q...", m.selectedImage.Name, query)
+	}
+	m.startLoading()
+	return m.startTagStream(streamer, m.selectedImage.Name, query)
+}
+
+func (m Model) updateTagsPartialMsg(msg tagsPartialMsg) (tea.Model, tea.Cmd) {
+	if msg.stream != m.tagsStream || !m.hasSelectedImage || m.selectedImage.Name != msg.image || msg.query != m.tagsQuery {
+		return m, msg.next
+	}
+	if !m.tagsStreaming {
+		m.tagsStreaming = true
+		m.tags = nil
+		m.history = nil
+		m.hasSelectedTag = false
+		m.selectedTag = registry.Tag{}
+		m.focus = FocusTags
+	}
+	m.tags = append(m.tags, msg.tags...)
+	// A signature can arrive on a later page than the tag it signs.
+	registry.MarkSignedTags(m.tags)
+	m.status = fmt.Sprintf("Loading tags... %d so far", len(m.tags))
+	m.syncTable()
+	return m, msg.next
+}
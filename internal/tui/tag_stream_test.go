@@ -0,0 +1,137 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+type fakeTagStreamer struct {
+	registry.Client
+	queries []string
+}
+
+func (c *fakeTagStreamer) StreamTags(_ context.Context, _ string, filter string, emit func([]registry.Tag)) error {
+	c.queries = append(c.queries, filter)
+	if filter == "" {
+		emit([]registry.Tag{{Name: "latest"}, {Name: "v1.0"}})
+		emit([]registry.Tag{{Name: "v2.0"}})
+		return nil
+	}
+	emit([]registry.Tag{{Name: "v1.0"}})
+	return nil
+}
+
+func TestFilterQueriesTagStreamer(t *testing.T) {
+	client := &fakeTagStreamer{}
+	m := newTagSelectModel(client)
+
+	m.filterActive = true
+	m.filterInput.SetValue("v1")
+	updated, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if cmd == nil || m.tagsQuery != "v1" {
+		t.Fatalf("expected applying the filter to query the registry, got query %q", m.tagsQuery)
+	}
+	updated, cmd = m.Update(cmd())
+	m = updated.(Model)
+	if len(m.tags) != 1 || !strings.Contains(m.status, "1 so far") {
+		t.Fatalf("expected the first page to show while streaming, got %+v %q", m.tags, m.status)
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if m.filterInput.Value() != "v1" || !strings.Contains(m.status, `matching "v1"`) {
+		t.Fatalf("expected the filter to stay applied, got %q %q", m.filterInput.Value(), m.status)
+	}
+
+	m.filterActive = true
+	updated, cmd = m.handleKey(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if cmd == nil || m.tagsQuery != "" {
+		t.Fatalf("expected clearing the filter to reload every tag")
+	}
+	for msg := cmd(); ; {
+		updated, cmd = m.Update(msg)
+		m = updated.(Model)
+		if _, done := msg.(tagsMsg); done {
+			break
+		}
+		msg = cmd()
+	}
+	if len(m.tags) != 3 || strings.Join(client.queries, ",") != "v1," {
+		t.Fatalf("expected the full list after clearing, got %+v queries %q", m.tags, client.queries)
+	}
+
+	// A query result that arrives after the filter changed is dropped.
+	updated, _ = m.Update(tagsMsg{image: "team/app", query: "v1", tags: []registry.Tag{{Name: "v1.0"}}})
+	m = updated.(Model)
+	if len(m.tags) != 3 {
+		t.Fatalf("expected a stale query result to be ignored, got %+v", m.tags)
+	}
+}
+
+func TestTagStreamEndKeepsHistoryOpen(t *testing.T) {
+	m := newTagSelectModel(&fakeTagStreamer{})
+	msg := m.refreshCurrent()()
+	first, ok := msg.(tagsPartialMsg)
+	if !ok {
+		t.Fatalf("expected the first page, got %T", msg)
+	}
+	updated, cmd := m.Update(first)
+	m = updated.(Model)
+
+	// Open the first tag's history while the second page is still pending.
+	updated, _ = m.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.focus != FocusHistory {
+		t.Fatalf("expected enter to open history, got focus %v", m.focus)
+	}
+	for msg = cmd(); ; {
+		updated, cmd = m.Update(msg)
+		m = updated.(Model)
+		if _, done := msg.(tagsMsg); done {
+			break
+		}
+		msg = cmd()
+	}
+	if m.focus != FocusHistory || !m.hasSelectedTag || len(m.tags) != 3 {
+		t.Fatalf("expected history to stay open with the full list behind it, got focus %v tags %+v", m.focus, m.tags)
+	}
+}
+
+type blockingTagStreamer struct {
+	registry.Client
+	done chan error
+}
+
+func (c *blockingTagStreamer) StreamTags(ctx context.Context, _ string, _ string, emit func([]registry.Tag)) error {
+	emit([]registry.Tag{{Name: "v1"}})
+	<-ctx.Done()
+	c.done <- ctx.Err()
+	return ctx.Err()
+}
+
+func TestLeavingTagsCancelsStream(t *testing.T) {
+	client := &blockingTagStreamer{done: make(chan error, 1)}
+	m := newTagSelectModel(client)
+	cmd := m.refreshCurrent()
+	msg := cmd()
+	updated, _ := m.Update(msg)
+	m = updated.(Model)
+
+	updated, _ = m.handleKey(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if err := <-client.done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected leaving the image to cancel the stream, got %v", err)
+	}
+	updated, _ = m.Update(msg.(tagsPartialMsg).next())
+	m = updated.(Model)
+	if m.focus != FocusImages || m.statusIsError() || len(m.tags) != 0 {
+		t.Fatalf("expected the canceled stream to be ignored, got focus %v status %q", m.focus, m.status)
+	}
+}
@@ -10,15 +10,28 @@ import (
 )
 
 func (m Model) updateKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if isShortcut(msg, shortcutSuspend) {
+		return m, tea.Suspend
+	}
+	if m.welcomeActive {
+		return m.dismissWelcome()
+	}
 	if m.helpActive {
 		return m.handleHelpKey(msg)
 	}
 	if isHelpShortcut(msg) &&
 		!m.commandActive &&
 		!m.filterActive &&
+		!m.repoPromptActive &&
 		!(m.dockerHubActive && m.dockerHubInputFocus) &&
 		!(m.githubActive && m.githubInputFocus) &&
 		!m.isConfirmModalActive() &&
+		!m.columnTogglesActive &&
+		!m.historyDetailActive &&
+		!m.tagDiffActive &&
+		!m.rowPreviewActive &&
+		!m.tagSearchActive &&
+		!m.recentContextsActive &&
 		!m.isContextFormActive() &&
 		!m.isContextSelectionActive() &&
 		!m.isAuthModalActive() {
@@ -27,6 +40,24 @@ func (m Model) updateKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if m.isConfirmModalActive() {
 		return m.handleConfirmKey(msg)
 	}
+	if m.columnTogglesActive {
+		return m.handleColumnTogglesKey(msg)
+	}
+	if m.historyDetailActive {
+		return m.handleHistoryDetailKey(msg)
+	}
+	if m.tagDiffActive {
+		return m.handleTagDiffKey(msg)
+	}
+	if m.rowPreviewActive {
+		return m.handleRowPreviewKey(msg)
+	}
+	if m.tagSearchActive {
+		return m.handleTagSearchKey(msg)
+	}
+	if m.recentContextsActive {
+		return m.handleRecentContextsKey(msg)
+	}
 	if m.isContextFormActive() {
 		return m.handleContextFormKey(msg)
 	}
@@ -39,6 +70,9 @@ func (m Model) updateKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if m.commandActive {
 		return m.handleCommandKey(msg)
 	}
+	if m.repoPromptActive {
+		return m.handleRepoPromptKey(msg)
+	}
 	if m.dockerHubActive {
 		return m.handleDockerHubKey(msg)
 	}
@@ -50,7 +84,14 @@ func (m Model) updateKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 func (m Model) updateMouseMsg(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 	if m.helpActive ||
+		m.welcomeActive ||
 		m.commandActive ||
+		m.columnTogglesActive ||
+		m.historyDetailActive ||
+		m.tagDiffActive ||
+		m.rowPreviewActive ||
+		m.tagSearchActive ||
+		m.recentContextsActive ||
 		m.isConfirmModalActive() ||
 		m.isContextFormActive() ||
 		m.isContextSelectionActive() ||
@@ -73,13 +114,56 @@ func (m Model) updateWindowSizeMsg(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// updateResumeMsg redraws from scratch after fg: the terminal may have been
+// resized or scribbled on while Beacon was stopped.
+func (m Model) updateResumeMsg() (tea.Model, tea.Cmd) {
+	m.syncTable()
+	return m, tea.Batch(tea.ClearScreen, tea.WindowSize())
+}
+
 func (m Model) updateImagesMsg(msg imagesMsg) (tea.Model, tea.Cmd) {
+	if msg.appendPage {
+		return m.updateImagesPageMsg(msg)
+	}
 	m.stopLoading()
+	watched := m.watchRefreshing
+	m.watchRefreshing = false
+	streamed := m.imagesStreaming
+	m.imagesStreaming = false
+	m.imagesNext = msg.next
+	m.imagesLoadingMore = false
+	if cmd, ok := m.loginOnListingUnauthorized(msg.err); ok {
+		m.syncTable()
+		return m, cmd
+	}
+	m.catalogForbidden = errors.Is(msg.err, registry.ErrCatalogForbidden) || errors.Is(msg.err, registry.ErrCatalogDisabled)
+	if m.catalogForbidden {
+		m.images = nil
+		m.focus = FocusImages
+		m.status = "Catalog listing not permitted — use search or enter an image name"
+		if errors.Is(msg.err, registry.ErrCatalogDisabled) {
+			m.status = "Catalog listing disabled on this registry — enter a repository path"
+		}
+		m.syncTable()
+		return m, m.openRepoPrompt()
+	}
 	if msg.err != nil {
-		m.status = fmt.Sprintf("Error loading images: %v", msg.err)
+		m.setErrorStatus(fmt.Sprintf("Error loading images: %s", loadErrorText(msg.err)))
 		m.syncTable()
 		return m, nil
 	}
+	if watched {
+		return m.updateWatchedImages(msg.images)
+	}
+	if streamed && m.focus != m.defaultFocus() {
+		// The user already drilled in while batches arrived; keep their place.
+		m.images = msg.images
+		if m.tableSpec().SupportsProjects {
+			m.projects = deriveProjects(msg.images)
+		}
+		m.status = m.imagesLoadedStatus()
+		return m, nil
+	}
 	m.images = msg.images
 	m.projects = nil
 	m.tags = nil
@@ -92,23 +176,62 @@ func (m Model) updateImagesMsg(msg imagesMsg) (tea.Model, tea.Cmd) {
 	m.focus = m.defaultFocus()
 	if m.tableSpec().SupportsProjects {
 		m.projects = deriveProjects(msg.images)
-		m.status = fmt.Sprintf("Loaded %d images across %d projects", len(msg.images), len(m.projects))
-	} else {
-		m.status = fmt.Sprintf("Loaded %d images", len(msg.images))
 	}
-	m.clearFilter()
+	m.status = m.imagesLoadedStatus()
+	m.resetFilterOnNavigate()
+	counts := m.startTagCounts(true)
 	m.syncTable()
-	return m, nil
+	return m, tea.Batch(m.followDefaultPath(), counts)
+}
+
+func (m Model) updateImagesPartialMsg(msg imagesPartialMsg) (tea.Model, tea.Cmd) {
+	if msg.client != m.registryClient {
+		return m, msg.next
+	}
+	if !m.imagesStreaming {
+		m.imagesStreaming = true
+		m.images = nil
+		m.projects = nil
+		m.tags = nil
+		m.history = nil
+		m.selectedProject = ""
+		m.hasSelectedProject = false
+		m.hasSelectedImage = false
+		m.hasSelectedTag = false
+		m.selectedTag = registry.Tag{}
+		m.focus = m.defaultFocus()
+		m.clearFilter()
+	}
+	m.images = append(m.images, msg.images...)
+	if m.tableSpec().SupportsProjects {
+		m.projects = deriveProjects(m.images)
+	}
+	m.status = fmt.Sprintf("Loading images... %d so far", len(m.images))
+	if msg.total > 0 && msg.total >= len(m.images) {
+		m.status = fmt.Sprintf("Loading images... %d/%d", len(m.images), msg.total)
+	}
+	m.syncTable()
+	return m, msg.next
 }
 
 func (m Model) updateProjectsMsg(msg projectsMsg) (tea.Model, tea.Cmd) {
 	m.stopLoading()
+	watched := m.watchRefreshing
+	m.watchRefreshing = false
+	if cmd, ok := m.loginOnListingUnauthorized(msg.err); ok {
+		m.syncTable()
+		return m, cmd
+	}
 	if msg.err != nil {
-		m.status = fmt.Sprintf("Error loading projects: %v", msg.err)
+		m.setErrorStatus(fmt.Sprintf("Error loading projects: %s", loadErrorText(msg.err)))
 		m.syncTable()
 		return m, nil
 	}
+	previous := m.projects
 	m.projects = toProjectInfos(msg.projects)
+	if watched {
+		keepArtifactCounts(m.projects, previous)
+	}
 	m.images = nil
 	m.tags = nil
 	m.history = nil
@@ -120,15 +243,61 @@ func (m Model) updateProjectsMsg(msg projectsMsg) (tea.Model, tea.Cmd) {
 	m.hasSelectedTag = false
 	m.focus = FocusProjects
 	m.status = fmt.Sprintf("Loaded %d projects", len(msg.projects))
-	m.clearFilter()
+	if watched {
+		// Watch ticks keep the totals they had instead of listing every
+		// repository again.
+		m.syncTable()
+		return m, m.followDefaultPath()
+	}
+	m.resetFilterOnNavigate()
+	m.syncTable()
+	return m, tea.Batch(m.followDefaultPath(), m.loadProjectArtifactCounts())
+}
+
+// loadProjectArtifactCounts fetches the artifact totals when the Artifacts
+// column is shown and the client can count them.
+func (m Model) loadProjectArtifactCounts() tea.Cmd {
+	counter, ok := m.registryClient.(registry.ProjectArtifactCounter)
+	if !ok || !m.effectiveTableSpec().Project.ShowArtifactCount {
+		return nil
+	}
+	return loadProjectArtifactCountsCmd(m.registryClient, counter)
+}
+
+func keepArtifactCounts(projects, previous []projectInfo) {
+	known := make(map[string]int, len(previous))
+	for _, project := range previous {
+		known[project.Name] = project.ArtifactCount
+	}
+	for i := range projects {
+		if count, ok := known[projects[i].Name]; ok && projects[i].ArtifactCount < 0 {
+			projects[i].ArtifactCount = count
+		}
+	}
+}
+
+func (m Model) updateProjectArtifactCountsMsg(msg projectArtifactCountsMsg) (tea.Model, tea.Cmd) {
+	// Artifact totals are a nice-to-have; keep the project list if they fail.
+	if msg.client != m.registryClient || msg.err != nil {
+		return m, nil
+	}
+	for i := range m.projects {
+		if count, ok := msg.counts[m.projects[i].Name]; ok {
+			m.projects[i].ArtifactCount = count
+		} else {
+			m.projects[i].ArtifactCount = 0
+		}
+	}
 	m.syncTable()
 	return m, nil
 }
 
 func (m Model) updateProjectImagesMsg(msg projectImagesMsg) (tea.Model, tea.Cmd) {
 	m.stopLoading()
+	watched := m.watchRefreshing
+	m.watchRefreshing = false
 	if msg.err != nil {
-		m.status = fmt.Sprintf("Error loading images for %s: %v", msg.project, msg.err)
+		m.setErrorStatus(fmt.Sprintf("Error loading images for %s: %s", msg.project, loadErrorText(msg.err)))
 		m.syncTable()
 		return m, nil
 	}
@@ -144,23 +313,57 @@ func (m Model) updateProjectImagesMsg(msg projectImagesMsg) (tea.Model, tea.Cmd)
 	m.hasSelectedTag = false
 	m.focus = FocusImages
 	m.status = fmt.Sprintf("Loaded %d images for %s", len(msg.images), msg.project)
-	m.clearFilter()
+	if !watched {
+		m.resetFilterOnNavigate()
+	}
 	m.syncTable()
-	return m, nil
+	return m, m.followDefaultPath()
 }
 
 func (m Model) updateTagsMsg(msg tagsMsg) (tea.Model, tea.Cmd) {
 	m.stopLoading()
+	if msg.query != m.tagsQuery || (msg.stream != 0 && msg.stream != m.tagsStream) {
+		// A newer filter or load superseded this listing.
+		return m, nil
+	}
+	m.cancelTags = nil
+	watched := m.watchRefreshing
+	m.watchRefreshing = false
+	streamed := m.tagsStreaming
+	m.tagsStreaming = false
+	m.tagsLoadErr = msg.err
+	if errors.Is(msg.err, registry.ErrRepositoryNotFound) && m.hasSelectedImage {
+		m.setErrorStatus(fmt.Sprintf("Repository %s not found", m.selectedImage.Name))
+		m.syncTable()
+		return m, nil
+	}
+	if msg.err != nil && m.hasSelectedImage {
+		m.setErrorStatus(fmt.Sprintf("Error loading tags for %s: %s", m.selectedImage.Name, loadErrorText(msg.err)))
+		m.syncTable()
+		return m, nil
+	}
 	if msg.err != nil {
-		m.status = fmt.Sprintf("Error loading tags: %v", msg.err)
+		m.setErrorStatus(fmt.Sprintf("Error loading tags: %s", loadErrorText(msg.err)))
+		m.syncTable()
+		return m, nil
+	}
+	if streamed && (m.focus != FocusTags || !m.hasSelectedImage || m.selectedImage.Name != msg.image) {
+		// The pages already reset the view; the user may have moved on to a
+		// tag's history meanwhile, so only the list is replaced.
+		m.tags = msg.tags
+		registry.MarkSignedTags(m.tags)
 		m.syncTable()
 		return m, nil
 	}
 	m.tags = msg.tags
-	m.history = nil
-	m.hasSelectedTag = false
-	m.selectedTag = registry.Tag{}
-	if m.hasSelectedImage {
+	registry.MarkSignedTags(m.tags)
+	m.pruneTagSelection()
+	if !streamed {
+		m.history = nil
+		m.hasSelectedTag = false
+		m.selectedTag = registry.Tag{}
+	}
+	if m.hasSelectedImage && msg.query == "" {
 		m.selectedImage.TagCount = len(msg.tags)
 		for i := range m.images {
 			if m.images[i].Name == m.selectedImage.Name {
@@ -170,23 +373,76 @@ func (m Model) updateTagsMsg(msg tagsMsg) (tea.Model, tea.Cmd) {
 		}
 	}
 	m.focus = FocusTags
-	m.status = fmt.Sprintf("Loaded %d tags", len(msg.tags))
-	m.clearFilter()
+	if msg.query != "" {
+		m.status = fmt.Sprintf("Found %d tags matching %q on the server", len(msg.tags), msg.query)
+	} else if len(msg.tags) == 0 {
+		m.status = "No tags (repository is empty)"
+		m.clearFilter()
+	} else {
+		m.status = fmt.Sprintf("Loaded %d tags", len(msg.tags))
+		if !streamed && !watched {
+			// A filter typed while the pages streamed in is kept, and so is
+			// one applied before a watch tick.
+			m.resetFilterOnNavigate()
+		}
+	}
+	m.syncTable()
+	m.selectPendingTag()
+	return m, m.loadTagPlatforms(FocusTags)
+}
+
+func (m Model) updateTagPlatformsMsg(msg tagPlatformsMsg) (tea.Model, tea.Cmd) {
+	var tags []registry.Tag
+	switch msg.focus {
+	case FocusGitHubTags:
+		if !m.githubActive || m.githubImage != msg.image {
+			return m, nil
+		}
+		tags = m.githubTags
+	default:
+		if !m.hasSelectedImage || m.selectedImage.Name != msg.image {
+			return m, nil
+		}
+		tags = m.tags
+	}
+	for i := range tags {
+		if count, ok := msg.platforms[tags[i].Name]; ok {
+			if count <= 0 {
+				count = platformLookupFailed
+			}
+			tags[i].Platforms = count
+		}
+		// Accessory tags are already typed from their name.
+		if kind, ok := msg.artifactTypes[tags[i].Name]; ok && tags[i].ArtifactType == "" {
+			tags[i].ArtifactType = kind
+		}
+	}
 	m.syncTable()
 	return m, nil
 }
 
 func (m Model) updateHistoryMsg(msg historyMsg) (tea.Model, tea.Cmd) {
 	m.stopLoading()
+	if m.dockerHubActive && msg.rateLimit.Limit > 0 {
+		m.dockerHubRateLimit = msg.rateLimit
+	}
 	if msg.err != nil {
-		m.status = fmt.Sprintf("Error loading history: %v", msg.err)
+		m.setErrorStatus(fmt.Sprintf("Error loading history: %s", loadErrorText(msg.err)))
 		m.syncTable()
 		return m, nil
 	}
 	m.history = msg.history
+	m.historyExpanded = nil
+	m.historyShowAll = false
+	m.historyDigest = msg.digest
+	m.historyLabels = msg.labels
+	m.historyAnnotations = msg.annotations
 	m.focus = FocusHistory
 	m.status = fmt.Sprintf("Loaded %d history entries", len(msg.history))
-	m.clearFilter()
+	if len(msg.history) > 0 && msg.history[0].Legacy {
+		m.status += " (legacy schema v1 manifest)"
+	}
+	m.resetFilterOnNavigate()
 	m.syncTable()
 	return m, nil
 }
@@ -194,13 +450,22 @@ func (m Model) updateHistoryMsg(msg historyMsg) (tea.Model, tea.Cmd) {
 func (m Model) updateDockerPullMsg(msg dockerPullMsg) (tea.Model, tea.Cmd) {
 	m.stopLoading()
 	if msg.err != nil {
-		m.status = fmt.Sprintf("Failed to pull %s: %v", msg.reference, msg.err)
+		m.setErrorStatus(fmt.Sprintf("Failed to pull %s: %v", msg.reference, msg.err))
 		return m, nil
 	}
 	m.status = fmt.Sprintf("Pulled %s", msg.reference)
 	return m, nil
 }
 
+func (m Model) updateExportMsg(msg exportMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.setErrorStatus(fmt.Sprintf("Failed to export to %s: %v", msg.path, msg.err))
+		return m, nil
+	}
+	m.status = fmt.Sprintf("Exported %d rows to %s", msg.rows, msg.path)
+	return m, nil
+}
+
 func (m Model) updateDockerHubTagsMsg(msg dockerHubTagsMsg) (tea.Model, tea.Cmd) {
 	m.stopLoading()
 	m.dockerHubLoading = false
@@ -212,9 +477,14 @@ func (m Model) updateDockerHubTagsMsg(msg dockerHubTagsMsg) (tea.Model, tea.Cmd)
 	if msg.err != nil {
 		var rateErr *registry.DockerHubRateLimitError
 		if errors.As(msg.err, &rateErr) {
+			if msg.appendPage && m.dockerHubNext != "" {
+				cmd := m.deferDockerHubPage(false)
+				m.syncTable()
+				return m, cmd
+			}
 			m.status = m.dockerHubRateLimitStatus("Docker Hub rate limit reached")
 		} else {
-			m.status = fmt.Sprintf("Error searching Docker Hub: %v", msg.err)
+			m.setErrorStatus(fmt.Sprintf("Error searching Docker Hub: %s", loadErrorText(msg.err)))
 		}
 		m.syncTable()
 		return m, nil
@@ -225,11 +495,18 @@ func (m Model) updateDockerHubTagsMsg(msg dockerHubTagsMsg) (tea.Model, tea.Cmd)
 		m.dockerHubTags = msg.tags
 		m.clearFilter()
 	}
+	registry.MarkSignedTags(m.dockerHubTags)
 	m.dockerHubImage = msg.image
 	m.dockerHubNext = msg.next
 	m.focus = FocusDockerHubTags
 	m.status = m.dockerHubLoadedStatus()
 	m.syncTable()
+	if msg.digest != "" {
+		return m, m.openExternalDigest(externalModeDockerHub, msg.digest)
+	}
+	if !msg.appendPage && m.externalPendingTag != "" {
+		return m, m.openExternalPendingTag(externalModeDockerHub)
+	}
 	if cmd := m.maybeLoadDockerHubForFilter(); cmd != nil {
 		return m, cmd
 	}
@@ -243,7 +520,7 @@ func (m Model) updateGitHubTagsMsg(msg githubTagsMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 	if msg.err != nil {
-		m.status = fmt.Sprintf("Error searching GHCR: %v", msg.err)
+		m.setErrorStatus(fmt.Sprintf("Error searching GHCR: %s", loadErrorText(msg.err)))
 		m.syncTable()
 		return m, nil
 	}
@@ -253,20 +530,51 @@ func (m Model) updateGitHubTagsMsg(msg githubTagsMsg) (tea.Model, tea.Cmd) {
 		m.githubTags = msg.tags
 		m.clearFilter()
 	}
+	registry.MarkSignedTags(m.githubTags)
 	m.githubImage = msg.image
 	m.githubNext = msg.next
 	m.focus = FocusGitHubTags
 	m.status = m.githubLoadedStatus()
 	m.syncTable()
-	if cmd := m.maybeLoadGitHubForFilter(); cmd != nil {
-		return m, cmd
+	if msg.digest != "" {
+		return m, m.openExternalDigest(externalModeGitHub, msg.digest)
 	}
+	if !msg.appendPage && m.externalPendingTag != "" {
+		return m, m.openExternalPendingTag(externalModeGitHub)
+	}
+	return m, tea.Batch(m.maybeLoadGitHubForFilter(), m.loadTagPlatforms(FocusGitHubTags))
+}
+
+func (m Model) updateGitHubPackagesMsg(msg githubPackagesMsg) (tea.Model, tea.Cmd) {
+	m.stopLoading()
+	m.githubLoading = false
+	if !m.githubActive {
+		return m, nil
+	}
+	if msg.err != nil {
+		if errors.Is(msg.err, registry.ErrGitHubTokenRequired) {
+			m.status = fmt.Sprintf("Set GITHUB_TOKEN to list packages for %s, or search owner/image", msg.owner)
+		} else {
+			m.setErrorStatus(fmt.Sprintf("Error listing GHCR packages: %s", loadErrorText(msg.err)))
+		}
+		m.syncTable()
+		return m, nil
+	}
+	m.githubOwner = msg.owner
+	m.githubPackages = msg.packages
+	m.focus = FocusGitHubPackages
+	m.status = fmt.Sprintf("GHCR: %s (%d packages)", msg.owner, len(msg.packages))
+	m.clearFilter()
+	m.syncTable()
 	return m, nil
 }
 
 func (m Model) updateLogMsg(msg logMsg) (tea.Model, tea.Cmd) {
 	m.appendLog(string(msg))
-	m.syncTable()
+	// Only the request log panel changes size with new entries.
+	if m.debug {
+		m.syncTable()
+	}
 	if m.logCh != nil {
 		return m, listenLogs(m.logCh)
 	}
@@ -274,11 +582,14 @@ func (m Model) updateLogMsg(msg logMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) updateInitClientMsg(msg initClientMsg) (tea.Model, tea.Cmd) {
+	if cmd, ok := m.loginOnUnauthorized(msg.err); ok {
+		return m, cmd
+	}
 	if msg.err != nil {
-		m.status = fmt.Sprintf("Error initializing registry: %v", msg.err)
+		m.setErrorStatus(fmt.Sprintf("Error initializing registry: %v", msg.err))
 		m.authError = msg.err.Error()
 		return m, nil
 	}
 	m.registryClient = msg.client
-	return m, m.initialLoadCmd()
+	return m, m.connectLoadCmd()
 }
@@ -1,11 +1,34 @@
 package tui
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+// Below this size tables collapse to a row or two and modals overlap, so a
+// notice is shown instead until the terminal is resized.
+const (
+	minTerminalWidth  = 40
+	minTerminalHeight = 10
 )
 
+func (m Model) terminalTooSmall() bool {
+	if m.width <= 0 || m.height <= 0 {
+		return false
+	}
+	return m.width < minTerminalWidth || m.height < minTerminalHeight
+}
+
+func (m Model) renderTooSmall() string {
+	message := fmt.Sprintf("Terminal too small (need ≥%dx%d, have %dx%d)", minTerminalWidth, minTerminalHeight, m.width, m.height)
+	message = lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(message)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, emptyStyle.Render(message))
+}
+
 func (m Model) renderApp() string {
 	sections := []string{
 		m.renderTopSection(),
@@ -27,6 +50,9 @@ func (m Model) renderTopSection() string {
 		statusValue = "-"
 	}
 	statusLine := statusStyle.Render(statusValue)
+	if m.statusIsError() {
+		statusLine = lipgloss.JoinHorizontal(lipgloss.Top, statusErrorStyle.Render(statusValue), shortcutHintStyle.Render(" esc to dismiss"))
+	}
 	if m.isLoading() {
 		statusLine = statusLoadingStyle.Render("Loading")
 		if statusValue != "-" {
@@ -41,19 +67,111 @@ func (m Model) renderTopSection() string {
 	metaLine := lipgloss.JoinHorizontal(
 		lipgloss.Top,
 		metaLabelStyle.Render("Context"),
+		m.renderConnDot(),
 		metaValueStyle.Render(contextName),
+		m.renderAuthKind(),
 		metaLabelStyle.Render("Path"),
 		metaValueStyle.Render(pathValue),
+		m.renderHistoryDigest(),
+		m.renderDockerHubRateChip(),
+		m.renderWatchChip(),
 	)
-	lines := []string{
-		headerLine,
-		metaLine,
+	lines := []string{headerLine}
+	if !m.compact {
+		lines = append(lines, metaLine)
 	}
 	if inputLine := m.renderModeInputLine(); inputLine != "" {
 		lines = append(lines, modeInputStyle.Render(inputLine))
 	}
+	if progress := m.renderExternalPageProgress(); progress != "" {
+		lines = append(lines, progress)
+	}
+	if m.commandActive {
+		lines = append(lines, m.renderCommandSuggestions(sectionPanelWidth(m.width)-m.mainSectionHChrome()))
+	}
 	lines = append(lines, shortcutHintStyle.Render(m.renderShortcutHintLine()))
-	return topSectionStyle.Width(sectionPanelWidth(m.width)).Render(strings.Join(lines, "\n"))
+	return m.topSectionStyle().Width(sectionPanelWidth(m.width)).Render(strings.Join(lines, "\n"))
+}
+
+// renderAuthKind labels how the registry is being accessed, to tell an
+// anonymous session from an authenticated one at a glance.
+func (m Model) renderAuthKind() string {
+	if m.registryHost == "" || m.dockerHubActive || m.githubActive {
+		return ""
+	}
+	return authKindStyle.Render("[" + authKindLabel(m.auth) + "]")
+}
+
+func authKindLabel(auth registry.Auth) string {
+	auth.Normalize()
+	switch auth.Kind {
+	case "harbor":
+		if auth.Harbor.Anonymous {
+			return "anon"
+		}
+		return "harbor"
+	case "gcr":
+		return "gcloud"
+	case "registry_v2", "acr":
+		switch {
+		case auth.RegistryV2.Token != "":
+			return "token"
+		case auth.RegistryV2.Anonymous:
+			return "anon"
+		default:
+			return "basic"
+		}
+	default:
+		return "anon"
+	}
+}
+
+// renderHistoryDigest shows the digest the inspected tag resolved to.
+func (m Model) renderHistoryDigest() string {
+	if m.focus != FocusHistory || m.historyDigest == "" {
+		return ""
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, metaLabelStyle.Render("Digest"), metaValueStyle.Render(shortDigest(m.historyDigest)))
+}
+
+// renderDockerHubRateChip shows the last Docker Hub rate limit headers while
+// Docker Hub mode is active, turning amber under 10% and red when exhausted.
+func (m Model) renderDockerHubRateChip() string {
+	limit := m.dockerHubRateLimit
+	if !m.dockerHubActive || limit.Limit <= 0 || limit.Remaining < 0 {
+		return ""
+	}
+	label := fmt.Sprintf("DH rate: %d/%d", limit.Remaining, limit.Limit)
+	if !limit.ResetAt.IsZero() {
+		label += ", reset " + limit.ResetAt.In(m.displayLocation()).Format("15:04:05")
+	}
+	switch {
+	case limit.Remaining == 0:
+		return rateChipEmptyStyle.Render(label)
+	case limit.Remaining*10 <= limit.Limit:
+		return rateChipLowStyle.Render(label)
+	default:
+		return rateChipStyle.Render(label)
+	}
+}
+
+var pageProgressFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// renderExternalPageProgress shows how deep a filter-driven chain of Docker
+// Hub or GHCR tag pages has gone while the next page is fetched.
+func (m Model) renderExternalPageProgress() string {
+	if m.externalFilterPages == 0 {
+		return ""
+	}
+	for _, kind := range []externalModeKind{externalModeDockerHub, externalModeGitHub} {
+		if !m.externalActive(kind) || !m.externalLoading(kind) {
+			continue
+		}
+		frame := pageProgressFrames[m.externalFilterPages%len(pageProgressFrames)]
+		// The first page came from the search itself.
+		return pageProgressStyle.Render(fmt.Sprintf("%s Loaded %d tags, fetching page %d", frame, len(m.externalTags(kind)), m.externalFilterPages+1))
+	}
+	return ""
 }
 
 func (m Model) renderMainSection() string {
@@ -63,6 +181,9 @@ func (m Model) renderMainSection() string {
 	body := m.renderBody()
 	if m.helpActive {
 		titleLabel = "Help"
+		if topic, ok := lookupHelpTopic(m.helpTopic); ok {
+			titleLabel = "Help: " + topic.title
+		}
 		body = m.renderHelpSectionBody()
 	}
 	title := mainSectionTitleStyle.Render(strings.ToUpper(titleLabel))
@@ -74,7 +195,7 @@ func (m Model) renderMainSection() string {
 		titleLine,
 		body,
 	}, "\n")
-	return mainSectionStyle.Width(panelWidth).Render(content)
+	return m.mainSectionStyle().Width(panelWidth).Render(content)
 }
 
 func sectionPanelWidth(width int) int {
@@ -92,7 +213,7 @@ func sectionPanelWidth(width int) int {
 }
 
 func (m Model) mainSectionContentWidth() int {
-	contentWidth := sectionPanelWidth(m.width) - mainSectionHChromeChars
+	contentWidth := sectionPanelWidth(m.width) - m.mainSectionHChrome()
 	if contentWidth < 1 {
 		return 1
 	}
@@ -103,6 +224,9 @@ func (m Model) renderModeInputLine() string {
 	if m.commandActive {
 		return m.commandInput.View()
 	}
+	if m.repoPromptActive {
+		return m.repoInput.View()
+	}
 	if m.filterActive {
 		return m.filterInput.View()
 	}
@@ -135,7 +259,7 @@ func (m Model) renderShortcutHintLine() string {
 }
 
 func (m Model) renderBody() string {
-	view := m.table.View()
+	view := m.highlightFilterMatches(m.table.View())
 	if len(m.table.Rows()) == 0 {
 		return view + "\n" + emptyStyle.Render(m.emptyBodyMessage())
 	}
@@ -0,0 +1,179 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/scottbass3/beacon/internal/config"
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+func TestViewShowsNoticeWhenTerminalTooSmall(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+
+	tests := []struct {
+		name          string
+		width, height int
+		tooSmall      bool
+	}{
+		{name: "narrow", width: 30, height: 30, tooSmall: true},
+		{name: "short", width: 120, height: 8, tooSmall: true},
+		{name: "minimum", width: minTerminalWidth, height: minTerminalHeight},
+		{name: "roomy", width: 120, height: 40},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated, _ := m.Update(tea.WindowSizeMsg{Width: tt.width, Height: tt.height})
+			view := updated.(Model).View()
+			if got := strings.Contains(view, "Terminal too small"); got != tt.tooSmall {
+				t.Fatalf("expected too-small notice %v at %dx%d, got view %q", tt.tooSmall, tt.width, tt.height, view)
+			}
+		})
+	}
+}
+
+func TestConnHealthFollowsRegistryResponses(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	if m.renderConnDot() != "" {
+		t.Fatalf("expected no dot before the first response")
+	}
+
+	steps := []struct {
+		name string
+		msg  tea.Msg
+		want connHealth
+	}{
+		{name: "failed tags", msg: tagsMsg{err: errors.New("connection refused")}, want: connFailing},
+		{name: "loaded images", msg: imagesMsg{}, want: connHealthy},
+		{name: "failed history", msg: historyMsg{err: errors.New("timeout")}, want: connFailing},
+		{name: "unrelated message", msg: exportMsg{}, want: connFailing},
+	}
+	for _, step := range steps {
+		updated, _ := m.Update(step.msg)
+		m = updated.(Model)
+		if m.conn != step.want {
+			t.Fatalf("%s: expected conn %v, got %v", step.name, step.want, m.conn)
+		}
+	}
+	if !strings.Contains(m.renderTopSection(), "●") {
+		t.Fatalf("expected the top bar to show the health dot")
+	}
+
+	m.dockerHubActive = true
+	updated, _ := m.Update(historyMsg{})
+	m = updated.(Model)
+	if m.conn != connFailing {
+		t.Fatalf("expected Docker Hub history to leave the registry health alone")
+	}
+}
+
+func TestSetStatusColorsKeepsUnsetDefaults(t *testing.T) {
+	defaults := statusColors
+	t.Cleanup(func() {
+		statusColors = defaults
+		applyStatusColors()
+	})
+
+	SetStatusColors(config.StatusColors{OK: "#2ecc71", Critical: " "})
+	if got := connHealthyStyle.GetForeground(); got != lipgloss.Color("#2ecc71") {
+		t.Fatalf("expected the ok color on the connection dot, got %v", got)
+	}
+	if got := rateChipStyle.GetBackground(); got != lipgloss.Color("#2ecc71") {
+		t.Fatalf("expected the ok color on the rate chip, got %v", got)
+	}
+	if got := statusErrorStyle.GetBackground(); got != lipgloss.Color(defaults.Critical) {
+		t.Fatalf("expected the default critical color, got %v", got)
+	}
+}
+
+func TestFilterMatchesAreHighlighted(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	t.Cleanup(func() { lipgloss.SetColorProfile(profile) })
+
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.width = 120
+	m.height = 30
+	m.focus = FocusImages
+	m.images = []registry.Image{{Name: "team/alpine"}, {Name: "nginx-alpine"}, {Name: "redis"}}
+	m.filterInput.SetValue("ALP")
+	m.syncTable()
+
+	plain := m.table.View()
+	view := m.renderBody()
+	if !strings.Contains(view, filterMatchSelectedStyle.Render("alp")) {
+		t.Fatalf("expected the cursor row match highlighted, got:\n%q", view)
+	}
+	if !strings.Contains(view, filterMatchStyle.Render("alp")) {
+		t.Fatalf("expected the other row match highlighted, got:\n%q", view)
+	}
+	before, after := strings.Split(plain, "\n"), strings.Split(view, "\n")
+	if len(before) != len(after) {
+		t.Fatalf("expected the same line count, got %d and %d", len(before), len(after))
+	}
+	for i := range before {
+		if lipgloss.Width(before[i]) != lipgloss.Width(after[i]) {
+			t.Fatalf("line %d changed width: %q -> %q", i, before[i], after[i])
+		}
+	}
+}
+
+func TestSuspendAndResumeRedraw(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.filterActive = true
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlZ})
+	if cmd == nil {
+		t.Fatalf("expected ctrl+z to suspend")
+	}
+	if _, ok := cmd().(tea.SuspendMsg); !ok {
+		t.Fatalf("expected a suspend message")
+	}
+
+	_, cmd = m.Update(tea.ResumeMsg{})
+	if cmd == nil {
+		t.Fatalf("expected a redraw after resume")
+	}
+}
+
+func TestAuthKindLabel(t *testing.T) {
+	tests := []struct {
+		name string
+		auth registry.Auth
+		want string
+	}{
+		{name: "anonymous v2", auth: registry.Auth{Kind: "registry_v2", RegistryV2: registry.RegistryV2Auth{Anonymous: true}}, want: "anon"},
+		{name: "basic v2", auth: registry.Auth{Kind: "registry", RegistryV2: registry.RegistryV2Auth{Username: "me"}}, want: "basic"},
+		{name: "token", auth: registry.Auth{Kind: "acr", RegistryV2: registry.RegistryV2Auth{Token: "abc"}}, want: "token"},
+		{name: "harbor", auth: registry.Auth{Kind: "harbor", Harbor: registry.HarborAuth{Username: "me"}}, want: "harbor"},
+		{name: "anonymous harbor", auth: registry.Auth{Kind: "harbor", Harbor: registry.HarborAuth{Anonymous: true}}, want: "anon"},
+		{name: "none", auth: registry.Auth{}, want: "anon"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := authKindLabel(tt.auth); got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	if !strings.Contains(m.renderTopSection(), "[anon]") {
+		t.Fatalf("expected the top bar to show the auth kind")
+	}
+}
@@ -63,6 +63,9 @@ func (m Model) renderAuthModal() string {
 	if m.authUI().ShowRemember {
 		lines = append(lines, remember)
 	}
+	if hint := m.authUI().Hint; hint != "" {
+		lines = append(lines, "", modalLabelStyle.Render(hint))
+	}
 	lines = append(lines,
 		"",
 		modalHelpStyle.Render(strings.ToUpper(help)),
@@ -84,9 +87,22 @@ func (m Model) renderConfirmModal() string {
 		confirmLabel = "Quit"
 		confirmButtonStyle = modalDangerButtonStyle
 		confirmButtonFocusStyle = modalDangerFocusStyle
+	case confirmActionDeleteTags:
+		confirmLabel = "Delete"
+		confirmButtonStyle = modalDangerButtonStyle
+		confirmButtonFocusStyle = modalDangerFocusStyle
+	case confirmActionRetag:
+		confirmLabel = "Retag"
+		confirmButtonStyle = modalDangerButtonStyle
+		confirmButtonFocusStyle = modalDangerFocusStyle
+	case confirmActionRepairConfig:
+		confirmLabel = "Back up"
 	}
 
 	cancel := "Cancel"
+	if m.confirmAction == confirmActionRepairConfig {
+		cancel = "Quit"
+	}
 	if m.confirmFocus == 0 {
 		cancel = modalButtonFocusStyle.Render(cancel)
 	} else {
@@ -0,0 +1,144 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+const (
+	defaultWatchInterval = 30 * time.Second
+	minWatchInterval     = 2 * time.Second
+)
+
+func autoRefreshCmd(id int, interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return autoRefreshMsg{id: id}
+	})
+}
+
+func runWatchCommand(m Model, args []string) (tea.Model, tea.Cmd) {
+	if len(args) == 0 {
+		return m.toggleWatch()
+	}
+	if len(args) > 1 {
+		m.status = "Usage: :watch [<seconds>|off]"
+		return m, nil
+	}
+	arg := strings.ToLower(strings.TrimSpace(args[0]))
+	if arg == "off" || arg == "0" {
+		m.stopWatch()
+		return m, nil
+	}
+	seconds, err := strconv.Atoi(strings.TrimSuffix(arg, "s"))
+	if err != nil || seconds <= 0 {
+		m.status = fmt.Sprintf("Invalid watch interval %q; use a number of seconds", args[0])
+		return m, nil
+	}
+	return m.startWatch(time.Duration(seconds) * time.Second)
+}
+
+func (m Model) toggleWatch() (tea.Model, tea.Cmd) {
+	if m.watchInterval > 0 {
+		m.stopWatch()
+		return m, nil
+	}
+	interval := m.watchDefault
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+	return m.startWatch(interval)
+}
+
+func (m Model) startWatch(interval time.Duration) (tea.Model, tea.Cmd) {
+	if interval < minWatchInterval {
+		interval = minWatchInterval
+	}
+	m.watchID++
+	m.watchInterval = interval
+	m.watchDefault = interval
+	m.status = fmt.Sprintf("Auto-refresh every %s", formatWatchInterval(interval))
+	return m, autoRefreshCmd(m.watchID, interval)
+}
+
+// stopWatch turns auto-refresh off; bumping watchID makes the pending tick a
+// no-op.
+func (m *Model) stopWatch() {
+	m.watchID++
+	m.watchInterval = 0
+	m.status = "Auto-refresh off"
+}
+
+func (m Model) updateAutoRefreshMsg(msg autoRefreshMsg) (tea.Model, tea.Cmd) {
+	if msg.id != m.watchID || m.watchInterval <= 0 {
+		return m, nil
+	}
+	next := autoRefreshCmd(m.watchID, m.watchInterval)
+	if m.isLoading() || m.isTypingInInput() {
+		return m, next
+	}
+	if _, tag, ok := m.selectedTagImageAndTag(); ok && m.focus == FocusTags {
+		m.pendingTag = tag
+	}
+	m.watchRefreshing = !m.dockerHubActive && !m.githubActive && m.focus != FocusHistory
+	refresh := m.refreshCurrent()
+	if refresh == nil {
+		m.watchRefreshing = false
+	}
+	return m, tea.Batch(refresh, next)
+}
+
+// updateWatchedImages swaps in a tick's catalog, keeping the filter, the
+// cursor row and the tag counts that are already known.
+func (m Model) updateWatchedImages(images []registry.Image) (tea.Model, tea.Cmd) {
+	known := make(map[string]int, len(m.images))
+	for _, image := range m.images {
+		if image.TagCount >= 0 {
+			known[image.Name] = image.TagCount
+		}
+	}
+	for i := range images {
+		if count, ok := known[images[i].Name]; ok && images[i].TagCount == -1 {
+			images[i].TagCount = count
+		}
+	}
+	m.images = images
+	if m.tableSpec().SupportsProjects {
+		m.projects = deriveProjects(images)
+	}
+	m.status = m.imagesLoadedStatus()
+	counts := m.startTagCounts(true)
+	m.syncTable()
+	return m, counts
+}
+
+// isTypingInInput reports whether a text input has focus, so a refresh would
+// not yank the view out from under the user.
+func (m Model) isTypingInInput() bool {
+	return m.commandActive ||
+		m.filterActive ||
+		m.repoPromptActive ||
+		(m.dockerHubActive && m.dockerHubInputFocus) ||
+		(m.githubActive && m.githubInputFocus) ||
+		m.isContextFormActive() ||
+		m.isAuthModalActive()
+}
+
+func (m Model) renderWatchChip() string {
+	if m.watchInterval <= 0 {
+		return ""
+	}
+	return rateChipStyle.Render("⟳ " + formatWatchInterval(m.watchInterval))
+}
+
+func formatWatchInterval(interval time.Duration) string {
+	if interval%time.Minute == 0 {
+		return fmt.Sprintf("%dm", int(interval/time.Minute))
+	}
+	return fmt.Sprintf("%ds", int(interval/time.Second))
+}
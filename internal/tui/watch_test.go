@@ -0,0 +1,134 @@
+package tui
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/scottbass3/beacon/internal/config"
+	"github.com/scottbass3/beacon/internal/registry"
+)
+
+func TestWatchCommandSchedulesAndStopsRefresh(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "")
+	m.registryClient = &fakePagedClient{repos: []string{"a"}}
+	m.focus = FocusImages
+
+	updated, cmd := runWatchCommand(m, []string{"10"})
+	m = updated.(Model)
+	if cmd == nil || m.watchInterval != 10*time.Second {
+		t.Fatalf("expected a 10s watch with a scheduled tick, got %s", m.watchInterval)
+	}
+	if chip := m.renderWatchChip(); chip == "" {
+		t.Fatalf("expected a watch indicator in the top section")
+	}
+
+	updated, cmd = m.updateAutoRefreshMsg(autoRefreshMsg{id: m.watchID})
+	m = updated.(Model)
+	if cmd == nil || !m.isLoading() {
+		t.Fatalf("expected a tick to refresh the current view")
+	}
+
+	m.stopLoading()
+	m.filterActive = true
+	updated, cmd = m.updateAutoRefreshMsg(autoRefreshMsg{id: m.watchID})
+	m = updated.(Model)
+	if cmd == nil || m.isLoading() {
+		t.Fatalf("expected the tick to be rescheduled without refreshing while typing")
+	}
+	m.filterActive = false
+
+	stale := m.watchID
+	updated, _ = runWatchCommand(m, []string{"off"})
+	m = updated.(Model)
+	if m.watchInterval != 0 {
+		t.Fatalf("expected :watch off to stop auto-refresh")
+	}
+	if _, cmd = m.updateAutoRefreshMsg(autoRefreshMsg{id: stale}); cmd != nil {
+		t.Fatalf("expected ticks from before :watch off to be ignored")
+	}
+}
+
+func TestWatchTickKeepsPlaceInCatalog(t *testing.T) {
+	auth := registry.Auth{Kind: "registry_v2"}
+	auth.RegistryV2.Anonymous = true
+	m := NewModel("https://registry.example.com", auth, nil, false, nil, nil, "", "").WithSettings(config.Settings{CatalogLimit: 2})
+	m.registryClient = &fakePagedClient{repos: []string{"a", "b", "c", "d", "e"}}
+	updated, _ := m.Update(m.loadCatalogCmd()())
+	m = updated.(Model)
+	updated, _ = m.Update(m.loadMoreImages()())
+	m = updated.(Model)
+	m.images[0].TagCount = 7
+	m.tableSetCursor(3)
+
+	updated, _ = runWatchCommand(m, []string{"10"})
+	m = updated.(Model)
+	updated, cmd := m.updateAutoRefreshMsg(autoRefreshMsg{id: m.watchID})
+	m = updated.(Model)
+	if cmd == nil || !m.watchRefreshing {
+		t.Fatalf("expected the tick to start an in-place reload")
+	}
+	updated, _ = m.Update(m.loadCatalogCmd()())
+	m = updated.(Model)
+	if len(m.images) != 4 || m.imagesNext != "d" {
+		t.Fatalf("expected both loaded pages to be reloaded, got %d images next=%q", len(m.images), m.imagesNext)
+	}
+	if m.table.Cursor() != 3 || m.images[0].TagCount != 7 || m.watchRefreshing {
+		t.Fatalf("expected the cursor and known tag count kept, got cursor %d count %d", m.table.Cursor(), m.images[0].TagCount)
+	}
+
+	m.filterInput.SetValue("c")
+	m.syncTable()
+	m.watchRefreshing = true
+	updated, _ = m.Update(m.loadCatalogCmd()())
+	m = updated.(Model)
+	if m.filterInput.Value() != "c" {
+		t.Fatalf("expected the filter to survive a watch tick, got %q", m.filterInput.Value())
+	}
+}
+
+type fakeArtifactCountClient struct {
+	registry.Client
+	calls int
+}
+
+func (c *fakeArtifactCountClient) ListProjects(context.Context) ([]registry.Project, error) {
+	return []registry.Project{{Name: "library", ImageCount: 2, ArtifactCount: -1}}, nil
+}
+
+func (c *fakeArtifactCountClient) ListProjectImages(context.Context, string) ([]registry.Image, error) {
+	return nil, nil
+}
+
+func (c *fakeArtifactCountClient) ProjectArtifactCounts(context.Context) (map[string]int, error) {
+	c.calls++
+	return map[string]int{"library": 8}, nil
+}
+
+func TestWatchTickKeepsProjectArtifactCounts(t *testing.T) {
+	auth := registry.Auth{Kind: "harbor"}
+	auth.Harbor.Anonymous = true
+	m := NewModel("https://harbor.example.com", auth, nil, false, nil, nil, "", "")
+	client := &fakeArtifactCountClient{}
+	m.registryClient = client
+
+	updated, cmd := m.Update(loadProjectsCmd(client)())
+	m = updated.(Model)
+	updated, _ = m.Update(findMsg[projectArtifactCountsMsg](t, cmd))
+	m = updated.(Model)
+	if m.projects[0].ArtifactCount != 8 {
+		t.Fatalf("expected the artifact total after the project list, got %d", m.projects[0].ArtifactCount)
+	}
+
+	m.watchRefreshing = true
+	updated, cmd = m.Update(loadProjectsCmd(client)())
+	m = updated.(Model)
+	if _, ok := tryFindMsg[projectArtifactCountsMsg](cmd); ok || client.calls != 1 {
+		t.Fatalf("expected a watch tick not to recount artifacts, got %d counts", client.calls)
+	}
+	if m.projects[0].ArtifactCount != 8 {
+		t.Fatalf("expected the known artifact total kept, got %d", m.projects[0].ArtifactCount)
+	}
+}
@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/scottbass3/beacon/internal/contextstore"
+)
+
+// welcomeKeys are the shortcuts a first run needs. Their keys come from
+// shortcutDefinitions so the screen follows the bindings.
+var welcomeKeys = []struct {
+	actions []shortcutAction
+	label   string
+}{
+	{[]shortcutAction{shortcutMoveDown, shortcutMoveUp}, "move through lists"},
+	{[]shortcutAction{shortcutOpenImageTags}, "open the selected row"},
+	{[]shortcutAction{shortcutBack}, "go back"},
+	{[]shortcutAction{shortcutOpenFilter}, "filter the current list"},
+	{[]shortcutAction{shortcutRefresh}, "refresh"},
+	{[]shortcutAction{shortcutOpenCommand}, "run a command (:context, :image, :dockerhub, :github)"},
+	{[]shortcutAction{shortcutOpenHelp}, "show every shortcut"},
+	{[]shortcutAction{shortcutQuit}, "quit"},
+}
+
+func welcomeKeyText(actions []shortcutAction) string {
+	keys := make([]string, 0, len(actions))
+	for _, action := range actions {
+		keys = append(keys, shortcutDefinitions[action].HelpKeys)
+	}
+	return strings.Join(keys, ", ")
+}
+
+// dismissWelcome closes the first-run screen on any key and records it so
+// later launches go straight to the app.
+func (m Model) dismissWelcome() (tea.Model, tea.Cmd) {
+	m.welcomeActive = false
+	if strings.TrimSpace(m.configPath) == "" {
+		return m, nil
+	}
+	if err := contextstore.New(m.configPath).MarkWelcomeSeen(); err != nil {
+		m.setErrorStatus(fmt.Sprintf("Failed to save settings: %v", err))
+	}
+	return m, nil
+}
+
+func (m Model) renderWelcomeModal() string {
+	lines := []string{
+		modalTitleStyle.Render("Welcome to Beacon"),
+		modalDividerStyle.Render(strings.Repeat("─", 24)),
+		"Browse registries, repositories, tags and image history from the terminal.",
+		"",
+	}
+	keyWidth := 0
+	for _, key := range welcomeKeys {
+		keyWidth = maxInt(keyWidth, len(welcomeKeyText(key.actions)))
+	}
+	for _, key := range welcomeKeys {
+		lines = append(lines, modalFocusStyle.Render(fmt.Sprintf("%-*s", keyWidth, welcomeKeyText(key.actions)))+" "+modalLabelStyle.Render(key.label))
+	}
+	lines = append(lines,
+		"",
+		"Next, add a registry context; it is saved to your config file.",
+		"",
+		modalHelpStyle.Render("press any key to continue"),
+	)
+	return m.renderModalCard(strings.Join(lines, "\n"), 72)
+}